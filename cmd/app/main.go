@@ -7,11 +7,22 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	// Embed the zone database so trip and event time zones resolve on hosts without one
+	_ "time/tzdata"
 
 	"github.com/simopzz/traccia/internal/handler"
+	"github.com/simopzz/traccia/internal/infra/airports"
+	"github.com/simopzz/traccia/internal/infra/bcbp"
+	"github.com/simopzz/traccia/internal/infra/bookingmail"
 	"github.com/simopzz/traccia/internal/infra/config"
 	"github.com/simopzz/traccia/internal/infra/database"
+	"github.com/simopzz/traccia/internal/infra/geoexport"
+	"github.com/simopzz/traccia/internal/infra/ical"
+	"github.com/simopzz/traccia/internal/infra/jwtauth"
+	"github.com/simopzz/traccia/internal/infra/pdf"
 	"github.com/simopzz/traccia/internal/infra/server"
+	"github.com/simopzz/traccia/internal/infra/textexport"
+	"github.com/simopzz/traccia/internal/infra/travel"
 	"github.com/simopzz/traccia/internal/repository"
 	"github.com/simopzz/traccia/internal/service"
 )
@@ -52,17 +63,69 @@ func run() error {
 	lodgingDetailsStore := repository.NewLodgingDetailsStore()
 	transitDetailsStore := repository.NewTransitDetailsStore()
 	eventStore := repository.NewEventStore(pool, flightDetailsStore, lodgingDetailsStore, transitDetailsStore)
+	calendarFeedStore := repository.NewCalendarFeedStore(pool)
+	tripShareStore := repository.NewTripShareStore(pool)
+	tripMemberStore := repository.NewTripMemberStore(pool)
+	userStore := repository.NewUserStore(pool)
+	sessionStore := repository.NewSessionStore(pool)
+	tripImportStore := repository.NewTripImportStore(pool, flightDetailsStore, lodgingDetailsStore, transitDetailsStore)
+
+	// Airport directory, built into the binary
+	airportDirectory, err := airports.New()
+	if err != nil {
+		return err
+	}
 
 	// Services
 	tripService := service.NewTripService(tripStore)
-	eventService := service.NewEventService(eventStore)
+	airportService := service.NewAirportService(airportDirectory)
+	eventService := service.NewEventService(eventStore, tripStore).WithAirports(airportService).
+		WithBoardingPasses(bcbp.NewParser())
+	travelService := service.NewTravelService(travel.NewHaversineEstimator(nil)).
+		WithMinConnectionTime(cfg.MinConnectionTime)
+	calendarFeedService := service.NewCalendarFeedService(calendarFeedStore, tripStore, eventStore)
+	exportService := service.NewExportService(pdf.NewExporter(), ical.NewExporter(), geoexport.NewExporter(), textexport.NewExporter())
+	importService := service.NewImportService(eventService, ical.NewParser(), bookingmail.NewReader())
+	archiveService := service.NewArchiveService(tripStore, eventStore, tripImportStore)
+	shareService := service.NewShareService(tripShareStore, tripStore)
+	memberService := service.NewMemberService(tripMemberStore, tripStore, userStore)
+	claimService := service.NewClaimService(tripStore, userStore)
+	authService := service.NewAuthService(userStore, sessionStore).WithSessionTTL(cfg.SessionTTL)
+	if cfg.BearerAuthEnabled() {
+		verifier, jwtErr := jwtauth.New(ctx, jwtauth.Config{
+			Secret:   cfg.JWTSecret,
+			JWKS:     cfg.JWTJWKS,
+			Issuer:   cfg.JWTIssuer,
+			Audience: cfg.JWTAudience,
+			Leeway:   30 * time.Second,
+		})
+		if jwtErr != nil {
+			return jwtErr
+		}
+		authService.WithTokenVerifier(verifier)
+		logger.Info("accepting bearer tokens", "issuer", cfg.JWTIssuer, "audience", cfg.JWTAudience)
+	}
+
+	// Trips from before accounts are invisible to everyone until claimed
+	if ownerless, countErr := claimService.CountOwnerless(ctx); countErr != nil {
+		logger.Warn("counting trips without an owner", "error", countErr)
+	} else if ownerless > 0 {
+		logger.Warn("trips without an owner are hidden from every user; run `just claim-trips <username>` to claim them", "count", ownerless)
+	}
 
 	// Handlers
-	tripHandler := handler.NewTripHandler(tripService, eventService)
-	eventHandler := handler.NewEventHandler(eventService)
+	tripHandler := handler.NewTripHandler(tripService, eventService, travelService, exportService)
+	eventHandler := handler.NewEventHandler(eventService, travelService)
+	feedHandler := handler.NewFeedHandler(calendarFeedService, exportService)
+	archiveHandler := handler.NewArchiveHandler(archiveService)
+	airportHandler := handler.NewAirportHandler(airportService)
+	authHandler := handler.NewAuthHandler(authService, cfg.IsProduction())
+	shareHandler := handler.NewShareHandler(shareService, eventService, travelService)
+	memberHandler := handler.NewMemberHandler(memberService, tripService)
+	importHandler := handler.NewImportHandler(importService, tripService)
 
 	// Router
-	router := handler.NewRouter(tripHandler, eventHandler)
+	router := handler.NewRouter(tripHandler, eventHandler, feedHandler, archiveHandler, airportHandler, authHandler, shareHandler, memberHandler, importHandler)
 
 	// Server
 	srv := server.New(cfg.ServerAddress, router, logger)
@@ -0,0 +1,63 @@
+// Command claim-trips gives the trips created before there were accounts to
+// a user. Those trips have no owner, so no signed-in user can see them until
+// they are claimed. Create the account first, then run:
+//
+//	claim-trips -user <username>
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/simopzz/traccia/internal/infra/config"
+	"github.com/simopzz/traccia/internal/infra/database"
+	"github.com/simopzz/traccia/internal/repository"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+func main() {
+	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo})
+	slog.SetDefault(slog.New(handler))
+
+	username := flag.String("user", "", "Username of the local account to own the trips")
+	flag.Parse()
+
+	if err := run(*username); err != nil {
+		slog.Error("claiming trips failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(username string) error {
+	if username == "" {
+		return errors.New("-user is required")
+	}
+	cfg := config.Load()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("connecting to database: %w", err)
+	}
+	defer pool.Close()
+
+	claimService := service.NewClaimService(repository.NewTripStore(pool), repository.NewUserStore(pool))
+	claimed, err := claimService.ClaimOwnerless(ctx, username)
+	if err != nil {
+		return err
+	}
+	if claimed == 0 {
+		slog.Info("No trips without an owner")
+		return nil
+	}
+
+	slog.Info("Claimed trips without an owner", "user", username, "count", claimed)
+	return nil
+}
@@ -0,0 +1,70 @@
+// Command daterange-report lists events whose date falls outside their trip's
+// date range. Such events were accepted before the event service enforced the
+// range and are never shown on the timeline; move them or widen the trip.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/simopzz/traccia/internal/infra/config"
+	"github.com/simopzz/traccia/internal/infra/database"
+	"github.com/simopzz/traccia/internal/repository"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+func main() {
+	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo})
+	slog.SetDefault(slog.New(handler))
+
+	if err := run(); err != nil {
+		slog.Error("report failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	cfg := config.Load()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("connecting to database: %w", err)
+	}
+	defer pool.Close()
+
+	tripStore := repository.NewTripStore(pool)
+	eventStore := repository.NewEventStore(pool, repository.NewFlightDetailsStore(), repository.NewLodgingDetailsStore(), repository.NewTransitDetailsStore())
+	eventService := service.NewEventService(eventStore, tripStore)
+
+	events, err := eventService.ListOutOfRangeEvents(ctx)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		slog.Info("All events fall inside their trip's date range")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TRIP\tTRIP NAME\tTRIP DATES\tEVENT\tEVENT DATE\tTITLE")
+	for i := range events {
+		e := &events[i]
+		fmt.Fprintf(w, "%d\t%s\t%s – %s\t%d\t%s\t%s\n",
+			e.TripID, e.TripName,
+			e.TripStartDate.Format(time.DateOnly), e.TripEndDate.Format(time.DateOnly),
+			e.EventID, e.EventDate.Format(time.DateOnly), e.Title)
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+
+	slog.Warn("Found events outside their trip's date range", "count", len(events))
+	return nil
+}
@@ -62,7 +62,7 @@ func run() error {
 	eventStore := repository.NewEventStore(pool, flightDetailsStore, lodgingDetailsStore, transitDetailsStore)
 
 	tripService := service.NewTripService(tripStore)
-	eventService := service.NewEventService(eventStore)
+	eventService := service.NewEventService(eventStore, tripStore)
 
 	if *clean {
 		if err := cleanup(ctx, pool); err != nil {
@@ -7,4 +7,5 @@ var (
 	ErrConflict          = errors.New("already exists")
 	ErrInvalidInput      = errors.New("invalid input")
 	ErrDateRangeConflict = errors.New("date range conflict")
+	ErrScheduleConflict  = errors.New("schedule conflict")
 )
@@ -9,7 +9,119 @@ type Trip struct {
 	UpdatedAt   time.Time
 	Name        string
 	Destination string
-	ID          int
+	// TimeZone is the IANA zone (e.g. "Asia/Tokyo") new events default to.
+	TimeZone string
+	// UserID is the UUID of the trip's owner, empty for trips created before
+	// there were accounts.
+	UserID string
+	// Members is everyone on the trip, the owner included.
+	Members []TripMember
+	ID      int
+}
+
+// RoleOf returns the role of userID on the trip, or "" if they are not on it.
+func (t *Trip) RoleOf(userID string) TripRole {
+	if t.UserID != "" && t.UserID == userID {
+		return RoleOwner
+	}
+	for _, m := range t.Members {
+		if m.UserID == userID {
+			return m.Role
+		}
+	}
+	return ""
+}
+
+// TripRole is what a member may do with a trip.
+type TripRole string
+
+const (
+	// RoleOwner may do anything, including deleting the trip and managing
+	// its members. Every trip with an owner has exactly one.
+	RoleOwner TripRole = "owner"
+	// RoleEditor changes the trip and its events.
+	RoleEditor TripRole = "editor"
+	// RoleViewer only reads the trip.
+	RoleViewer TripRole = "viewer"
+)
+
+// IsValidInviteRole reports whether members can be given role; ownership is
+// never handed out by invitation.
+func IsValidInviteRole(role TripRole) bool {
+	return role == RoleEditor || role == RoleViewer
+}
+
+// TripMember is a user on a trip.
+type TripMember struct {
+	CreatedAt time.Time
+	UserID    string
+	// Username is the member's name as shown to the others, taken when they
+	// joined.
+	Username string
+	Role     TripRole
+	TripID   int
+}
+
+// TripInvitation asks a user to join a trip. Invitee is the username or email
+// it was sent to; it turns into a TripMember once they accept.
+type TripInvitation struct {
+	CreatedAt time.Time
+	Invitee   string
+	InvitedBy string // username of the member who sent it
+	TripName  string
+	Role      TripRole
+	ID        int
+	TripID    int
+}
+
+// User is a local account.
+type User struct {
+	CreatedAt time.Time
+	ID        string // UUID
+	Username  string
+	Email     string // optional
+	// PasswordHash is the encoded hash of the password, never the password.
+	PasswordHash string
+}
+
+// Session is a signed-in browser. Only a hash of the session token is kept,
+// the token itself lives in the browser's cookie.
+type Session struct {
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	TokenHash string
+	UserID    string
+}
+
+// TokenIdentity is the user a verified bearer token was issued to.
+type TokenIdentity struct {
+	Subject       string // the user's UUID
+	Email         string // optional
+	EmailVerified bool   // whether the provider has verified Email
+}
+
+// CalendarFeed is a secret subscription URL for one trip's calendar. Anyone
+// holding the token can read the trip, so revoking a feed deletes it.
+type CalendarFeed struct {
+	CreatedAt time.Time
+	Token     string
+	ID        int
+	TripID    int
+}
+
+// TripShare is a secret read-only link to one trip, for people without an
+// account. Like a calendar feed, anyone holding the token can read the trip.
+type TripShare struct {
+	CreatedAt time.Time
+	ExpiresAt *time.Time // nil never expires
+	Token     string
+	ID        int
+	TripID    int
+}
+
+// Expired reports whether the link stopped working at or before now.
+func (s *TripShare) Expired(now time.Time) bool {
+	return s.ExpiresAt != nil && !now.Before(*s.ExpiresAt)
 }
 
 type EventCategory string
@@ -55,10 +167,61 @@ type FlightDetails struct {
 	DepartureGate     string
 	ArrivalGate       string
 	BookingReference  string
-	EventID           int
+	// DepartureTimeZone and ArrivalTimeZone are the IANA zones of the two
+	// airports. Empty falls back to the event's own zone.
+	DepartureTimeZone string
+	ArrivalTimeZone   string
+	// Segments are the legs of a connecting itinerary in flying order, all
+	// under the one booking. A direct flight has none; for a connecting one
+	// the fields above describe the journey from the first departure to the
+	// last arrival.
+	Segments []FlightSegment
+	EventID  int
+	ID       int
+}
+
+// FlightSegment is one leg of a connecting flight itinerary.
+type FlightSegment struct {
+	DepartureTime     time.Time
+	ArrivalTime       time.Time
+	Airline           string
+	FlightNumber      string
+	DepartureAirport  string
+	ArrivalAirport    string
+	DepartureTerminal string
+	ArrivalTerminal   string
+	DepartureGate     string
+	ArrivalGate       string
+	DepartureTimeZone string
+	ArrivalTimeZone   string
 	ID                int
 }
 
+// Layover is the wait between two segments of a connecting flight. The two
+// airports differ when the connection means changing airport.
+type Layover struct {
+	ArrivalAirport   string
+	DepartureAirport string
+	Duration         time.Duration
+	// Short is set when the layover is under the minimum connection time.
+	Short bool
+}
+
+// Layovers returns the waits between consecutive segments, none for a
+// direct flight.
+func (fd *FlightDetails) Layovers() []Layover {
+	var layovers []Layover
+	for i := 1; i < len(fd.Segments); i++ {
+		prev, next := &fd.Segments[i-1], &fd.Segments[i]
+		layovers = append(layovers, Layover{
+			ArrivalAirport:   prev.ArrivalAirport,
+			DepartureAirport: next.DepartureAirport,
+			Duration:         next.DepartureTime.Sub(prev.ArrivalTime),
+		})
+	}
+	return layovers
+}
+
 type TransitDetails struct {
 	Origin        string
 	Destination   string
@@ -82,8 +245,142 @@ type Event struct {
 	Title     string
 	Location  string
 	Notes     string
-	ID        int
-	TripID    int
-	Position  int
-	Pinned    bool
+	// TimeZone is the IANA zone the event's wall-clock times were entered in.
+	TimeZone string
+	ID       int
+	TripID   int
+	Position int
+	Pinned   bool
+}
+
+// ConflictSeverity ranks an overlap between two events on the same day.
+type ConflictSeverity string
+
+const (
+	// ConflictWarning marks an overlap that can likely be resolved by reordering.
+	ConflictWarning ConflictSeverity = "warning"
+	// ConflictCritical marks an overlap between two pinned events or involving a
+	// flight, where neither side can simply be shifted.
+	ConflictCritical ConflictSeverity = "critical"
+)
+
+// Conflict describes two events whose time ranges overlap.
+type Conflict struct {
+	Severity ConflictSeverity
+	Overlap  time.Duration
+	EventID  int
+	OtherID  int
+}
+
+// TravelMode is how a traveller gets from one event to the next.
+type TravelMode string
+
+const (
+	TravelWalking TravelMode = "walking"
+	TravelTransit TravelMode = "transit"
+	TravelDriving TravelMode = "driving"
+)
+
+// Coordinates is a point on the globe in decimal degrees.
+type Coordinates struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Airport is a commercial airport as known to the airport directory.
+type Airport struct {
+	IATA    string
+	ICAO    string
+	Name    string
+	City    string
+	Country string // ISO 3166-1 alpha-2
+	// TimeZone is the IANA zone of the airport's local time.
+	TimeZone  string
+	Latitude  float64
+	Longitude float64
+}
+
+// TightConnection flags two consecutive events where the gap between them is
+// shorter than the estimated time needed to travel from one to the other.
+type TightConnection struct {
+	Mode     TravelMode
+	Gap      time.Duration
+	Estimate time.Duration
+	FromID   int
+	ToID     int
+}
+
+// CalendarEntry is one event as read from an iCalendar file.
+type CalendarEntry struct {
+	Start       time.Time
+	End         time.Time
+	Latitude    *float64
+	Longitude   *float64
+	UID         string
+	Summary     string
+	Location    string
+	Description string
+	Categories  string
+	// TimeZone is the IANA zone of the start, empty for UTC and floating times.
+	TimeZone string
+	Duration time.Duration
+	AllDay   bool
+	HasEnd   bool
+}
+
+// Reservation is a flight or hotel booking, as read from a confirmation email.
+type Reservation struct {
+	// Start is the departure or check-in. End is the arrival or check-out,
+	// zero when the booking does not give it.
+	Start     time.Time
+	End       time.Time
+	Latitude  *float64
+	Longitude *float64
+	// Flight is set for flights, which have no Name or Address.
+	Flight           *FlightDetails
+	Name             string
+	Address          string
+	BookingReference string
+	// StartDateOnly and EndDateOnly mark stays booked by date, without times.
+	StartDateOnly bool
+	EndDateOnly   bool
+	Cancelled     bool
+}
+
+// BoardingPass is the mandatory data of an IATA Bar Coded Boarding Pass.
+type BoardingPass struct {
+	PassengerName string
+	Legs          []BoardingPassLeg
+}
+
+// BoardingPassLeg is one flight of a boarding pass. The flight date is only
+// given as a day of the year.
+type BoardingPassLeg struct {
+	BookingReference string
+	From             string
+	To               string
+	Carrier          string
+	FlightNumber     string
+	Seat             string
+	DayOfYear        int
+}
+
+// Date resolves the leg's day of the year to the date nearest ref.
+func (l *BoardingPassLeg) Date(ref time.Time) time.Time {
+	var best time.Time
+	for year := ref.Year() - 1; year <= ref.Year()+1; year++ {
+		d := time.Date(year, time.January, l.DayOfYear, 0, 0, 0, 0, time.UTC)
+		if d.Year() != year {
+			continue // day 366 of a common year
+		}
+		if best.IsZero() || d.Sub(ref).Abs() < best.Sub(ref).Abs() {
+			best = d
+		}
+	}
+	return best
+}
+
+// Flight is the carrier code joined to the flight number, as in "AZ610".
+func (l *BoardingPassLeg) Flight() string {
+	return l.Carrier + l.FlightNumber
 }
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"io"
 	"time"
 )
 
@@ -11,9 +12,34 @@ type DateEventCount struct {
 	Count int
 }
 
+// OutOfRangeEvent identifies an event whose date falls outside its trip's date range.
+type OutOfRangeEvent struct {
+	EventDate     time.Time
+	TripStartDate time.Time
+	TripEndDate   time.Time
+	Title         string
+	TripName      string
+	EventID       int
+	TripID        int
+}
+
+// ItineraryDay is one day of a trip as exported documents lay it out: its
+// events in timeline order and the lodging stays that touch it.
+type ItineraryDay struct {
+	Date   time.Time
+	Events []Event
+	Stays  []DayStay
+	Number int
+}
+
+// TripRepository stores trips. Trips it returns carry their Members.
 type TripRepository interface {
+	// Create stores the trip and records its owner, from trip.Members, as a
+	// member.
 	Create(ctx context.Context, trip *Trip) error
 	GetByID(ctx context.Context, id int) (*Trip, error)
+	// List returns the trips userID is a member of, or the trips without an
+	// owner when userID is nil.
 	List(ctx context.Context, userID *string) ([]Trip, error)
 	Update(ctx context.Context, id int, updater func(*Trip) *Trip) (*Trip, error)
 	Delete(ctx context.Context, id int) error
@@ -24,10 +50,153 @@ type TripRepository interface {
 type EventRepository interface {
 	Create(ctx context.Context, event *Event) error
 	GetByID(ctx context.Context, id int) (*Event, error)
+	GetByTripAndID(ctx context.Context, tripID, id int) (*Event, error)
 	ListByTrip(ctx context.Context, tripID int) ([]Event, error)
 	ListByTripAndDate(ctx context.Context, tripID int, date time.Time) ([]Event, error)
 	Update(ctx context.Context, id int, updater func(*Event) *Event) (*Event, error)
 	Delete(ctx context.Context, id int) error
-	Restore(ctx context.Context, id int) (*Event, error)
+	Restore(ctx context.Context, tripID, id int) (*Event, error)
+	Reorder(ctx context.Context, tripID int, date time.Time, orderedIDs []int) error
+	MoveToDay(ctx context.Context, id int, eventDate, startTime, endTime time.Time) (*Event, error)
 	CountByTrip(ctx context.Context, tripID int) (int, error)
+	ListOutsideTripRange(ctx context.Context) ([]OutOfRangeEvent, error)
+	ListLodgingByTrip(ctx context.Context, tripID int) ([]Event, error)
+}
+
+// TripImporter creates a trip together with its events, all or nothing. Events
+// keep their dates, positions and details; IDs are assigned on the way in.
+type TripImporter interface {
+	Import(ctx context.Context, trip *Trip, events []Event) error
+}
+
+// TripClaimer hands the trips created before there were accounts, which have
+// no owner, to a user.
+type TripClaimer interface {
+	CountOwnerless(ctx context.Context) (int, error)
+	// ClaimOwnerless makes owner the owner of every ownerless trip and
+	// returns how many there were.
+	ClaimOwnerless(ctx context.Context, owner TripMember) (int, error)
+}
+
+type CalendarFeedRepository interface {
+	Create(ctx context.Context, feed *CalendarFeed) error
+	GetByToken(ctx context.Context, token string) (*CalendarFeed, error)
+	ListByTrip(ctx context.Context, tripID int) ([]CalendarFeed, error)
+	Delete(ctx context.Context, tripID, id int) error
+	// TripLastModified is the latest change to the trip or any of its events,
+	// including deleted ones.
+	TripLastModified(ctx context.Context, tripID int) (time.Time, error)
+}
+
+// TripShareRepository stores read-only share links. Methods taking a trip ID
+// and a share ID report shares of other trips as ErrNotFound.
+type TripShareRepository interface {
+	Create(ctx context.Context, share *TripShare) error
+	GetByToken(ctx context.Context, token string) (*TripShare, error)
+	ListByTrip(ctx context.Context, tripID int) ([]TripShare, error)
+	Delete(ctx context.Context, tripID, id int) error
+	// Rotate replaces the share's token, so the old link stops working.
+	Rotate(ctx context.Context, tripID, id int, token string) (*TripShare, error)
+	// SetExpiry changes when the share stops working; nil never expires.
+	SetExpiry(ctx context.Context, tripID, id int, expiresAt *time.Time) (*TripShare, error)
+}
+
+// TripMemberRepository stores who is on which trip besides its owner, and
+// the invitations that are still pending. Methods taking a trip ID report
+// members and invitations of other trips as ErrNotFound.
+type TripMemberRepository interface {
+	SetRole(ctx context.Context, tripID int, userID string, role TripRole) (*TripMember, error)
+	Remove(ctx context.Context, tripID int, userID string) error
+	// CreateInvitation reports an invitee already invited to the trip as
+	// ErrConflict.
+	CreateInvitation(ctx context.Context, invitation *TripInvitation) error
+	GetInvitation(ctx context.Context, id int) (*TripInvitation, error)
+	ListInvitationsByTrip(ctx context.Context, tripID int) ([]TripInvitation, error)
+	// ListInvitationsFor returns the invitations sent to any of invitees.
+	ListInvitationsFor(ctx context.Context, invitees []string) ([]TripInvitation, error)
+	DeleteInvitation(ctx context.Context, tripID, id int) error
+	// AcceptInvitation turns the invitation into a membership of member.
+	AcceptInvitation(ctx context.Context, id int, member *TripMember) error
+}
+
+// UserRepository stores local accounts. Usernames and emails are unique;
+// Create reports a taken one as ErrConflict.
+type UserRepository interface {
+	Create(ctx context.Context, user *User) error
+	GetByID(ctx context.Context, id string) (*User, error)
+	GetByUsername(ctx context.Context, username string) (*User, error)
+}
+
+// SessionRepository stores signed-in sessions by the hash of their token.
+type SessionRepository interface {
+	Create(ctx context.Context, session *Session) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*Session, error)
+	Delete(ctx context.Context, tokenHash string) error
+	DeleteExpired(ctx context.Context) error
+}
+
+// TravelTimeEstimator estimates how long it takes to travel between two points
+// using the given mode.
+type TravelTimeEstimator interface {
+	Estimate(ctx context.Context, from, to Coordinates, mode TravelMode) (time.Duration, error)
+}
+
+// AirportDirectory finds airports by code or by name.
+type AirportDirectory interface {
+	// Lookup finds an airport by IATA or ICAO code, ignoring case.
+	Lookup(code string) (*Airport, bool)
+	// Search returns up to limit airports whose code, name or city matches
+	// query, best matches first.
+	Search(query string, limit int) []Airport
+}
+
+// CalendarExporter writes a trip's events as an iCalendar file.
+type CalendarExporter interface {
+	ExportCalendar(w io.Writer, trip *Trip, events []Event) error
+}
+
+// MapExporter writes the places of a trip's days as map files.
+type MapExporter interface {
+	ExportGPX(w io.Writer, trip *Trip, days []ItineraryDay) error
+	ExportKML(w io.Writer, trip *Trip, days []ItineraryDay) error
+	ExportGeoJSON(w io.Writer, trip *Trip, days []ItineraryDay) error
+}
+
+// ItineraryExporter writes a trip's day-by-day itinerary as text.
+type ItineraryExporter interface {
+	ExportMarkdown(w io.Writer, trip *Trip, days []ItineraryDay) error
+	ExportText(w io.Writer, trip *Trip, days []ItineraryDay) error
+}
+
+// PDFExporter renders a trip's day-by-day itinerary as a printable PDF.
+type PDFExporter interface {
+	ExportPDF(ctx context.Context, w io.Writer, trip *Trip, days []ItineraryDay) error
+}
+
+// TokenVerifier checks bearer tokens issued by an external identity provider.
+type TokenVerifier interface {
+	// Verify checks token's signature, issuer, audience and expiry and
+	// returns who it was issued to. Invalid tokens are ErrInvalidInput.
+	Verify(ctx context.Context, token string) (*TokenIdentity, error)
+}
+
+// CalendarParser reads the events of an iCalendar file.
+type CalendarParser interface {
+	// ParseCalendar reads the VEVENTs of data. Floating times, and zones that
+	// are not IANA names, are read in loc.
+	ParseCalendar(data string, loc *time.Location) ([]CalendarEntry, error)
+}
+
+// BoardingPassReader reads the barcode text of boarding passes.
+type BoardingPassReader interface {
+	ReadBoardingPass(data string) (*BoardingPass, error)
+}
+
+// ReservationReader reads the bookings described by schema.org markup in
+// confirmation emails.
+type ReservationReader interface {
+	// ReadReservations returns the flights and stays in an RFC 822 message
+	// that name a departure or check-in. Times without an offset are read in
+	// loc.
+	ReadReservations(data []byte, loc *time.Location) ([]Reservation, error)
 }
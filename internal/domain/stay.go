@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// StayKind is how a lodging stay shows up on one day of the timeline.
+type StayKind string
+
+const (
+	StayCheckIn  StayKind = "check-in"
+	StayNight    StayKind = "staying"
+	StayCheckOut StayKind = "check-out"
+)
+
+// DayStay is a lodging event's marker on one day: its check-in, an
+// intermediate night, or its check-out.
+type DayStay struct {
+	Lodging *Event
+	Kind    StayKind
+}
+
+// StayDates returns the local calendar dates (at midnight UTC, like EventDate)
+// of a lodging event's check-in and check-out. Check-in falls back to the
+// event's start day. ok is false unless the event is lodging with a check-out
+// on a later day than check-in.
+func (e *Event) StayDates() (checkIn, checkOut time.Time, ok bool) {
+	if e.Category != CategoryLodging || e.Lodging == nil || e.Lodging.CheckOutTime == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	loc := ZoneOrUTC(e.TimeZone)
+	start := e.LocalStart()
+	if e.Lodging.CheckInTime != nil {
+		start = e.Lodging.CheckInTime.In(loc)
+	}
+	end := e.Lodging.CheckOutTime.In(loc)
+	checkIn = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	checkOut = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	if !checkOut.After(checkIn) {
+		return time.Time{}, time.Time{}, false
+	}
+	return checkIn, checkOut, true
+}
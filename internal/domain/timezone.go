@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultTimeZone is used for trips created without an explicit zone.
+const DefaultTimeZone = "UTC"
+
+// LoadTimeZone resolves an IANA zone name. An empty name resolves to UTC.
+func LoadTimeZone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown time zone %q", ErrInvalidInput, name)
+	}
+	return loc, nil
+}
+
+// ZoneOrUTC is LoadTimeZone for display paths, where a zone that was valid
+// when stored but is no longer known should not break rendering.
+func ZoneOrUTC(name string) *time.Location {
+	loc, err := LoadTimeZone(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// StartZone is the zone the event starts in: a flight's departure zone when
+// set, otherwise the event's own zone.
+func (e *Event) StartZone() string {
+	if e.Flight != nil && e.Flight.DepartureTimeZone != "" {
+		return e.Flight.DepartureTimeZone
+	}
+	return e.TimeZone
+}
+
+// EndZone is the zone the event ends in: a flight's arrival zone when set,
+// otherwise the event's own zone.
+func (e *Event) EndZone() string {
+	if e.Flight != nil && e.Flight.ArrivalTimeZone != "" {
+		return e.Flight.ArrivalTimeZone
+	}
+	return e.TimeZone
+}
+
+// LocalStart returns StartTime as wall-clock time in StartZone.
+func (e *Event) LocalStart() time.Time {
+	return e.StartTime.In(ZoneOrUTC(e.StartZone()))
+}
+
+// LocalEnd returns EndTime as wall-clock time in EndZone.
+func (e *Event) LocalEnd() time.Time {
+	return e.EndTime.In(ZoneOrUTC(e.EndZone()))
+}
@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/a-h/templ"
+
+	"github.com/simopzz/traccia/internal/service"
+)
+
+type AirportHandler struct {
+	airportService *service.AirportService
+}
+
+func NewAirportHandler(airportService *service.AirportService) *AirportHandler {
+	return &AirportHandler{airportService: airportService}
+}
+
+// Options suggests airports for the text typed in an airport field, as the
+// options of the page's airport datalist.
+func (h *AirportHandler) Options(w http.ResponseWriter, r *http.Request) {
+	templ.Handler(AirportOptionList(h.airportService.Search(r.URL.Query().Get("q")))).ServeHTTP(w, r)
+}
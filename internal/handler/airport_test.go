@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/infra/airports"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+func newTestAirportHandler(t *testing.T) *AirportHandler {
+	t.Helper()
+	directory, err := airports.New()
+	if err != nil {
+		t.Fatalf("airports.New() error = %v", err)
+	}
+	return NewAirportHandler(service.NewAirportService(directory))
+}
+
+func TestEventHandler_Create_FlightAirports(t *testing.T) {
+	directory, err := airports.New()
+	if err != nil {
+		t.Fatalf("airports.New() error = %v", err)
+	}
+
+	tests := []struct {
+		wantStart   time.Time
+		wantEnd     time.Time
+		name        string
+		airports    string
+		wantArrival string
+		wantCode    int
+	}{
+		{
+			name:        "times are read in the airports' zones",
+			airports:    "departure_airport=lhr&arrival_airport=RJAA",
+			wantCode:    http.StatusOK,
+			wantArrival: "NRT",
+			wantStart:   time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC),
+			wantEnd:     time.Date(2026, 6, 1, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "airport names are kept",
+			airports:    "departure_airport=lhr&arrival_airport=Aosta+airfield",
+			wantCode:    http.StatusOK,
+			wantArrival: "Aosta airfield",
+			wantStart:   time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC),
+			wantEnd:     time.Date(2026, 6, 1, 8, 0, 0, 0, time.UTC).AddDate(0, 0, 1),
+		},
+		{
+			name:     "unknown airport",
+			airports: "departure_airport=LHR&arrival_airport=QQQ",
+			wantCode: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockEventRepo{}
+			svc := service.NewEventService(repo, &mockTripRepo{}).WithAirports(service.NewAirportService(directory))
+			h := NewEventHandler(svc, newTestTravelService())
+
+			form := "title=To Tokyo&category=flight&date=2026-06-01&start_time=11:00&end_time=08:00&" + tt.airports
+			r := httptest.NewRequest("POST", "/trips/1/events", strings.NewReader(form))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			r.Header.Set("HX-Request", "true")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("tripID", "1")
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+			h.Create(w, r)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				if !strings.Contains(w.Body.String(), "unknown airport") {
+					t.Errorf("form missing the airport error")
+				}
+				return
+			}
+			e := repo.capturedEvent
+			if e.Flight.DepartureAirport != "LHR" || e.Flight.ArrivalAirport != tt.wantArrival {
+				t.Errorf("airports = %s → %s, want LHR → %s", e.Flight.DepartureAirport, e.Flight.ArrivalAirport, tt.wantArrival)
+			}
+			if !e.StartTime.Equal(tt.wantStart) || !e.EndTime.Equal(tt.wantEnd) {
+				t.Errorf("times = %v – %v, want %v – %v", e.StartTime.UTC(), e.EndTime.UTC(), tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestAirportHandler_Options(t *testing.T) {
+	h := newTestAirportHandler(t)
+
+	r := httptest.NewRequest("GET", "/airports?q=fco&departure_airport=fco", http.NoBody)
+	w := httptest.NewRecorder()
+	h.Options(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if want := `<option value="FCO">Leonardo da Vinci–Fiumicino Airport, Rome (IT)</option>`; !strings.HasPrefix(w.Body.String(), want) {
+		t.Errorf("body = %q, want it to start with %q", w.Body.String(), want)
+	}
+}
+
+func TestFlightCardContent_AirportNames(t *testing.T) {
+	h := newTestAirportHandler(t)
+	svc := service.NewEventService(&mockEventRepo{}, &mockTripRepo{}).WithAirports(h.airportService)
+	fd := &domain.FlightDetails{DepartureAirport: "FCO", ArrivalAirport: "XYZ"}
+
+	render := func(names map[string]string) string {
+		var b strings.Builder
+		if err := FlightCardContent(fd, "UTC", nil, names).Render(context.Background(), &b); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		return b.String()
+	}
+
+	named := render(svc.AirportNames([]domain.Event{{Flight: fd}}))
+	if !strings.Contains(named, "Leonardo da Vinci–Fiumicino Airport → XYZ") {
+		t.Errorf("card missing airport names: %s", named)
+	}
+
+	// Without names the card shows codes only
+	if plain := render(nil); strings.Contains(plain, "Fiumicino") {
+		t.Errorf("card without names has names: %s", plain)
+	}
+}
+
+func TestEventHandler_Create_ConnectingFlight(t *testing.T) {
+	directory, err := airports.New()
+	if err != nil {
+		t.Fatalf("airports.New() error = %v", err)
+	}
+	repo := &mockEventRepo{}
+	svc := service.NewEventService(repo, &mockTripRepo{}).WithAirports(service.NewAirportService(directory))
+	h := NewEventHandler(svc, newTestTravelService())
+
+	// No journey times or airports: they come from the segments. The blank
+	// third row is an editor row left unused.
+	segment := func(airline, number, from, to, dep, arr string) string {
+		return "&segment_airline=" + airline + "&segment_flight_number=" + number +
+			"&segment_departure_airport=" + from + "&segment_arrival_airport=" + to +
+			"&segment_departure_time=" + dep + "&segment_arrival_time=" + arr
+	}
+	form := "title=To Tokyo&category=flight&date=2026-06-01" +
+		segment("LH", "901", "lhr", "FRA", "07:00", "09:40") +
+		segment("LH", "716", "FRA", "NRT", "10:30", "06:15") +
+		segment("", "", "", "", "", "")
+	r := httptest.NewRequest("POST", "/trips/1/events", strings.NewReader(form))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("HX-Request", "true")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("tripID", "1")
+	r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+	h.Create(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	e := repo.capturedEvent
+	if len(e.Flight.Segments) != 2 {
+		t.Fatalf("segments = %+v, want 2", e.Flight.Segments)
+	}
+	if e.Flight.DepartureAirport != "LHR" || e.Flight.ArrivalAirport != "NRT" || e.Flight.Airline != "LH" {
+		t.Errorf("journey = %s %s → %s, want LH LHR → NRT", e.Flight.Airline, e.Flight.DepartureAirport, e.Flight.ArrivalAirport)
+	}
+	// 07:00 in London and, the next morning, 06:15 in Tokyo
+	wantStart := time.Date(2026, 6, 1, 6, 0, 0, 0, time.UTC)
+	wantEnd := time.Date(2026, 6, 1, 21, 15, 0, 0, time.UTC)
+	if !e.StartTime.Equal(wantStart) || !e.EndTime.Equal(wantEnd) {
+		t.Errorf("times = %v – %v, want %v – %v", e.StartTime.UTC(), e.EndTime.UTC(), wantStart, wantEnd)
+	}
+	if second := e.Flight.Segments[1]; second.DepartureTimeZone != "Europe/Berlin" || !second.DepartureTime.Equal(time.Date(2026, 6, 1, 8, 30, 0, 0, time.UTC)) {
+		t.Errorf("second segment departs %v in %q", second.DepartureTime.UTC(), second.DepartureTimeZone)
+	}
+}
+
+func TestFlightCardContent_Layovers(t *testing.T) {
+	at := func(hour, minute int) time.Time { return time.Date(2026, 6, 1, hour, minute, 0, 0, time.UTC) }
+	fd := &domain.FlightDetails{DepartureAirport: "LHR", ArrivalAirport: "NRT", Segments: []domain.FlightSegment{
+		{Airline: "LH", FlightNumber: "901", DepartureAirport: "LHR", ArrivalAirport: "FRA", DepartureTime: at(6, 0), ArrivalTime: at(7, 40),
+			DepartureTimeZone: "Europe/London", ArrivalTimeZone: "Europe/Berlin"},
+		{Airline: "LH", FlightNumber: "716", DepartureAirport: "FRA", ArrivalAirport: "NRT", DepartureTime: at(8, 30), ArrivalTime: at(21, 15),
+			DepartureTimeZone: "Europe/Berlin", ArrivalTimeZone: "Asia/Tokyo"},
+	}}
+
+	tests := []struct {
+		name     string
+		layovers []domain.Layover
+		want     []string
+		notWant  string
+	}{
+		{
+			name:     "flagged short",
+			layovers: []domain.Layover{{ArrivalAirport: "FRA", DepartureAirport: "FRA", Duration: 50 * time.Minute, Short: true}},
+			want:     []string{"LHR → FRA", "LH 901", "7:00 AM – 9:40 AM", "10:30 AM – 6:15 AM +1", "Layover in FRA · 50 min", "(short connection)"},
+		},
+		{
+			name:    "worked out from the segments",
+			want:    []string{"Layover in FRA · 50 min"},
+			notWant: "short connection",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := FlightCardContent(fd, "UTC", tt.layovers, nil).Render(context.Background(), &b); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(b.String(), want) {
+					t.Errorf("card missing %q: %s", want, b.String())
+				}
+			}
+			if tt.notWant != "" && strings.Contains(b.String(), tt.notWant) {
+				t.Errorf("card has %q: %s", tt.notWant, b.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/a-h/templ"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+// maxArchiveUpload bounds restored archives; a large trip is a few hundred KB.
+const maxArchiveUpload = 10 << 20
+
+type ArchiveHandler struct {
+	archiveService *service.ArchiveService
+}
+
+func NewArchiveHandler(archiveService *service.ArchiveService) *ArchiveHandler {
+	return &ArchiveHandler{archiveService: archiveService}
+}
+
+// Export downloads the trip as a versioned JSON archive.
+func (h *ArchiveHandler) Export(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return
+	}
+
+	archive, err := h.archiveService.Export(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Trip not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to export trip", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="trip-%d.json"`, id))
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(archive)
+}
+
+// Import restores an archive as a new trip. The archive is either the request
+// body, when sent as application/json, or the "archive" file of a form upload.
+func (h *ArchiveHandler) Import(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxArchiveUpload)
+
+	var body io.Reader = r.Body
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		file, _, err := r.FormFile("archive")
+		if err != nil {
+			h.renderError(w, r, "Choose a trip backup file to restore")
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	var archive service.TripArchive
+	if err := json.NewDecoder(body).Decode(&archive); err != nil {
+		h.renderError(w, r, "The file is not a valid trip backup")
+		return
+	}
+
+	trip, err := h.archiveService.Import(r.Context(), &archive)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) || errors.Is(err, domain.ErrDateRangeConflict) {
+			h.renderError(w, r, "The backup could not be restored: "+formErrorMessage(err))
+			return
+		}
+		http.Error(w, "Failed to restore trip", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/trips/"+strconv.Itoa(trip.ID), http.StatusSeeOther)
+}
+
+// renderError shows a rejected archive on the new trip page, where the
+// restore form lives.
+func (h *ArchiveHandler) renderError(w http.ResponseWriter, r *http.Request, msg string) {
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	templ.Handler(TripNewPage(nil, &FormErrors{General: msg})).ServeHTTP(w, r)
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/simopzz/traccia/internal/service"
+)
+
+func TestArchiveHandler_Import_Rejected(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantMessage string
+	}{
+		{name: "not JSON", contentType: "application/json", body: "BEGIN:VCALENDAR", wantMessage: "not a valid trip backup"},
+		{name: "newer version", contentType: "application/json", body: `{"version": 99, "trip": {"name": "Rome"}}`, wantMessage: "unsupported archive version 99"},
+		{name: "no file", contentType: "application/x-www-form-urlencoded", body: "", wantMessage: "Choose a trip backup file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewArchiveHandler(service.NewArchiveService(&mockTripRepo{}, &mockEventRepo{}, nil))
+			r := httptest.NewRequest("POST", "/trips/restore", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			h.Import(w, r)
+
+			if w.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("Import() status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantMessage) {
+				t.Errorf("response does not mention %q", tt.wantMessage)
+			}
+		})
+	}
+}
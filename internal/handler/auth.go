@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+// sessionCookie holds the session token of a signed-in browser.
+const sessionCookie = "traccia_session"
+
+type AuthHandler struct {
+	authService *service.AuthService
+	// secureCookies marks the session cookie Secure, for deployments behind HTTPS.
+	secureCookies bool
+}
+
+func NewAuthHandler(authService *service.AuthService, secureCookies bool) *AuthHandler {
+	return &AuthHandler{authService: authService, secureCookies: secureCookies}
+}
+
+// Middleware lets only signed-in users through, acting for them in the
+// request context. Requests with an Authorization bearer token are checked
+// against the token alone; everyone else needs a session cookie and is sent
+// to the login page without one.
+func (h *AuthHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token, ok := bearerToken(r); ok {
+			p, err := h.authService.AuthenticateBearer(r.Context(), token)
+			if err != nil {
+				if !errors.Is(err, domain.ErrInvalidInput) {
+					slog.ErrorContext(r.Context(), "authenticating bearer token", "error", err)
+				}
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				http.Error(w, "Invalid bearer token", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(service.WithPrincipal(r.Context(), p)))
+			return
+		}
+
+		var user *domain.User
+		if cookie, err := r.Cookie(sessionCookie); err == nil {
+			user, err = h.authService.Authenticate(r.Context(), cookie.Value)
+			if err != nil && !errors.Is(err, domain.ErrNotFound) {
+				slog.ErrorContext(r.Context(), "authenticating session", "error", err)
+				http.Error(w, "Failed to check your session", http.StatusInternalServerError)
+				return
+			}
+		}
+		if user == nil {
+			if r.Header.Get("HX-Request") == "true" {
+				w.Header().Set("HX-Redirect", "/login")
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+			return
+		}
+		ctx := service.WithPrincipal(r.Context(), service.Principal{UserID: user.ID, Username: user.Username})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// bearerToken returns the token of an "Authorization: Bearer" header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+func (h *AuthHandler) LoginPage(w http.ResponseWriter, r *http.Request) {
+	templ.Handler(LoginPage(&AuthFormData{})).ServeHTTP(w, r)
+}
+
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+	data := &AuthFormData{Username: r.FormValue("username")}
+
+	signedIn, err := h.authService.Login(r.Context(), data.Username, r.FormValue("password"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			data.Error = formErrorMessage(err)
+			w.WriteHeader(http.StatusUnauthorized)
+			templ.Handler(LoginPage(data)).ServeHTTP(w, r)
+			return
+		}
+		http.Error(w, "Failed to log in", http.StatusInternalServerError)
+		return
+	}
+	h.setSessionCookie(w, signedIn.Token, signedIn.ExpiresAt)
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+func (h *AuthHandler) SignupPage(w http.ResponseWriter, r *http.Request) {
+	templ.Handler(SignupPage(&AuthFormData{})).ServeHTTP(w, r)
+}
+
+// Signup creates an account and signs its owner straight in.
+func (h *AuthHandler) Signup(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+	data := &AuthFormData{Username: r.FormValue("username"), Email: r.FormValue("email")}
+
+	user, err := h.authService.Signup(r.Context(), &service.SignupInput{
+		Username: data.Username,
+		Email:    data.Email,
+		Password: r.FormValue("password"),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			data.Error = formErrorMessage(err)
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			templ.Handler(SignupPage(data)).ServeHTTP(w, r)
+			return
+		}
+		http.Error(w, "Failed to create account", http.StatusInternalServerError)
+		return
+	}
+	signedIn, err := h.authService.StartSession(r.Context(), user)
+	if err != nil {
+		http.Error(w, "Failed to log in", http.StatusInternalServerError)
+		return
+	}
+	h.setSessionCookie(w, signedIn.Token, signedIn.ExpiresAt)
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// Logout ends the browser's session and clears its cookie.
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(sessionCookie); err == nil {
+		if err := h.authService.Logout(r.Context(), cookie.Value); err != nil {
+			http.Error(w, "Failed to log out", http.StatusInternalServerError)
+			return
+		}
+	}
+	h.setSessionCookie(w, "", time.Unix(0, 0))
+	http.Redirect(w, r, "/login", http.StatusSeeOther)
+}
+
+func (h *AuthHandler) setSessionCookie(w http.ResponseWriter, token string, expires time.Time) {
+	cookie := &http.Cookie{
+		Name:     sessionCookie,
+		Value:    token,
+		Path:     "/",
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   h.secureCookies,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if token == "" {
+		cookie.MaxAge = -1
+	}
+	http.SetCookie(w, cookie)
+}
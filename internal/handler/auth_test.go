@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+type mockUserRepo struct {
+	user *domain.User
+}
+
+func (m *mockUserRepo) Create(_ context.Context, user *domain.User) error {
+	if m.user != nil {
+		return domain.ErrConflict
+	}
+	user.ID = "00000000-0000-0000-0000-000000000001"
+	m.user = user
+	return nil
+}
+
+func (m *mockUserRepo) GetByID(_ context.Context, id string) (*domain.User, error) {
+	if m.user == nil || m.user.ID != id {
+		return nil, domain.ErrNotFound
+	}
+	return m.user, nil
+}
+
+func (m *mockUserRepo) GetByUsername(_ context.Context, username string) (*domain.User, error) {
+	if m.user == nil || m.user.Username != username {
+		return nil, domain.ErrNotFound
+	}
+	return m.user, nil
+}
+
+type mockSessionRepo struct {
+	sessions map[string]*domain.Session
+}
+
+func (m *mockSessionRepo) Create(_ context.Context, session *domain.Session) error {
+	m.sessions[session.TokenHash] = session
+	return nil
+}
+
+func (m *mockSessionRepo) GetByTokenHash(_ context.Context, tokenHash string) (*domain.Session, error) {
+	if s, ok := m.sessions[tokenHash]; ok {
+		return s, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockSessionRepo) Delete(_ context.Context, tokenHash string) error {
+	delete(m.sessions, tokenHash)
+	return nil
+}
+
+func (m *mockSessionRepo) DeleteExpired(_ context.Context) error { return nil }
+
+func newTestAuthHandler() *AuthHandler {
+	svc := service.NewAuthService(&mockUserRepo{}, &mockSessionRepo{sessions: make(map[string]*domain.Session)})
+	return NewAuthHandler(svc, false)
+}
+
+func postForm(h http.HandlerFunc, path string, form url.Values) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("POST", path, strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h(w, r)
+	return w
+}
+
+func TestAuthHandler_Middleware(t *testing.T) {
+	h := newTestAuthHandler()
+	var gotUserID *string
+	protected := h.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserID = getUserID(r)
+	}))
+
+	// Anonymous requests go to the login page
+	w := httptest.NewRecorder()
+	protected.ServeHTTP(w, httptest.NewRequest("GET", "/", http.NoBody))
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/login" {
+		t.Errorf("anonymous: status = %d, location = %q, want 303 to /login", w.Code, w.Header().Get("Location"))
+	}
+	r := httptest.NewRequest("GET", "/", http.NoBody)
+	r.Header.Set("HX-Request", "true")
+	w = httptest.NewRecorder()
+	protected.ServeHTTP(w, r)
+	if w.Code != http.StatusUnauthorized || w.Header().Get("HX-Redirect") != "/login" {
+		t.Errorf("anonymous htmx: status = %d, HX-Redirect = %q, want 401 to /login", w.Code, w.Header().Get("HX-Redirect"))
+	}
+
+	signup := postForm(h.Signup, "/signup", url.Values{"username": {"ada"}, "password": {"correct horse"}})
+	if signup.Code != http.StatusSeeOther {
+		t.Fatalf("signup status = %d, want 303: %s", signup.Code, signup.Body.String())
+	}
+	cookies := signup.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != sessionCookie || !cookies[0].HttpOnly {
+		t.Fatalf("signup cookies = %+v, want an HttpOnly session cookie", cookies)
+	}
+
+	r = httptest.NewRequest("GET", "/", http.NoBody)
+	r.AddCookie(cookies[0])
+	w = httptest.NewRecorder()
+	protected.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || gotUserID == nil || *gotUserID != "00000000-0000-0000-0000-000000000001" {
+		t.Errorf("signed in: status = %d, user = %v, want the signed-up user", w.Code, gotUserID)
+	}
+
+	// After logging out the cookie no longer works
+	r = httptest.NewRequest("POST", "/logout", http.NoBody)
+	r.AddCookie(cookies[0])
+	h.Logout(httptest.NewRecorder(), r)
+	r = httptest.NewRequest("GET", "/", http.NoBody)
+	r.AddCookie(cookies[0])
+	w = httptest.NewRecorder()
+	protected.ServeHTTP(w, r)
+	if w.Code != http.StatusSeeOther {
+		t.Errorf("after logout: status = %d, want 303", w.Code)
+	}
+}
+
+func TestAuthHandler_Login(t *testing.T) {
+	h := newTestAuthHandler()
+	postForm(h.Signup, "/signup", url.Values{"username": {"ada"}, "password": {"correct horse"}})
+
+	w := postForm(h.Login, "/login", url.Values{"username": {"ada"}, "password": {"wrong horse"}})
+	if w.Code != http.StatusUnauthorized || !strings.Contains(w.Body.String(), "wrong username or password") {
+		t.Errorf("wrong password: status = %d, want 401 with the error: %s", w.Code, w.Body.String())
+	}
+	if len(w.Result().Cookies()) != 0 {
+		t.Error("wrong password set a cookie")
+	}
+
+	w = postForm(h.Login, "/login", url.Values{"username": {"ada"}, "password": {"correct horse"}})
+	if w.Code != http.StatusSeeOther || len(w.Result().Cookies()) != 1 {
+		t.Errorf("login: status = %d, cookies = %v, want 303 with a session cookie", w.Code, w.Result().Cookies())
+	}
+}
+
+// stubTokenVerifier accepts only the token "good", issued to subject.
+type stubTokenVerifier struct {
+	subject string
+}
+
+func (s stubTokenVerifier) Verify(_ context.Context, token string) (*domain.TokenIdentity, error) {
+	if token != "good" {
+		return nil, domain.ErrInvalidInput
+	}
+	return &domain.TokenIdentity{Subject: s.subject}, nil
+}
+
+func TestAuthHandler_Middleware_Bearer(t *testing.T) {
+	svc := service.NewAuthService(&mockUserRepo{}, &mockSessionRepo{sessions: make(map[string]*domain.Session)}).
+		WithTokenVerifier(stubTokenVerifier{subject: "7B6F3C1E-2F4A-4C2B-9D1E-0A5B6C7D8E9F"})
+	h := NewAuthHandler(svc, false)
+	var gotUserID *string
+	protected := h.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserID = getUserID(r)
+	}))
+
+	tests := []struct {
+		name   string
+		header string
+		wantID string
+		want   int
+	}{
+		{name: "valid token", header: "Bearer good", want: http.StatusOK, wantID: "7b6f3c1e-2f4a-4c2b-9d1e-0a5b6c7d8e9f"},
+		{name: "invalid token", header: "Bearer bad", want: http.StatusUnauthorized},
+		{name: "no token", want: http.StatusSeeOther},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotUserID = nil
+			r := httptest.NewRequest("GET", "/", http.NoBody)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			protected.ServeHTTP(w, r)
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+			if tt.want == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+				t.Error("missing WWW-Authenticate header")
+			}
+			if tt.wantID != "" && (gotUserID == nil || *gotUserID != tt.wantID) {
+				t.Errorf("user = %v, want %s", gotUserID, tt.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+// BoardingPass pre-fills the event creation form from the barcode text of a
+// boarding pass, keeping whatever else was already entered. The first leg of
+// a multi-leg pass is used.
+func (h *EventHandler) BoardingPass(w http.ResponseWriter, r *http.Request) {
+	tripID, err := strconv.Atoi(chi.URLParam(r, "tripID"))
+	if err != nil {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return
+	}
+	if err = r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	data := flightDataFromDomain(parseFlightDetails(r, nil))
+	data.TripID = tripID
+	data.Date = r.FormValue("date")
+	data.Category = string(domain.CategoryFlight)
+	data.Title = r.FormValue("title")
+	data.Location = r.FormValue("location")
+	data.StartTime = r.FormValue("start_time")
+	data.EndTime = r.FormValue("end_time")
+	data.Notes = r.FormValue("notes")
+	data.Pinned = r.FormValue("pinned") == "on" || r.FormValue("pinned") == "true"
+	data.TimeZone = r.FormValue("time_zone")
+	data.Segments = parseSegmentRows(r)
+
+	pass, err := h.eventService.ReadBoardingPass(r.FormValue("boarding_pass"))
+	if err != nil {
+		data.BoardingPass = r.FormValue("boarding_pass")
+		data.Errors = map[string]string{"boarding_pass": "Could not read the barcode: " + formErrorMessage(err)}
+		renderEventFormError(w, r, &data)
+		return
+	}
+	fillFromBoardingPass(&data, pass)
+
+	if r.Header.Get("HX-Request") == "true" {
+		templ.Handler(EventCreateForm(&data)).ServeHTTP(w, r)
+		return
+	}
+	templ.Handler(EventNewPage(&data)).ServeHTTP(w, r)
+}
+
+// fillFromBoardingPass copies the first leg of pass into the form. The seat
+// and passenger have no field of their own, so they go into the notes.
+func fillFromBoardingPass(data *EventFormData, pass *domain.BoardingPass) {
+	leg := &pass.Legs[0]
+	ref := parseDate(data.Date)
+	if ref.IsZero() {
+		ref = time.Now()
+	}
+	data.Date = leg.Date(ref).Format("2006-01-02")
+	data.Airline = leg.Carrier
+	data.FlightNumber = leg.Flight()
+	data.DepartureAirport = leg.From
+	data.ArrivalAirport = leg.To
+	if leg.BookingReference != "" {
+		data.BookingReference = leg.BookingReference
+	}
+	if data.Title == "" {
+		data.Title = fmt.Sprintf("Flight %s %s → %s", leg.Flight(), leg.From, leg.To)
+	}
+
+	var notes []string
+	if pass.PassengerName != "" {
+		notes = append(notes, "Passenger: "+pass.PassengerName)
+	}
+	if leg.Seat != "" {
+		notes = append(notes, "Seat: "+leg.Seat)
+	}
+	for _, note := range notes {
+		if !strings.Contains(data.Notes, note) {
+			data.Notes = strings.TrimSpace(data.Notes + "\n" + note)
+		}
+	}
+}
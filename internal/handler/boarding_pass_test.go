@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/simopzz/traccia/internal/infra/bcbp"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+// The single-leg example of IATA Resolution 792
+const sampleBoardingPass = "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 226F001A0025 100"
+
+func TestEventHandler_BoardingPass(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       url.Values
+		wantStatus int
+		want       []string
+	}{
+		{
+			name: "fills the flight",
+			form: url.Values{
+				"date": {"2026-08-10"}, "boarding_pass": {sampleBoardingPass},
+				"notes": {"Window please"}, "departure_terminal": {"3"},
+			},
+			wantStatus: http.StatusOK,
+			want: []string{
+				`name="date" value="2026-08-14"`,
+				`value="Flight AC834 YUL → FRA"`,
+				`name="flight_number" value="AC834"`,
+				`name="departure_airport" value="YUL"`,
+				`name="departure_terminal" value="3"`,
+				`name="booking_reference" value="ABC123"`,
+				"Window please\nPassenger: DESMARAIS/LUC\nSeat: 1A",
+			},
+		},
+		{
+			name:       "unreadable barcode",
+			form:       url.Values{"date": {"2026-08-10"}, "boarding_pass": {"hello"}},
+			wantStatus: http.StatusUnprocessableEntity,
+			want:       []string{"Could not read the barcode", `value="hello"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewEventHandler(service.NewEventService(&mockEventRepo{}, &mockTripRepo{}).WithBoardingPasses(bcbp.NewParser()), newTestTravelService())
+
+			r := httptest.NewRequest("POST", "/trips/1/events/boarding-pass", strings.NewReader(tt.form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			r.Header.Set("HX-Request", "true")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("tripID", "1")
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+			h.BoardingPass(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(w.Body.String(), want) {
+					t.Errorf("form missing %q", want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+// Calendar serves the trip's events as an iCalendar file.
+func (h *TripHandler) Calendar(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return
+	}
+
+	trip, err := h.tripService.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Trip not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to load trip", http.StatusInternalServerError)
+		return
+	}
+
+	events, err := h.eventService.ListByTrip(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Failed to load events", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="trip-%d.ics"`, trip.ID))
+	_ = h.exportService.Calendar(w, trip, events)
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/a-h/templ"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+// maxCalendarUpload bounds the size of an uploaded .ics file.
+const maxCalendarUpload = 2 << 20
+
+// ImportHandler previews and imports events from calendars and booking
+// emails made by other apps.
+type ImportHandler struct {
+	importService *service.ImportService
+	tripService   *service.TripService
+}
+
+func NewImportHandler(importService *service.ImportService, tripService *service.TripService) *ImportHandler {
+	return &ImportHandler{importService: importService, tripService: tripService}
+}
+
+// ImportPage shows the calendar upload form.
+func (h *ImportHandler) ImportPage(w http.ResponseWriter, r *http.Request) {
+	trip, ok := loadTrip(w, r, h.tripService)
+	if !ok {
+		return
+	}
+	templ.Handler(TripImportPage(trip, nil, "", "")).ServeHTTP(w, r)
+}
+
+// ImportPreview parses an uploaded calendar and shows the events importing it
+// would create, without creating anything.
+func (h *ImportHandler) ImportPreview(w http.ResponseWriter, r *http.Request) {
+	trip, ok := loadTrip(w, r, h.tripService)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxCalendarUpload)
+	if err := r.ParseMultipartForm(maxCalendarUpload); err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		templ.Handler(TripImportPage(trip, nil, "", "Choose an .ics file of at most 2 MB")).ServeHTTP(w, r)
+		return
+	}
+	file, _, err := r.FormFile("calendar")
+	if err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		templ.Handler(TripImportPage(trip, nil, "", "Choose an .ics file to import")).ServeHTTP(w, r)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, "Failed to read upload", http.StatusBadRequest)
+		return
+	}
+
+	candidates, err := h.importService.CalendarCandidates(r.Context(), trip, string(data), formCategories(r))
+	if err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		templ.Handler(TripImportPage(trip, nil, "", formErrorMessage(err))).ServeHTTP(w, r)
+		return
+	}
+	templ.Handler(TripImportPage(trip, candidates, string(data), "")).ServeHTTP(w, r)
+}
+
+// Import creates the events selected in the preview. The preview form carries
+// the calendar itself, so nothing is kept server-side between the two steps.
+// When some events fail, the preview is shown again with the created ones
+// marked so a retry cannot duplicate them.
+func (h *ImportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	trip, ok := loadTrip(w, r, h.tripService)
+	if !ok {
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	data := r.FormValue("calendar")
+	candidates, err := h.importService.CalendarCandidates(r.Context(), trip, data, formCategories(r))
+	if err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		templ.Handler(TripImportPage(trip, nil, "", formErrorMessage(err))).ServeHTTP(w, r)
+		return
+	}
+
+	if !h.createCandidates(r, candidates) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		templ.Handler(TripImportPage(trip, candidates, data, "Some events could not be imported")).ServeHTTP(w, r)
+		return
+	}
+	http.Redirect(w, r, "/trips/"+strconv.Itoa(trip.ID), http.StatusSeeOther)
+}
+
+// createCandidates creates the candidates selected in a submitted preview,
+// skipping those an earlier attempt already created. It reports whether all
+// succeeded.
+func (h *ImportHandler) createCandidates(r *http.Request, candidates []service.ImportCandidate) bool {
+	imported := formIndexes(r.Form["imported"])
+	for i := range candidates {
+		candidates[i].Imported = imported[candidates[i].Index]
+	}
+	return h.importService.Create(r.Context(), candidates, formIndexes(r.Form["import"]))
+}
+
+// formCategories reads the category choices of a submitted preview, by
+// candidate index.
+func formCategories(r *http.Request) map[int]domain.EventCategory {
+	categories := make(map[int]domain.EventCategory)
+	for key, values := range r.PostForm {
+		var i int
+		if _, err := fmt.Sscanf(key, "category_%d", &i); err != nil || len(values) == 0 || values[0] == "" {
+			continue
+		}
+		categories[i] = domain.EventCategory(values[0])
+	}
+	return categories
+}
+
+// formIndexes collects the integer values of a repeated form field.
+func formIndexes(values []string) map[int]bool {
+	indexes := make(map[int]bool, len(values))
+	for _, v := range values {
+		if i, err := strconv.Atoi(v); err == nil {
+			indexes[i] = true
+		}
+	}
+	return indexes
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/simopzz/traccia/internal/infra/bookingmail"
+	"github.com/simopzz/traccia/internal/infra/ical"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+const sampleCalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VTIMEZONE\r\n" +
+	"TZID:Europe/Rome\r\n" +
+	"END:VTIMEZONE\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:abc-1\r\n" +
+	"SUMMARY:Flight AZ 610 FCO-JFK\r\n" +
+	"DTSTART;TZID=Europe/Rome:20260503T101500\r\n" +
+	"DTEND;TZID=America/New_York:20260503T135000\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:abc-2\r\n" +
+	"SUMMARY:Hotel Artemide\r\n" +
+	"DTSTART;VALUE=DATE:20260501\r\n" +
+	"DTEND;VALUE=DATE:20260503\r\n" +
+	"LOCATION:Via Nazionale 22\\, Roma\r\n" +
+	"GEO:41.9009;12.4936\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:abc-3\r\n" +
+	"SUMMARY:Dinner at Roscioli\r\n" +
+	"DTSTART:20260501T180000Z\r\n" +
+	"DURATION:PT1H30M\r\n" +
+	"DESCRIPTION:Ask for the carbonara\\nTable for two\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:event-42@traccia\r\n" +
+	"SUMMARY:Vatican Museums with a very long title that has to be folded by the\r\n" +
+	"  exporting calendar\r\n" +
+	"DTSTART;TZID=W. Europe Standard Time:20260502T090000\r\n" +
+	"DTEND;TZID=W. Europe Standard Time:20260502T120000\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:abc-4\r\n" +
+	"SUMMARY:Train to Florence\r\n" +
+	"DTSTART:20260610T080000Z\r\n" +
+	"DTEND:20260610T093000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func newTestImportHandler(repo *mockEventRepo) *ImportHandler {
+	importService := service.NewImportService(service.NewEventService(repo, &mockTripRepo{}), ical.NewParser(), bookingmail.NewReader())
+	return NewImportHandler(importService, service.NewTripService(&mockTripRepo{}))
+}
+
+func TestImportHandler_Import(t *testing.T) {
+	repo := &mockEventRepo{}
+	h := newTestImportHandler(repo)
+
+	form := url.Values{
+		"calendar": {sampleCalendar},
+		"import":   {"2"},
+	}
+	r := httptest.NewRequest("POST", "/trips/1/import", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+	h.Import(w, r)
+
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("Import() status = %d, want %d\n%s", w.Code, http.StatusSeeOther, w.Body.String())
+	}
+	if repo.capturedEvent == nil || repo.capturedEvent.Title != "Dinner at Roscioli" {
+		t.Errorf("Import() created %+v, want only the selected dinner", repo.capturedEvent)
+	}
+}
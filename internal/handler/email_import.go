@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/a-h/templ"
+)
+
+// maxEmailUpload bounds the size of an uploaded .eml file, which may carry
+// inline images alongside the booking.
+const maxEmailUpload = 5 << 20
+
+// EmailImportPage shows the booking email upload form.
+func (h *ImportHandler) EmailImportPage(w http.ResponseWriter, r *http.Request) {
+	trip, ok := loadTrip(w, r, h.tripService)
+	if !ok {
+		return
+	}
+	templ.Handler(EmailImportPage(trip, nil, "", "")).ServeHTTP(w, r)
+}
+
+// EmailImportPreview reads the reservations of an uploaded booking email and
+// shows the events importing it would create, without creating anything.
+func (h *ImportHandler) EmailImportPreview(w http.ResponseWriter, r *http.Request) {
+	trip, ok := loadTrip(w, r, h.tripService)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxEmailUpload)
+	if err := r.ParseMultipartForm(maxEmailUpload); err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		templ.Handler(EmailImportPage(trip, nil, "", "Choose an .eml file of at most 5 MB")).ServeHTTP(w, r)
+		return
+	}
+	file, _, err := r.FormFile("email")
+	if err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		templ.Handler(EmailImportPage(trip, nil, "", "Choose an .eml file to import")).ServeHTTP(w, r)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, "Failed to read upload", http.StatusBadRequest)
+		return
+	}
+
+	candidates, err := h.importService.ReservationCandidates(r.Context(), trip, string(data))
+	if err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		templ.Handler(EmailImportPage(trip, nil, "", formErrorMessage(err))).ServeHTTP(w, r)
+		return
+	}
+	templ.Handler(EmailImportPage(trip, candidates, string(data), "")).ServeHTTP(w, r)
+}
+
+// EmailImport creates the reservations selected in the preview. Like the
+// calendar import, the preview form carries the email itself.
+func (h *ImportHandler) EmailImport(w http.ResponseWriter, r *http.Request) {
+	trip, ok := loadTrip(w, r, h.tripService)
+	if !ok {
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	data := r.FormValue("email")
+	candidates, err := h.importService.ReservationCandidates(r.Context(), trip, data)
+	if err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		templ.Handler(EmailImportPage(trip, nil, "", formErrorMessage(err))).ServeHTTP(w, r)
+		return
+	}
+
+	if !h.createCandidates(r, candidates) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		templ.Handler(EmailImportPage(trip, candidates, data, "Some reservations could not be imported")).ServeHTTP(w, r)
+		return
+	}
+	http.Redirect(w, r, "/trips/"+strconv.Itoa(trip.ID), http.StatusSeeOther)
+}
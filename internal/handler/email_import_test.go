@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// bookingEmail is a hotel confirmation with its reservation as JSON-LD.
+const bookingEmail = "Subject: Your booking\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"\r\n" +
+	`<script type="application/ld+json">{"@context": "http://schema.org", "@type": "LodgingReservation",
+	"reservationFor": {"@type": "LodgingBusiness", "name": "Hotel Artemide"},
+	"checkinDate": "2026-05-01", "checkoutDate": "2026-05-03"}</script>`
+
+func TestImportHandler_EmailImportPreview(t *testing.T) {
+	tests := []struct {
+		name       string
+		email      string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "booking", email: bookingEmail, wantStatus: http.StatusOK, wantBody: "Hotel Artemide"},
+		{name: "no reservations", email: "Subject: hi\r\nContent-Type: text/html\r\n\r\n<p>Hello</p>", wantStatus: http.StatusUnprocessableEntity, wantBody: "no flight or hotel reservations"},
+		{name: "not an email", email: "just some text", wantStatus: http.StatusUnprocessableEntity, wantBody: "not an email message"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestImportHandler(&mockEventRepo{})
+
+			var body bytes.Buffer
+			mw := multipart.NewWriter(&body)
+			part, _ := mw.CreateFormFile("email", "booking.eml")
+			_, _ = part.Write([]byte(tt.email))
+			_ = mw.Close()
+
+			r := httptest.NewRequest("POST", "/trips/1/import/email/preview", &body)
+			r.Header.Set("Content-Type", mw.FormDataContentType())
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "1")
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+			h.EmailImportPreview(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("body missing %q", tt.wantBody)
+			}
+		})
+	}
+}
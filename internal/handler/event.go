@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -46,8 +49,30 @@ type EventFormData struct {
 	Origin            string
 	Destination       string
 	TransportMode     string
-	TripID            int
-	Pinned            bool
+	TimeZone          string
+	DepartureTimeZone string
+	ArrivalTimeZone   string
+	// BoardingPass is barcode text pasted into the flight form's shortcut.
+	BoardingPass string
+	// Segments are the rows of a connecting flight's segment editor.
+	Segments []SegmentFormData
+	TripID   int
+	Pinned   bool
+}
+
+// SegmentFormData is one row of the flight form's segment editor. Times are
+// "15:04" on the event's date, in the segment's airport zones.
+type SegmentFormData struct {
+	Airline           string
+	FlightNumber      string
+	DepartureAirport  string
+	ArrivalAirport    string
+	DepartureTime     string
+	ArrivalTime       string
+	DepartureTerminal string
+	DepartureGate     string
+	ArrivalTerminal   string
+	ArrivalGate       string
 }
 
 // renderEventFormError sends a 422 response with the appropriate form template.
@@ -62,12 +87,14 @@ func renderEventFormError(w http.ResponseWriter, r *http.Request, data *EventFor
 }
 
 type EventHandler struct {
-	eventService *service.EventService
+	eventService  *service.EventService
+	travelService *service.TravelService
 }
 
-func NewEventHandler(eventService *service.EventService) *EventHandler {
+func NewEventHandler(eventService *service.EventService, travelService *service.TravelService) *EventHandler {
 	return &EventHandler{
-		eventService: eventService,
+		eventService:  eventService,
+		travelService: travelService,
 	}
 }
 
@@ -101,6 +128,7 @@ func (h *EventHandler) NewPage(w http.ResponseWriter, r *http.Request) {
 		Category:  category,
 		StartTime: defaults.StartTime.Format("15:04"),
 		EndTime:   defaults.EndTime.Format("15:04"),
+		TimeZone:  defaults.TimeZone,
 	}
 
 	// Dual-path: HTMX request → Sheet fragment; otherwise → full page fallback
@@ -133,6 +161,10 @@ func (h *EventHandler) Create(w http.ResponseWriter, r *http.Request) {
 	endTimeStr := r.FormValue("end_time")
 	notes := r.FormValue("notes")
 	pinned := r.FormValue("pinned") == "on" || r.FormValue("pinned") == "true"
+	timeZone := r.FormValue("time_zone")
+	if timeZone == "" {
+		timeZone = h.eventService.DefaultTimeZone(r.Context(), tripID)
+	}
 
 	formData := &EventFormData{
 		TripID:           tripID,
@@ -150,17 +182,24 @@ func (h *EventHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Origin:           r.FormValue("origin"),
 		Destination:      r.FormValue("destination"),
 		TransportMode:    r.FormValue("transport_mode"),
+		TimeZone:         timeZone,
 	}
 
+	// A connecting flight takes its times and airports from its segments
+	if category == string(domain.CategoryFlight) {
+		formData.Segments = parseSegmentRows(r)
+	}
+	connecting := len(formData.Segments) > 0
+
 	// Handler pre-validates required fields for field-level errors
 	formErrors := make(map[string]string)
 	if title == "" {
 		formErrors["title"] = "Title is required"
 	}
-	if startTimeStr == "" {
+	if startTimeStr == "" && !connecting {
 		formErrors["start_time"] = "Start time is required"
 	}
-	if endTimeStr == "" {
+	if endTimeStr == "" && !connecting {
 		formErrors["end_time"] = "End time is required"
 	}
 	if dateStr == "" {
@@ -172,7 +211,11 @@ func (h *EventHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	var serviceFlightDetails *domain.FlightDetails
 	if category == string(domain.CategoryFlight) {
-		serviceFlightDetails = parseFlightDetails(r)
+		serviceFlightDetails = parseFlightDetails(r, formData.Segments)
+		// Known airports bring their zones, which the times below are read in
+		if err := h.eventService.CompleteFlight(serviceFlightDetails); err != nil {
+			formErrors["general"] = formErrorMessage(err)
+		}
 		formData.Airline = serviceFlightDetails.Airline
 		formData.FlightNumber = serviceFlightDetails.FlightNumber
 		formData.DepartureAirport = serviceFlightDetails.DepartureAirport
@@ -182,41 +225,59 @@ func (h *EventHandler) Create(w http.ResponseWriter, r *http.Request) {
 		formData.DepartureGate = serviceFlightDetails.DepartureGate
 		formData.ArrivalGate = serviceFlightDetails.ArrivalGate
 		formData.BookingReference = serviceFlightDetails.BookingReference
+		formData.DepartureTimeZone = serviceFlightDetails.DepartureTimeZone
+		formData.ArrivalTimeZone = serviceFlightDetails.ArrivalTimeZone
 
-		if serviceFlightDetails.DepartureAirport == "" {
+		if serviceFlightDetails.DepartureAirport == "" && !connecting {
 			formErrors["departure_airport"] = "Required"
 		}
-		if serviceFlightDetails.ArrivalAirport == "" {
+		if serviceFlightDetails.ArrivalAirport == "" && !connecting {
 			formErrors["arrival_airport"] = "Required"
 		}
 	}
 
+	startLoc, endLoc := formLocations(timeZone, serviceFlightDetails, formErrors)
+
 	if len(formErrors) > 0 {
 		formData.Errors = formErrors
 		renderEventFormError(w, r, formData)
 		return
 	}
 
-	startTime, err := parseDateAndTime(dateStr, startTimeStr)
-	if err != nil {
-		formErrors["start_time"] = "Invalid start time format"
-		formData.Errors = formErrors
-		renderEventFormError(w, r, formData)
-		return
-	}
+	var startTime, endTime time.Time
+	if connecting {
+		if err = parseSegmentTimes(dateStr, timeZone, formData.Segments, serviceFlightDetails.Segments); err != nil {
+			formErrors["general"] = formErrorMessage(err)
+			formData.Errors = formErrors
+			renderEventFormError(w, r, formData)
+			return
+		}
+		startTime, endTime = segmentsSpan(serviceFlightDetails.Segments)
+	} else {
+		startTime, err = parseDateAndTime(dateStr, startTimeStr, startLoc)
+		if err != nil {
+			formErrors["start_time"] = "Invalid start time format"
+			formData.Errors = formErrors
+			renderEventFormError(w, r, formData)
+			return
+		}
 
-	endTime, err := parseDateAndTime(dateStr, endTimeStr)
-	if err != nil {
-		formErrors["end_time"] = "Invalid end time format"
-		formData.Errors = formErrors
-		renderEventFormError(w, r, formData)
-		return
+		endTime, err = parseDateAndTime(dateStr, endTimeStr, endLoc)
+		if err != nil {
+			formErrors["end_time"] = "Invalid end time format"
+			formData.Errors = formErrors
+			renderEventFormError(w, r, formData)
+			return
+		}
+		if category == string(domain.CategoryFlight) {
+			endTime = overnightArrival(startTime, endTime)
+		}
 	}
 
 	var lodgingDetails *domain.LodgingDetails
 	if category == string(domain.CategoryLodging) {
 		var parseErr error
-		lodgingDetails, parseErr = parseLodgingDetails(formData)
+		lodgingDetails, parseErr = parseLodgingDetails(formData, startLoc)
 		if parseErr != nil {
 			formErrors["general"] = "Invalid lodging time format"
 			formData.Errors = formErrors
@@ -238,17 +299,25 @@ func (h *EventHandler) Create(w http.ResponseWriter, r *http.Request) {
 		StartTime:      startTime,
 		EndTime:        endTime,
 		Notes:          notes,
+		TimeZone:       timeZone,
 		Pinned:         pinned,
 		FlightDetails:  serviceFlightDetails,
 		LodgingDetails: lodgingDetails,
 		TransitDetails: transitDetails,
+		// Overlaps are reported back to the form until the user confirms them
+		RejectConflicts: r.FormValue("allow_conflicts") != "true",
 	}
 
 	event, err := h.eventService.Create(r.Context(), input)
 	if err != nil {
-		if errors.Is(err, domain.ErrInvalidInput) {
-			// Strip the domain error prefix to avoid leaking internals to the UI
-			formErrors["general"] = strings.TrimPrefix(err.Error(), "invalid input: ")
+		if errors.Is(err, domain.ErrScheduleConflict) {
+			formErrors["conflict"] = formErrorMessage(err)
+			formData.Errors = formErrors
+			renderEventFormError(w, r, formData)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidInput) || errors.Is(err, domain.ErrDateRangeConflict) {
+			formErrors["general"] = formErrorMessage(err)
 			formData.Errors = formErrors
 			renderEventFormError(w, r, formData)
 			return
@@ -268,9 +337,13 @@ func (h *EventHandler) Create(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		dayData := TimelineDayData{
+		days, err := h.withStayDays(r.Context(), tripID, []TimelineDayData{{
 			Date:   event.EventDate,
 			Events: events,
+		}}, service.StayDays(event))
+		if err != nil {
+			http.Error(w, "Failed to load events", http.StatusInternalServerError)
+			return
 		}
 
 		// Set HTMX response headers for retarget to day container
@@ -278,7 +351,7 @@ func (h *EventHandler) Create(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("HX-Reswap", "outerHTML")
 		w.Header().Set("HX-Trigger", `{"close-sheet": true}`)
 
-		templ.Handler(TimelineDay(tripID, dayData)).ServeHTTP(w, r)
+		h.renderDays(w, r, tripID, days)
 		return
 	}
 
@@ -287,6 +360,11 @@ func (h *EventHandler) Create(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *EventHandler) EditPage(w http.ResponseWriter, r *http.Request) {
+	tripID, err := strconv.Atoi(chi.URLParam(r, "tripID"))
+	if err != nil {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return
+	}
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
@@ -294,7 +372,7 @@ func (h *EventHandler) EditPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	event, err := h.eventService.GetByID(r.Context(), id)
+	event, err := h.eventService.GetByTripAndID(r.Context(), tripID, id)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			http.Error(w, "Event not found", http.StatusNotFound)
@@ -321,8 +399,8 @@ func (h *EventHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Fetch event first — needed for oldEventDate capture and 422 re-render
-	event, err := h.eventService.GetByID(r.Context(), id)
+	// Fetch event first — needed for the trip check, oldEventDate capture and 422 re-render
+	event, err := h.eventService.GetByTripAndID(r.Context(), tripID, id)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			http.Error(w, "Event not found", http.StatusNotFound)
@@ -332,6 +410,7 @@ func (h *EventHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	oldEventDate := event.EventDate
+	before := *event
 
 	if err = r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -345,6 +424,10 @@ func (h *EventHandler) Update(w http.ResponseWriter, r *http.Request) {
 	location := r.FormValue("location")
 	notes := r.FormValue("notes")
 	pinned := r.FormValue("pinned") == "on" || r.FormValue("pinned") == "true"
+	timeZone := r.FormValue("time_zone")
+	if timeZone == "" {
+		timeZone = event.TimeZone
+	}
 
 	formData := EventFormData{
 		TripID:           tripID,
@@ -361,11 +444,22 @@ func (h *EventHandler) Update(w http.ResponseWriter, r *http.Request) {
 		Origin:           r.FormValue("origin"),
 		Destination:      r.FormValue("destination"),
 		TransportMode:    r.FormValue("transport_mode"),
+		TimeZone:         timeZone,
 	}
 
+	// A connecting flight takes its times and airports from its segments
+	if event.Category == domain.CategoryFlight {
+		formData.Segments = parseSegmentRows(r)
+	}
+	connecting := len(formData.Segments) > 0
+
+	formErrors := make(map[string]string)
 	var flightDetails *domain.FlightDetails
 	if event.Category == domain.CategoryFlight {
-		flightDetails = parseFlightDetails(r)
+		flightDetails = parseFlightDetails(r, formData.Segments)
+		if err := h.eventService.CompleteFlight(flightDetails); err != nil {
+			formErrors["general"] = formErrorMessage(err)
+		}
 		formData.Airline = flightDetails.Airline
 		formData.FlightNumber = flightDetails.FlightNumber
 		formData.DepartureAirport = flightDetails.DepartureAirport
@@ -375,16 +469,17 @@ func (h *EventHandler) Update(w http.ResponseWriter, r *http.Request) {
 		formData.DepartureGate = flightDetails.DepartureGate
 		formData.ArrivalGate = flightDetails.ArrivalGate
 		formData.BookingReference = flightDetails.BookingReference
+		formData.DepartureTimeZone = flightDetails.DepartureTimeZone
+		formData.ArrivalTimeZone = flightDetails.ArrivalTimeZone
 	}
 
-	formErrors := make(map[string]string)
 	if title == "" {
 		formErrors["title"] = "Title is required"
 	}
-	if startTimeStr == "" {
+	if startTimeStr == "" && !connecting {
 		formErrors["start_time"] = "Start time is required"
 	}
-	if endTimeStr == "" {
+	if endTimeStr == "" && !connecting {
 		formErrors["end_time"] = "End time is required"
 	}
 	if dateStr == "" {
@@ -392,7 +487,7 @@ func (h *EventHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate flight fields
-	if event.Category == domain.CategoryFlight {
+	if event.Category == domain.CategoryFlight && !connecting {
 		if flightDetails.DepartureAirport == "" {
 			formErrors["departure_airport"] = "Required"
 		}
@@ -413,32 +508,48 @@ func (h *EventHandler) Update(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("HX-Retarget", fmt.Sprintf("#event-%d", id))
 			w.Header().Set("HX-Reswap", "outerHTML")
 			w.WriteHeader(http.StatusUnprocessableEntity)
-			templ.Handler(EventTimelineItem(*event, &EventCardProps{Editing: true, FormValues: data})).ServeHTTP(w, r)
+			templ.Handler(EventTimelineItem(*event, EventBadges{}, &EventCardProps{Editing: true, FormValues: data})).ServeHTTP(w, r)
 		} else {
 			http.Redirect(w, r, fmt.Sprintf("/trips/%s/events/%d/edit", tripIDStr, id), http.StatusSeeOther)
 		}
 	}
 
+	startLoc, endLoc := formLocations(timeZone, flightDetails, formErrors)
+
 	if len(formErrors) > 0 {
 		formData.Errors = formErrors
 		renderCardError(formData)
 		return
 	}
 
-	startTime, err := parseDateAndTime(dateStr, startTimeStr)
-	if err != nil {
-		formErrors["start_time"] = "Invalid start time format"
-		formData.Errors = formErrors
-		renderCardError(formData)
-		return
-	}
+	var startTime, endTime time.Time
+	if connecting {
+		if err = parseSegmentTimes(dateStr, timeZone, formData.Segments, flightDetails.Segments); err != nil {
+			formErrors["general"] = formErrorMessage(err)
+			formData.Errors = formErrors
+			renderCardError(formData)
+			return
+		}
+		startTime, endTime = segmentsSpan(flightDetails.Segments)
+	} else {
+		startTime, err = parseDateAndTime(dateStr, startTimeStr, startLoc)
+		if err != nil {
+			formErrors["start_time"] = "Invalid start time format"
+			formData.Errors = formErrors
+			renderCardError(formData)
+			return
+		}
 
-	endTime, err := parseDateAndTime(dateStr, endTimeStr)
-	if err != nil {
-		formErrors["end_time"] = "Invalid end time format"
-		formData.Errors = formErrors
-		renderCardError(formData)
-		return
+		endTime, err = parseDateAndTime(dateStr, endTimeStr, endLoc)
+		if err != nil {
+			formErrors["end_time"] = "Invalid end time format"
+			formData.Errors = formErrors
+			renderCardError(formData)
+			return
+		}
+		if event.Category == domain.CategoryFlight {
+			endTime = overnightArrival(startTime, endTime)
+		}
 	}
 
 	category := event.Category // preserve existing category
@@ -450,7 +561,7 @@ func (h *EventHandler) Update(w http.ResponseWriter, r *http.Request) {
 	var lodgingDetails *domain.LodgingDetails
 	if event.Category == domain.CategoryLodging {
 		var parseErr error
-		lodgingDetails, parseErr = parseLodgingDetails(&formData)
+		lodgingDetails, parseErr = parseLodgingDetails(&formData, startLoc)
 		if parseErr != nil {
 			formData.Errors = map[string]string{"general": "Invalid lodging time format"}
 			renderCardError(formData)
@@ -470,10 +581,13 @@ func (h *EventHandler) Update(w http.ResponseWriter, r *http.Request) {
 		StartTime:      &startTime,
 		EndTime:        &endTime,
 		Notes:          &notes,
+		TimeZone:       &timeZone,
 		Pinned:         &pinned,
 		FlightDetails:  serviceFlightDetails,
 		LodgingDetails: lodgingDetails,
 		TransitDetails: transitDetails,
+		// Overlaps are reported back to the form until the user confirms them
+		RejectConflicts: r.FormValue("allow_conflicts") != "true",
 	}
 
 	updatedEvent, err := h.eventService.Update(r.Context(), id, input)
@@ -482,8 +596,14 @@ func (h *EventHandler) Update(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Event not found", http.StatusNotFound)
 			return
 		}
-		if errors.Is(err, domain.ErrInvalidInput) {
-			formErrors["general"] = strings.TrimPrefix(err.Error(), "invalid input: ")
+		if errors.Is(err, domain.ErrScheduleConflict) {
+			formErrors["conflict"] = formErrorMessage(err)
+			formData.Errors = formErrors
+			renderCardError(formData)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidInput) || errors.Is(err, domain.ErrDateRangeConflict) {
+			formErrors["general"] = formErrorMessage(err)
 			formData.Errors = formErrors
 			renderCardError(formData)
 			return
@@ -508,13 +628,17 @@ func (h *EventHandler) Update(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		newEventDateStr := updatedEvent.EventDate.Format("2006-01-02")
-		dayData := TimelineDayData{
+		days, err := h.withStayDays(r.Context(), tripID, []TimelineDayData{{
 			Date:   updatedEvent.EventDate,
 			Events: events,
+		}}, service.StayDays(&before, updatedEvent))
+		if err != nil {
+			http.Error(w, "Failed to load events", http.StatusInternalServerError)
+			return
 		}
 		w.Header().Set("HX-Retarget", fmt.Sprintf("#day-%s", newEventDateStr))
 		w.Header().Set("HX-Reswap", "outerHTML")
-		templ.Handler(TimelineDay(tripID, dayData)).ServeHTTP(w, r)
+		h.renderDays(w, r, tripID, days)
 		return
 	}
 
@@ -535,8 +659,8 @@ func (h *EventHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Fetch before deleting to get EventDate for response
-	event, err := h.eventService.GetByID(r.Context(), id)
+	// Fetch before deleting to check the trip and get EventDate for response
+	event, err := h.eventService.GetByTripAndID(r.Context(), tripID, id)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			http.Error(w, "Event not found", http.StatusNotFound)
@@ -559,15 +683,19 @@ func (h *EventHandler) Delete(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Failed to load events", http.StatusInternalServerError)
 			return
 		}
-		dayData := TimelineDayData{
+		days, err := h.withStayDays(r.Context(), tripID, []TimelineDayData{{
 			Date:   eventDate,
 			Events: events,
+		}}, service.StayDays(event))
+		if err != nil {
+			http.Error(w, "Failed to load events", http.StatusInternalServerError)
+			return
 		}
 		eventDateStr := eventDate.Format("2006-01-02")
 		// Cannot use HX-Trigger header here because the triggering element (delete button)
 		// is removed from the DOM by the swap, so the event wouldn't bubble to window.
 		// Instead, we append a script to dispatch the event directly on window.
-		templ.Handler(TimelineDay(tripID, dayData)).ServeHTTP(w, r)
+		h.renderDays(w, r, tripID, days)
 		fmt.Fprintf(w, `<script>window.dispatchEvent(new CustomEvent('showundotoast', {detail: {"eventId": %d, "tripId": %d, "eventDate": "%s"}}));</script>`, id, tripID, eventDateStr)
 		return
 	}
@@ -589,7 +717,7 @@ func (h *EventHandler) Restore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	event, err := h.eventService.Restore(r.Context(), id)
+	event, err := h.eventService.Restore(r.Context(), tripID, id)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			http.Error(w, "Event not found", http.StatusNotFound)
@@ -609,30 +737,208 @@ func (h *EventHandler) Restore(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to load events", http.StatusInternalServerError)
 		return
 	}
-	dayData := TimelineDayData{
+	days, err := h.withStayDays(r.Context(), tripID, []TimelineDayData{{
 		Date:   event.EventDate,
 		Events: events,
+	}}, service.StayDays(event))
+	if err != nil {
+		http.Error(w, "Failed to load events", http.StatusInternalServerError)
+		return
 	}
 	eventDateStr := event.EventDate.Format("2006-01-02")
 	w.Header().Set("HX-Retarget", fmt.Sprintf("#day-%s", eventDateStr))
 	w.Header().Set("HX-Reswap", "outerHTML")
 	w.Header().Set("HX-Trigger", `{"hideundotoast": true}`)
-	templ.Handler(TimelineDay(tripID, dayData)).ServeHTTP(w, r)
+	h.renderDays(w, r, tripID, days)
 }
 
-func parseLodgingDetails(formData *EventFormData) (*domain.LodgingDetails, error) {
+// Reorder applies a drag-and-drop order to one day and returns the day's HTML.
+// The response always reflects the stored order, so a rejected or partially
+// honoured reorder (pinned events keep their slot) snaps the client back in line.
+func (h *EventHandler) Reorder(w http.ResponseWriter, r *http.Request) {
+	tripIDStr := chi.URLParam(r, "tripID")
+	tripID, err := strconv.Atoi(tripIDStr)
+	if err != nil {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return
+	}
+	date := parseDate(chi.URLParam(r, "date"))
+	if date.IsZero() {
+		http.Error(w, "Invalid date", http.StatusBadRequest)
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+	orderedIDs := make([]int, 0, len(r.Form["event_id"]))
+	for _, idStr := range r.Form["event_id"] {
+		id, convErr := strconv.Atoi(idStr)
+		if convErr != nil {
+			http.Error(w, "Invalid event ID", http.StatusBadRequest)
+			return
+		}
+		orderedIDs = append(orderedIDs, id)
+	}
+
+	events, err := h.eventService.ReorderEvents(r.Context(), tripID, date, orderedIDs)
+	if err != nil {
+		if !errors.Is(err, domain.ErrInvalidInput) && !errors.Is(err, domain.ErrConflict) {
+			http.Error(w, "Failed to reorder events", http.StatusInternalServerError)
+			return
+		}
+		// Stale or invalid order: re-render the stored order instead
+		events, err = h.eventService.ListByTripAndDate(r.Context(), tripID, date)
+		if err != nil {
+			http.Error(w, "Failed to load events", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if r.Header.Get("HX-Request") != "true" {
+		http.Redirect(w, r, "/trips/"+tripIDStr, http.StatusSeeOther)
+		return
+	}
+
+	w.Header().Set("HX-Retarget", fmt.Sprintf("#day-%s", date.Format("2006-01-02")))
+	w.Header().Set("HX-Reswap", "outerHTML")
+	h.renderDays(w, r, tripID, []TimelineDayData{{Date: date, Events: events}})
+}
+
+// Move moves an event to another day of the trip. The HTMX response renders the
+// target day and swaps the source day out-of-band so both columns stay current.
+func (h *EventHandler) Move(w http.ResponseWriter, r *http.Request) {
+	tripIDStr := chi.URLParam(r, "tripID")
+	tripID, err := strconv.Atoi(tripIDStr)
+	if err != nil {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return
+	}
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+	targetDate := parseDate(r.FormValue("date"))
+	if targetDate.IsZero() {
+		http.Error(w, "Invalid date", http.StatusBadRequest)
+		return
+	}
+
+	before, err := h.eventService.GetByTripAndID(r.Context(), tripID, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Event not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to load event", http.StatusInternalServerError)
+		return
+	}
+	sourceDate := before.EventDate
+
+	moved, err := h.eventService.MoveEventToDay(r.Context(), id, targetDate)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			http.Error(w, "Event not found", http.StatusNotFound)
+		case errors.Is(err, domain.ErrInvalidInput), errors.Is(err, domain.ErrDateRangeConflict):
+			http.Error(w, formErrorMessage(err), http.StatusUnprocessableEntity)
+		default:
+			http.Error(w, "Failed to move event", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if r.Header.Get("HX-Request") != "true" {
+		http.Redirect(w, r, "/trips/"+tripIDStr, http.StatusSeeOther)
+		return
+	}
+
+	targetEvents, err := h.eventService.ListByTripAndDate(r.Context(), tripID, moved.EventDate)
+	if err != nil {
+		http.Error(w, "Failed to load events", http.StatusInternalServerError)
+		return
+	}
+	days := []TimelineDayData{{Date: moved.EventDate, Events: targetEvents}}
+	if !sourceDate.Equal(moved.EventDate) {
+		sourceEvents, listErr := h.eventService.ListByTripAndDate(r.Context(), tripID, sourceDate)
+		if listErr != nil {
+			http.Error(w, "Failed to load events", http.StatusInternalServerError)
+			return
+		}
+		days = append(days, TimelineDayData{Date: sourceDate, Events: sourceEvents, SwapOOB: true})
+	}
+	days, err = h.withStayDays(r.Context(), tripID, days, service.StayDays(before, moved))
+	if err != nil {
+		http.Error(w, "Failed to load events", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Retarget", fmt.Sprintf("#day-%s", moved.EventDate.Format("2006-01-02")))
+	w.Header().Set("HX-Reswap", "outerHTML")
+	h.renderDays(w, r, tripID, days)
+}
+
+// withStayDays appends out-of-band renders of stayDates to days, skipping the
+// dates already in days. Lodging marks every day of its stay, so changing it
+// changes days other than its own.
+func (h *EventHandler) withStayDays(ctx context.Context, tripID int, days []TimelineDayData, stayDates []time.Time) ([]TimelineDayData, error) {
+	for _, date := range stayDates {
+		if slices.ContainsFunc(days, func(d TimelineDayData) bool { return d.Date.Equal(date) }) {
+			continue
+		}
+		events, err := h.eventService.ListByTripAndDate(ctx, tripID, date)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, TimelineDayData{Date: date, Events: events, SwapOOB: true})
+	}
+	return days, nil
+}
+
+// renderDays writes days as one HTMX response: the first is the swap target,
+// the others must be marked SwapOOB. Travel and stay annotations are added
+// here, and the nights-without-lodging strip is refreshed out-of-band.
+func (h *EventHandler) renderDays(w http.ResponseWriter, r *http.Request, tripID int, days []TimelineDayData) {
+	lodgings, err := h.eventService.ListStays(r.Context(), tripID)
+	if err != nil {
+		// Stay markers are advisory, like travel estimates
+		slog.WarnContext(r.Context(), "listing stays", "trip_id", tripID, "error", err)
+	}
+	for i := range days {
+		annotateDay(r.Context(), h.eventService, h.travelService, &days[i])
+		days[i].Stays = service.StaysOn(lodgings, days[i].Date)
+		templ.Handler(TimelineDay(tripID, days[i])).ServeHTTP(w, r)
+	}
+
+	nights, err := h.eventService.NightsWithoutLodging(r.Context(), tripID)
+	if err != nil {
+		slog.WarnContext(r.Context(), "checking nights without lodging", "trip_id", tripID, "error", err)
+		return
+	}
+	templ.Handler(LodgingGapsStrip(nights, true)).ServeHTTP(w, r)
+}
+
+// parseLodgingDetails reads the check-in and check-out times as local time in loc.
+func parseLodgingDetails(formData *EventFormData, loc *time.Location) (*domain.LodgingDetails, error) {
 	ld := &domain.LodgingDetails{
 		BookingReference: formData.BookingReference,
 	}
 	if formData.CheckInTime != "" {
-		t, err := time.ParseInLocation("2006-01-02T15:04", formData.CheckInTime, time.UTC)
+		t, err := time.ParseInLocation("2006-01-02T15:04", formData.CheckInTime, loc)
 		if err != nil {
 			return nil, fmt.Errorf("parsing check-in time: %w", err)
 		}
 		ld.CheckInTime = &t
 	}
 	if formData.CheckOutTime != "" {
-		t, err := time.ParseInLocation("2006-01-02T15:04", formData.CheckOutTime, time.UTC)
+		t, err := time.ParseInLocation("2006-01-02T15:04", formData.CheckOutTime, loc)
 		if err != nil {
 			return nil, fmt.Errorf("parsing check-out time: %w", err)
 		}
@@ -649,8 +955,8 @@ func parseTransitDetails(formData *EventFormData) *domain.TransitDetails {
 	}
 }
 
-func parseFlightDetails(r *http.Request) *domain.FlightDetails {
-	return &domain.FlightDetails{
+func parseFlightDetails(r *http.Request, segments []SegmentFormData) *domain.FlightDetails {
+	fd := &domain.FlightDetails{
 		Airline:           r.FormValue("airline"),
 		FlightNumber:      r.FormValue("flight_number"),
 		DepartureAirport:  r.FormValue("departure_airport"),
@@ -660,5 +966,101 @@ func parseFlightDetails(r *http.Request) *domain.FlightDetails {
 		DepartureGate:     r.FormValue("departure_gate"),
 		ArrivalGate:       r.FormValue("arrival_gate"),
 		BookingReference:  r.FormValue("booking_reference"),
+		DepartureTimeZone: r.FormValue("departure_time_zone"),
+		ArrivalTimeZone:   r.FormValue("arrival_time_zone"),
+	}
+	for i := range segments {
+		fd.Segments = append(fd.Segments, domain.FlightSegment{
+			Airline:           segments[i].Airline,
+			FlightNumber:      segments[i].FlightNumber,
+			DepartureAirport:  segments[i].DepartureAirport,
+			ArrivalAirport:    segments[i].ArrivalAirport,
+			DepartureTerminal: segments[i].DepartureTerminal,
+			DepartureGate:     segments[i].DepartureGate,
+			ArrivalTerminal:   segments[i].ArrivalTerminal,
+			ArrivalGate:       segments[i].ArrivalGate,
+		})
+	}
+	return fd
+}
+
+// parseSegmentRows reads the rows of the segment editor, whose fields repeat
+// once per row, skipping rows left entirely blank.
+func parseSegmentRows(r *http.Request) []SegmentFormData {
+	field := func(name string, i int) string {
+		values := r.Form[name]
+		if i < len(values) {
+			return strings.TrimSpace(values[i])
+		}
+		return ""
+	}
+	var rows []SegmentFormData
+	for i := range len(r.Form["segment_departure_airport"]) {
+		row := SegmentFormData{
+			Airline:           field("segment_airline", i),
+			FlightNumber:      field("segment_flight_number", i),
+			DepartureAirport:  field("segment_departure_airport", i),
+			ArrivalAirport:    field("segment_arrival_airport", i),
+			DepartureTime:     field("segment_departure_time", i),
+			ArrivalTime:       field("segment_arrival_time", i),
+			DepartureTerminal: field("segment_departure_terminal", i),
+			DepartureGate:     field("segment_departure_gate", i),
+			ArrivalTerminal:   field("segment_arrival_terminal", i),
+			ArrivalGate:       field("segment_arrival_gate", i),
+		}
+		if row != (SegmentFormData{}) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// parseSegmentTimes reads the times of the segment rows on the event's date,
+// each in its airport's zone or else the event's, and sets them on segments.
+// Like overnightArrival, a time that would fall before the one preceding it is
+// taken to be on the next day. Zones left empty are set to the one used.
+func parseSegmentTimes(dateStr, zone string, rows []SegmentFormData, segments []domain.FlightSegment) error {
+	var prev time.Time
+	read := func(i int, value string, segZone *string) (time.Time, error) {
+		if *segZone == "" {
+			*segZone = zone
+		}
+		loc, err := domain.LoadTimeZone(*segZone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("segment %d: %w", i+1, err)
+		}
+		t, err := parseDateAndTime(dateStr, value, loc)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("segment %d needs departure and arrival times", i+1)
+		}
+		if !prev.IsZero() && t.Before(prev) {
+			t = t.AddDate(0, 0, 1)
+		}
+		prev = t
+		return t, nil
+	}
+	for i := range segments {
+		var err error
+		if segments[i].DepartureTime, err = read(i, rows[i].DepartureTime, &segments[i].DepartureTimeZone); err != nil {
+			return err
+		}
+		if segments[i].ArrivalTime, err = read(i, rows[i].ArrivalTime, &segments[i].ArrivalTimeZone); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// segmentsSpan returns the first departure and last arrival of segments.
+func segmentsSpan(segments []domain.FlightSegment) (start, end time.Time) {
+	return segments[0].DepartureTime, segments[len(segments)-1].ArrivalTime
+}
+
+// overnightArrival moves a flight's arrival to the next day when, read on the
+// departure date, it would land before it took off.
+func overnightArrival(departure, arrival time.Time) time.Time {
+	if arrival.After(departure) {
+		return arrival
 	}
+	return arrival.AddDate(0, 0, 1)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/go-chi/chi/v5"
 
 	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/infra/travel"
 	"github.com/simopzz/traccia/internal/service"
 )
 
@@ -32,9 +33,21 @@ func (m *mockEventRepo) GetByID(ctx context.Context, id int) (*domain.Event, err
 	}
 	return nil, domain.ErrNotFound
 }
+func (m *mockEventRepo) GetByTripAndID(ctx context.Context, tripID, id int) (*domain.Event, error) {
+	if m.event != nil && m.event.ID == id && m.event.TripID == tripID {
+		return m.event, nil
+	}
+	return nil, domain.ErrNotFound
+}
 func (m *mockEventRepo) ListByTrip(ctx context.Context, tripID int) ([]domain.Event, error) {
 	return nil, nil
 }
+func (m *mockEventRepo) ListLodgingByTrip(ctx context.Context, tripID int) ([]domain.Event, error) {
+	if m.event != nil && m.event.TripID == tripID && m.event.Category == domain.CategoryLodging {
+		return []domain.Event{*m.event}, nil
+	}
+	return nil, nil
+}
 func (m *mockEventRepo) ListByTripAndDate(ctx context.Context, tripID int, date time.Time) ([]domain.Event, error) {
 	if m.event != nil && m.event.TripID == tripID && m.event.EventDate.Equal(date) {
 		// return empty list to simulate deletion for the list view
@@ -52,8 +65,24 @@ func (m *mockEventRepo) Update(ctx context.Context, id int, updater func(*domain
 func (m *mockEventRepo) Delete(ctx context.Context, id int) error {
 	return nil
 }
-func (m *mockEventRepo) Restore(ctx context.Context, id int) (*domain.Event, error) {
-	return nil, nil
+func (m *mockEventRepo) Restore(ctx context.Context, tripID, id int) (*domain.Event, error) {
+	if m.event != nil && m.event.ID == id && m.event.TripID == tripID {
+		return m.event, nil
+	}
+	return nil, domain.ErrNotFound
+}
+func (m *mockEventRepo) Reorder(ctx context.Context, tripID int, date time.Time, orderedIDs []int) error {
+	return nil
+}
+func (m *mockEventRepo) MoveToDay(ctx context.Context, id int, eventDate, startTime, endTime time.Time) (*domain.Event, error) {
+	if m.event != nil && m.event.ID == id {
+		moved := *m.event
+		moved.EventDate = eventDate
+		moved.StartTime = startTime
+		moved.EndTime = endTime
+		return &moved, nil
+	}
+	return nil, domain.ErrNotFound
 }
 func (m *mockEventRepo) CountByTrip(ctx context.Context, tripID int) (int, error) {
 	return 0, nil
@@ -62,6 +91,44 @@ func (m *mockEventRepo) GetLastEventByTrip(ctx context.Context, tripID int) (*do
 	return nil, nil
 }
 
+func (m *mockEventRepo) ListOutsideTripRange(ctx context.Context) ([]domain.OutOfRangeEvent, error) {
+	return nil, nil
+}
+
+func newTestTravelService() *service.TravelService {
+	return service.NewTravelService(travel.NewHaversineEstimator(nil))
+}
+
+// mockTripRepo for handler testing. Every trip spans all of 2026.
+type mockTripRepo struct{}
+
+func (m *mockTripRepo) Create(ctx context.Context, trip *domain.Trip) error {
+	return nil
+}
+func (m *mockTripRepo) GetByID(ctx context.Context, id int) (*domain.Trip, error) {
+	return &domain.Trip{
+		ID:        id,
+		Name:      "Test Trip",
+		StartDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+	}, nil
+}
+func (m *mockTripRepo) List(ctx context.Context, userID *string) ([]domain.Trip, error) {
+	return nil, nil
+}
+func (m *mockTripRepo) Update(ctx context.Context, id int, updater func(*domain.Trip) *domain.Trip) (*domain.Trip, error) {
+	return nil, domain.ErrNotFound
+}
+func (m *mockTripRepo) Delete(ctx context.Context, id int) error {
+	return nil
+}
+func (m *mockTripRepo) CountEventsByTripAndDateRange(ctx context.Context, tripID int, newStart, newEnd time.Time) (int, error) {
+	return 0, nil
+}
+func (m *mockTripRepo) CountEventsByTripGroupedByDate(ctx context.Context, tripID int, newStart, newEnd time.Time) ([]domain.DateEventCount, error) {
+	return nil, nil
+}
+
 func TestEventHandler_Delete_ScriptInjection(t *testing.T) {
 	eventDate := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
 	event := &domain.Event{
@@ -72,8 +139,8 @@ func TestEventHandler_Delete_ScriptInjection(t *testing.T) {
 	}
 
 	repo := &mockEventRepo{event: event}
-	svc := service.NewEventService(repo)
-	h := NewEventHandler(svc)
+	svc := service.NewEventService(repo, &mockTripRepo{})
+	h := NewEventHandler(svc, newTestTravelService())
 
 	// Create request
 	r := httptest.NewRequest("DELETE", "/trips/1/events/1", nil)
@@ -107,6 +174,139 @@ func TestEventHandler_Delete_ScriptInjection(t *testing.T) {
 	}
 }
 
+func TestEventHandler_Move_SwapsBothDays(t *testing.T) {
+	event := &domain.Event{
+		ID:        1,
+		TripID:    1,
+		Title:     "Museum",
+		StartTime: time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+		EventDate: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	repo := &mockEventRepo{event: event}
+	h := NewEventHandler(service.NewEventService(repo, &mockTripRepo{}), newTestTravelService())
+
+	r := httptest.NewRequest("PUT", "/trips/1/events/1/move", strings.NewReader("date=2026-05-03"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("HX-Request", "true")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("tripID", "1")
+	rctx.URLParams.Add("id", "1")
+	r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+	h.Move(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Move() status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if got := w.Header().Get("HX-Retarget"); got != "#day-2026-05-03" {
+		t.Errorf("Move() HX-Retarget = %q, want %q", got, "#day-2026-05-03")
+	}
+
+	body := w.Body.String()
+	if !strings.Contains(body, `id="day-2026-05-03"`) {
+		t.Errorf("Move() body missing target day")
+	}
+	if !strings.Contains(body, `id="day-2026-05-01" hx-swap-oob="outerHTML"`) {
+		t.Errorf("Move() body missing out-of-band source day.\nGot: %s", body)
+	}
+}
+
+func TestEventHandler_Delete_Lodging_SwapsStayDays(t *testing.T) {
+	checkIn := time.Date(2026, 5, 1, 15, 0, 0, 0, time.UTC)
+	checkOut := time.Date(2026, 5, 3, 11, 0, 0, 0, time.UTC)
+	event := &domain.Event{
+		ID:        1,
+		TripID:    1,
+		Title:     "Hotel Roma",
+		Category:  domain.CategoryLodging,
+		StartTime: checkIn,
+		EndTime:   checkIn.Add(time.Hour),
+		EventDate: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+		Lodging:   &domain.LodgingDetails{CheckInTime: &checkIn, CheckOutTime: &checkOut},
+	}
+
+	repo := &mockEventRepo{event: event}
+	h := NewEventHandler(service.NewEventService(repo, &mockTripRepo{}), newTestTravelService())
+
+	r := httptest.NewRequest("DELETE", "/trips/1/events/1", nil)
+	r.Header.Set("HX-Request", "true")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("tripID", "1")
+	rctx.URLParams.Add("id", "1")
+	r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+	h.Delete(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Delete() status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	body := w.Body.String()
+	for _, date := range []string{"2026-05-02", "2026-05-03"} {
+		if !strings.Contains(body, `id="day-`+date+`" hx-swap-oob="outerHTML"`) {
+			t.Errorf("Delete() body missing out-of-band stay day %s", date)
+		}
+	}
+	if strings.Contains(body, `id="day-2026-05-01" hx-swap-oob`) {
+		t.Errorf("Delete() rendered the target day out-of-band")
+	}
+}
+
+func TestEventHandler_WrongTrip_NotFound(t *testing.T) {
+	event := &domain.Event{
+		ID:        1,
+		TripID:    1,
+		Title:     "Museum",
+		StartTime: time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+		EventDate: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		handler func(h *EventHandler) http.HandlerFunc
+		name    string
+		method  string
+		form    string
+	}{
+		{name: "edit page", method: "GET", handler: func(h *EventHandler) http.HandlerFunc { return h.EditPage }},
+		{name: "update", method: "PUT", form: "title=Hijacked&date=2026-05-01&start_time=10:00&end_time=12:00&category=activity", handler: func(h *EventHandler) http.HandlerFunc { return h.Update }},
+		{name: "delete", method: "DELETE", handler: func(h *EventHandler) http.HandlerFunc { return h.Delete }},
+		{name: "restore", method: "POST", handler: func(h *EventHandler) http.HandlerFunc { return h.Restore }},
+		{name: "move", method: "PUT", form: "date=2026-05-02", handler: func(h *EventHandler) http.HandlerFunc { return h.Move }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockEventRepo{event: event}
+			h := NewEventHandler(service.NewEventService(repo, &mockTripRepo{}), newTestTravelService())
+
+			r := httptest.NewRequest(tt.method, "/trips/2/events/1", strings.NewReader(tt.form))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			r.Header.Set("HX-Request", "true")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("tripID", "2")
+			rctx.URLParams.Add("id", "1")
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+			tt.handler(h)(w, r)
+
+			if w.Code != http.StatusNotFound {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+			}
+			if event.Title != "Museum" {
+				t.Errorf("event of trip 1 was modified through trip 2: %+v", event)
+			}
+		})
+	}
+}
+
 func TestEventHandler_Update_Flight(t *testing.T) {
 	flightEvent := &domain.Event{
 		ID:        1,
@@ -144,8 +344,8 @@ func TestEventHandler_Update_Flight(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := &mockEventRepo{event: flightEvent}
-			svc := service.NewEventService(repo)
-			h := NewEventHandler(svc)
+			svc := service.NewEventService(repo, &mockTripRepo{})
+			h := NewEventHandler(svc, newTestTravelService())
 
 			body := strings.NewReader(tt.form)
 			r := httptest.NewRequest("POST", "/trips/1/events/1", body)
@@ -169,8 +369,8 @@ func TestEventHandler_Update_Flight(t *testing.T) {
 
 func TestEventHandler_Create_Flight(t *testing.T) {
 	repo := &mockEventRepo{}
-	svc := service.NewEventService(repo)
-	h := NewEventHandler(svc)
+	svc := service.NewEventService(repo, &mockTripRepo{})
+	h := NewEventHandler(svc, newTestTravelService())
 
 	form := strings.NewReader("title=Flight to Paris&date=2026-06-01&start_time=10:00&end_time=12:00&category=flight&airline=BA&flight_number=123&departure_airport=LHR&arrival_airport=CDG")
 	r := httptest.NewRequest("POST", "/trips/1/events", form)
@@ -208,3 +408,82 @@ func TestEventHandler_Create_Flight(t *testing.T) {
 		t.Errorf("Flight.ArrivalAirport = %q, want %q", repo.capturedEvent.Flight.ArrivalAirport, "CDG")
 	}
 }
+
+func TestEventHandler_Create_TimeZones(t *testing.T) {
+	tests := []struct {
+		wantStart time.Time
+		wantEnd   time.Time
+		name      string
+		form      string
+		wantZone  string
+		wantCode  int
+	}{
+		{
+			name:      "times are read in the event zone",
+			form:      "title=Sushi&category=food&date=2026-05-01&start_time=10:00&end_time=12:00&time_zone=Asia/Tokyo",
+			wantCode:  http.StatusOK,
+			wantStart: time.Date(2026, 5, 1, 1, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 5, 1, 3, 0, 0, 0, time.UTC),
+			wantZone:  "Asia/Tokyo",
+		},
+		{
+			name:      "missing zone falls back to UTC trip zone",
+			form:      "title=Museum&category=activity&date=2026-05-01&start_time=10:00&end_time=12:00",
+			wantCode:  http.StatusOK,
+			wantStart: time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+			wantZone:  "UTC",
+		},
+		{
+			name: "overnight flight lands the next day in the arrival zone",
+			form: "title=To+New+York&category=flight&date=2026-05-01&start_time=22:00&end_time=06:00&time_zone=Europe/Rome" +
+				"&departure_airport=FCO&arrival_airport=JFK&arrival_time_zone=America/New_York",
+			wantCode:  http.StatusOK,
+			wantStart: time.Date(2026, 5, 1, 20, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 5, 2, 10, 0, 0, 0, time.UTC),
+			wantZone:  "Europe/Rome",
+		},
+		{
+			name:     "unknown zone is a field error",
+			form:     "title=Museum&category=activity&date=2026-05-01&start_time=10:00&end_time=12:00&time_zone=Mars/Olympus",
+			wantCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockEventRepo{}
+			h := NewEventHandler(service.NewEventService(repo, &mockTripRepo{}), newTestTravelService())
+
+			r := httptest.NewRequest("POST", "/trips/1/events", strings.NewReader(tt.form))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			r.Header.Set("HX-Request", "true")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("tripID", "1")
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+			h.Create(w, r)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("Create() status = %d, want %d\nBody: %s", w.Code, tt.wantCode, w.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				if !strings.Contains(w.Body.String(), "Unknown time zone") {
+					t.Errorf("Create() body missing time zone error")
+				}
+				return
+			}
+			got := repo.capturedEvent
+			if !got.StartTime.Equal(tt.wantStart) {
+				t.Errorf("StartTime = %v, want %v", got.StartTime.UTC(), tt.wantStart)
+			}
+			if !got.EndTime.Equal(tt.wantEnd) {
+				t.Errorf("EndTime = %v, want %v", got.EndTime.UTC(), tt.wantEnd)
+			}
+			if got.TimeZone != tt.wantZone {
+				t.Errorf("TimeZone = %q, want %q", got.TimeZone, tt.wantZone)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+// ExportPDF serves the trip's day-by-day itinerary as a printable PDF.
+func (h *TripHandler) ExportPDF(w http.ResponseWriter, r *http.Request) {
+	trip, ok := loadTrip(w, r, h.tripService)
+	if !ok {
+		return
+	}
+	events, err := h.eventService.ListByTrip(r.Context(), trip.ID)
+	if err != nil {
+		http.Error(w, "Failed to load events", http.StatusInternalServerError)
+		return
+	}
+
+	// Render fully before writing so a failure is still a clean error page
+	var buf bytes.Buffer
+	if err := h.exportService.PDF(r.Context(), &buf, trip, itineraryDays(trip, events)); err != nil {
+		slog.ErrorContext(r.Context(), "exporting PDF", "trip_id", trip.ID, "error", err)
+		http.Error(w, "Failed to export trip", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="trip-%d.pdf"`, trip.ID))
+	_, _ = w.Write(buf.Bytes())
+}
+
+// itineraryDays lays events out day by day exactly as the timeline shows them.
+func itineraryDays(trip *domain.Trip, events []domain.Event) []domain.ItineraryDay {
+	timeline := buildTimelineDays(trip, events)
+	days := make([]domain.ItineraryDay, len(timeline))
+	for i, d := range timeline {
+		days[i] = domain.ItineraryDay{Date: d.Date, Number: d.DayNumber, Events: d.Events, Stays: d.Stays}
+	}
+	return days
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/infra/ical"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+type mockPDFExporter struct {
+	err  error
+	days []domain.ItineraryDay
+}
+
+func (m *mockPDFExporter) ExportPDF(ctx context.Context, w io.Writer, trip *domain.Trip, days []domain.ItineraryDay) error {
+	m.days = days
+	if m.err != nil {
+		return m.err
+	}
+	_, err := io.WriteString(w, "%PDF-1.4")
+	return err
+}
+
+func TestTripHandler_ExportPDF(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "exporter fails", err: errors.New("boom"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := &mockPDFExporter{err: tt.err}
+			h := NewTripHandler(service.NewTripService(&mockTripRepo{}), service.NewEventService(&mockEventRepo{}, &mockTripRepo{}),
+				newTestTravelService(), service.NewExportService(exporter, ical.NewExporter(), nil, nil))
+
+			r := httptest.NewRequest("GET", "/trips/1/export.pdf", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "1")
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+			h.ExportPDF(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("ExportPDF() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if len(exporter.days) != 365 || exporter.days[0].Number != 1 {
+				t.Errorf("exporter got %d days, want the trip's 365", len(exporter.days))
+			}
+			if tt.err == nil && w.Header().Get("Content-Type") != "application/pdf" {
+				t.Errorf("Content-Type = %q", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
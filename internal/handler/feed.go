@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/a-h/templ"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+type FeedHandler struct {
+	feedService   *service.CalendarFeedService
+	exportService *service.ExportService
+}
+
+func NewFeedHandler(feedService *service.CalendarFeedService, exportService *service.ExportService) *FeedHandler {
+	return &FeedHandler{feedService: feedService, exportService: exportService}
+}
+
+// Serve publishes a trip's calendar to whoever holds the feed token. Calendar
+// apps poll this URL, so conditional requests are answered with 304 when
+// nothing changed since the ETag or Last-Modified they hold.
+func (h *FeedHandler) Serve(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := h.feedService.Snapshot(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Feed not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to load feed", http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := h.exportService.Calendar(&buf, snapshot.Trip, snapshot.Events); err != nil {
+		http.Error(w, "Failed to render feed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Set("ETag", fmt.Sprintf(`"%d-%d"`, snapshot.Trip.ID, snapshot.LastModified.UnixNano()))
+	http.ServeContent(w, r, "", snapshot.LastModified, bytes.NewReader(buf.Bytes()))
+}
+
+// List renders the feed management section of the trip edit page.
+func (h *FeedHandler) List(w http.ResponseWriter, r *http.Request) {
+	tripID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return
+	}
+	h.renderFeeds(w, r, tripID)
+}
+
+func (h *FeedHandler) Create(w http.ResponseWriter, r *http.Request) {
+	tripIDStr := chi.URLParam(r, "id")
+	tripID, err := strconv.Atoi(tripIDStr)
+	if err != nil {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.feedService.Create(r.Context(), tripID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Trip not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to create feed", http.StatusInternalServerError)
+		return
+	}
+
+	if r.Header.Get("HX-Request") != "true" {
+		http.Redirect(w, r, "/trips/"+tripIDStr+"/edit", http.StatusSeeOther)
+		return
+	}
+	h.renderFeeds(w, r, tripID)
+}
+
+func (h *FeedHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	tripIDStr := chi.URLParam(r, "id")
+	tripID, err := strconv.Atoi(tripIDStr)
+	if err != nil {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return
+	}
+	id, err := strconv.Atoi(chi.URLParam(r, "feedID"))
+	if err != nil {
+		http.Error(w, "Invalid feed ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.feedService.Revoke(r.Context(), tripID, id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Feed not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to revoke feed", http.StatusInternalServerError)
+		return
+	}
+
+	if r.Header.Get("HX-Request") != "true" {
+		http.Redirect(w, r, "/trips/"+tripIDStr+"/edit", http.StatusSeeOther)
+		return
+	}
+	h.renderFeeds(w, r, tripID)
+}
+
+func (h *FeedHandler) renderFeeds(w http.ResponseWriter, r *http.Request, tripID int) {
+	feeds, err := h.feedService.ListByTrip(r.Context(), tripID)
+	if err != nil {
+		http.Error(w, "Failed to load feeds", http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(CalendarFeedsSection(tripID, feeds, requestOrigin(r))).ServeHTTP(w, r)
+}
+
+// requestOrigin is the scheme and host the user reached traccia through, so
+// feed URLs copied from the page also work from their phone.
+func requestOrigin(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	host := r.Host
+	if forwarded := r.Header.Get("X-Forwarded-Host"); forwarded != "" {
+		host = forwarded
+	}
+	return scheme + "://" + host
+}
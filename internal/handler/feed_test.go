@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/infra/ical"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+// mockFeedRepo serves a single feed for trip 1.
+type mockFeedRepo struct {
+	feed         domain.CalendarFeed
+	lastModified time.Time
+}
+
+func (m *mockFeedRepo) Create(ctx context.Context, feed *domain.CalendarFeed) error {
+	return nil
+}
+func (m *mockFeedRepo) GetByToken(ctx context.Context, token string) (*domain.CalendarFeed, error) {
+	if token != m.feed.Token {
+		return nil, domain.ErrNotFound
+	}
+	return &m.feed, nil
+}
+func (m *mockFeedRepo) ListByTrip(ctx context.Context, tripID int) ([]domain.CalendarFeed, error) {
+	return []domain.CalendarFeed{m.feed}, nil
+}
+func (m *mockFeedRepo) Delete(ctx context.Context, tripID, id int) error {
+	return nil
+}
+func (m *mockFeedRepo) TripLastModified(ctx context.Context, tripID int) (time.Time, error) {
+	return m.lastModified, nil
+}
+
+func TestFeedHandler_Serve(t *testing.T) {
+	feeds := &mockFeedRepo{
+		feed:         domain.CalendarFeed{ID: 1, TripID: 1, Token: "s3cret"},
+		lastModified: time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC),
+	}
+	h := NewFeedHandler(service.NewCalendarFeedService(feeds, &mockTripRepo{}, &mockEventRepo{}), service.NewExportService(nil, ical.NewExporter(), nil, nil))
+	router := chi.NewRouter()
+	router.Get("/feeds/{token}.ics", h.Serve)
+
+	get := func(path string, header http.Header) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", path, nil)
+		for k, v := range header {
+			r.Header[k] = v
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	w := get("/feeds/s3cret.ics", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Serve() status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if !strings.HasPrefix(w.Body.String(), "BEGIN:VCALENDAR") {
+		t.Errorf("Serve() body is not a calendar: %q", w.Body.String())
+	}
+	if got := w.Header().Get("Last-Modified"); got != "Wed, 01 Apr 2026 12:00:00 GMT" {
+		t.Errorf("Serve() Last-Modified = %q", got)
+	}
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("Serve() missing ETag")
+	}
+
+	t.Run("matching ETag is not modified", func(t *testing.T) {
+		w := get("/feeds/s3cret.ics", http.Header{"If-None-Match": {etag}})
+		if w.Code != http.StatusNotModified {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotModified)
+		}
+	})
+
+	t.Run("unchanged since Last-Modified is not modified", func(t *testing.T) {
+		w := get("/feeds/s3cret.ics", http.Header{"If-Modified-Since": {"Wed, 01 Apr 2026 12:00:00 GMT"}})
+		if w.Code != http.StatusNotModified {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotModified)
+		}
+	})
+
+	t.Run("a change produces a new ETag", func(t *testing.T) {
+		feeds.lastModified = feeds.lastModified.Add(time.Second)
+		defer func() { feeds.lastModified = feeds.lastModified.Add(-time.Second) }()
+		w := get("/feeds/s3cret.ics", http.Header{"If-None-Match": {etag}})
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if w.Header().Get("ETag") == etag {
+			t.Errorf("ETag unchanged after a change")
+		}
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		if w := get("/feeds/guess.ics", nil); w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
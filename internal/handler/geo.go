@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+// ExportGPX serves the trip's places as GPX 1.1 waypoints and daily routes.
+func (h *TripHandler) ExportGPX(w http.ResponseWriter, r *http.Request) {
+	h.serveMapExport(w, r, "application/gpx+xml", "gpx", h.exportService.GPX)
+}
+
+// ExportKML serves the trip's places as KML, one folder per day.
+func (h *TripHandler) ExportKML(w http.ResponseWriter, r *http.Request) {
+	h.serveMapExport(w, r, "application/vnd.google-earth.kml+xml", "kml", h.exportService.KML)
+}
+
+// ExportGeoJSON serves the trip's places as a GeoJSON FeatureCollection.
+func (h *TripHandler) ExportGeoJSON(w http.ResponseWriter, r *http.Request) {
+	h.serveMapExport(w, r, "application/geo+json", "geojson", h.exportService.GeoJSON)
+}
+
+func (h *TripHandler) serveMapExport(w http.ResponseWriter, r *http.Request, contentType, extension string,
+	write func(io.Writer, *domain.Trip, []domain.ItineraryDay) error,
+) {
+	trip, ok := loadTrip(w, r, h.tripService)
+	if !ok {
+		return
+	}
+	events, err := h.eventService.ListByTrip(r.Context(), trip.ID)
+	if err != nil {
+		http.Error(w, "Failed to load events", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="trip-%d.%s"`, trip.ID, extension))
+	_ = write(w, trip, itineraryDays(trip, events))
+}
@@ -1,8 +1,16 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
 )
 
 func formatDateInput(t time.Time) string {
@@ -23,11 +31,76 @@ func parseDate(s string) time.Time {
 	return t
 }
 
-func parseDateAndTime(dateStr, timeStr string) (time.Time, error) {
-	return time.Parse("2006-01-02 15:04", dateStr+" "+timeStr)
+// parseDateAndTime reads a form date and wall-clock time as local time in loc.
+func parseDateAndTime(dateStr, timeStr string, loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02 15:04", dateStr+" "+timeStr, loc)
+}
+
+// formLocations resolves the zones a submitted event's start and end times
+// are entered in. Flights may override either side; unknown zones are
+// recorded in formErrors and fall back to the event zone (or UTC).
+func formLocations(zone string, fd *domain.FlightDetails, formErrors map[string]string) (start, end *time.Location) {
+	loc, err := domain.LoadTimeZone(zone)
+	if err != nil {
+		formErrors["time_zone"] = "Unknown time zone"
+		loc = time.UTC
+	}
+	start, end = loc, loc
+	if fd == nil {
+		return start, end
+	}
+	if fd.DepartureTimeZone != "" {
+		if start, err = domain.LoadTimeZone(fd.DepartureTimeZone); err != nil {
+			formErrors["departure_time_zone"] = "Unknown time zone"
+			start = loc
+		}
+	}
+	if fd.ArrivalTimeZone != "" {
+		if end, err = domain.LoadTimeZone(fd.ArrivalTimeZone); err != nil {
+			formErrors["arrival_time_zone"] = "Unknown time zone"
+			end = loc
+		}
+	}
+	return start, end
 }
 
+// formErrorMessage returns the user-facing part of a domain validation error,
+// stripping the sentinel prefix to avoid leaking internals to the UI.
+func formErrorMessage(err error) string {
+	msg := err.Error()
+	for _, sentinel := range []error{domain.ErrInvalidInput, domain.ErrDateRangeConflict, domain.ErrScheduleConflict} {
+		if errors.Is(err, sentinel) {
+			msg = strings.TrimPrefix(msg, sentinel.Error()+": ")
+		}
+	}
+	return msg
+}
+
+// getUserID returns the signed-in user's UUID, or nil when nobody is signed in.
 func getUserID(r *http.Request) *string {
-	// TODO: Extract from Supabase JWT
-	return nil // anonymous for now
+	p, ok := service.PrincipalFromContext(r.Context())
+	if !ok {
+		return nil
+	}
+	return &p.UserID
+}
+
+// loadTrip loads the trip named by the id URL parameter, writing the error
+// response itself when it cannot.
+func loadTrip(w http.ResponseWriter, r *http.Request, trips *service.TripService) (*domain.Trip, bool) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return nil, false
+	}
+	trip, err := trips.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Trip not found", http.StatusNotFound)
+			return nil, false
+		}
+		http.Error(w, "Failed to load trip", http.StatusInternalServerError)
+		return nil, false
+	}
+	return trip, true
 }
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+// ExportMarkdown serves the itinerary as Markdown, for pasting into wikis and chats.
+func (h *TripHandler) ExportMarkdown(w http.ResponseWriter, r *http.Request) {
+	h.serveItinerary(w, r, "text/markdown; charset=utf-8", "md", h.exportService.Markdown)
+}
+
+// ExportText serves the itinerary as plain text.
+func (h *TripHandler) ExportText(w http.ResponseWriter, r *http.Request) {
+	h.serveItinerary(w, r, "text/plain; charset=utf-8", "txt", h.exportService.Text)
+}
+
+func (h *TripHandler) serveItinerary(w http.ResponseWriter, r *http.Request, contentType, extension string,
+	write func(io.Writer, *domain.Trip, []domain.ItineraryDay) error,
+) {
+	trip, ok := loadTrip(w, r, h.tripService)
+	if !ok {
+		return
+	}
+	events, err := h.eventService.ListByTrip(r.Context(), trip.ID)
+	if err != nil {
+		http.Error(w, "Failed to load events", http.StatusInternalServerError)
+		return
+	}
+
+	// Inline, so the browser shows the text ready to copy
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="trip-%d.%s"`, trip.ID, extension))
+	_ = write(w, trip, itineraryDays(trip, events))
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/simopzz/traccia/internal/infra/textexport"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+func TestTripHandler_ExportMarkdown(t *testing.T) {
+	h := NewTripHandler(service.NewTripService(&mockTripRepo{}), service.NewEventService(&mockEventRepo{}, &mockTripRepo{}),
+		newTestTravelService(), service.NewExportService(nil, nil, nil, textexport.NewExporter()))
+
+	r := httptest.NewRequest("GET", "/trips/1/itinerary.md", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+	h.ExportMarkdown(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("ExportMarkdown() status = %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/markdown; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if !strings.HasPrefix(w.Body.String(), "# Test Trip\n") || !strings.Contains(w.Body.String(), "## Day 365 ") {
+		t.Errorf("body does not cover the trip:\n%.200s", w.Body.String())
+	}
+}
@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/a-h/templ"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+type MemberHandler struct {
+	memberService *service.MemberService
+	tripService   *service.TripService
+}
+
+func NewMemberHandler(memberService *service.MemberService, tripService *service.TripService) *MemberHandler {
+	return &MemberHandler{memberService: memberService, tripService: tripService}
+}
+
+// List renders the members section of the trip edit page.
+func (h *MemberHandler) List(w http.ResponseWriter, r *http.Request) {
+	tripID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return
+	}
+	h.renderMembers(w, r, tripID, "")
+}
+
+// Invite invites the username or email in the invitee field.
+func (h *MemberHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	tripID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return
+	}
+	if err = r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	_, err = h.memberService.Invite(r.Context(), tripID, r.FormValue("invitee"), domain.TripRole(r.FormValue("role")))
+	h.respond(w, r, tripID, err)
+}
+
+func (h *MemberHandler) CancelInvitation(w http.ResponseWriter, r *http.Request) {
+	tripID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return
+	}
+	id, err := strconv.Atoi(chi.URLParam(r, "invitationID"))
+	if err != nil {
+		http.Error(w, "Invalid invitation ID", http.StatusBadRequest)
+		return
+	}
+	h.respond(w, r, tripID, h.memberService.CancelInvitation(r.Context(), tripID, id))
+}
+
+func (h *MemberHandler) SetRole(w http.ResponseWriter, r *http.Request) {
+	tripID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return
+	}
+	if err = r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	_, err = h.memberService.SetRole(r.Context(), tripID, chi.URLParam(r, "userID"), domain.TripRole(r.FormValue("role")))
+	h.respond(w, r, tripID, err)
+}
+
+// Remove takes a member off the trip. Members removing themselves leave it
+// and are sent back to their trip list.
+func (h *MemberHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	tripID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return
+	}
+	userID := chi.URLParam(r, "userID")
+	err = h.memberService.Remove(r.Context(), tripID, userID)
+
+	if p, ok := service.PrincipalFromContext(r.Context()); ok && p.UserID == userID && err == nil {
+		if r.Header.Get("HX-Request") == "true" {
+			w.Header().Set("HX-Redirect", "/")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+	h.respond(w, r, tripID, err)
+}
+
+// Invitations renders the invitations waiting for the signed-in user.
+func (h *MemberHandler) Invitations(w http.ResponseWriter, r *http.Request) {
+	h.renderInvitations(w, r)
+}
+
+// Accept joins the invitation's trip and opens it.
+func (h *MemberHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "invitationID"))
+	if err != nil {
+		http.Error(w, "Invalid invitation ID", http.StatusBadRequest)
+		return
+	}
+
+	member, err := h.memberService.Accept(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Invitation not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to accept invitation", http.StatusInternalServerError)
+		return
+	}
+
+	tripURL := "/trips/" + strconv.Itoa(member.TripID)
+	if r.Header.Get("HX-Request") == "true" {
+		w.Header().Set("HX-Redirect", tripURL)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	http.Redirect(w, r, tripURL, http.StatusSeeOther)
+}
+
+func (h *MemberHandler) Decline(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "invitationID"))
+	if err != nil {
+		http.Error(w, "Invalid invitation ID", http.StatusBadRequest)
+		return
+	}
+
+	if err = h.memberService.Decline(r.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Invitation not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to decline invitation", http.StatusInternalServerError)
+		return
+	}
+
+	if r.Header.Get("HX-Request") != "true" {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+	h.renderInvitations(w, r)
+}
+
+// respond re-renders the members section after a change, or reports err.
+func (h *MemberHandler) respond(w http.ResponseWriter, r *http.Request, tripID int, err error) {
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			http.Error(w, "Member not found", http.StatusNotFound)
+		case errors.Is(err, domain.ErrInvalidInput):
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			h.renderMembers(w, r, tripID, formErrorMessage(err))
+		default:
+			http.Error(w, "Failed to update members", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if r.Header.Get("HX-Request") != "true" {
+		http.Redirect(w, r, "/trips/"+strconv.Itoa(tripID)+"/edit", http.StatusSeeOther)
+		return
+	}
+	h.renderMembers(w, r, tripID, "")
+}
+
+func (h *MemberHandler) renderMembers(w http.ResponseWriter, r *http.Request, tripID int, formError string) {
+	invitations, err := h.memberService.ListInvitations(r.Context(), tripID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Trip not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to load members", http.StatusInternalServerError)
+		return
+	}
+	trip, err := h.tripService.GetByID(r.Context(), tripID)
+	if err != nil {
+		http.Error(w, "Failed to load members", http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(MembersSection(trip, invitations, formError)).ServeHTTP(w, r)
+}
+
+func (h *MemberHandler) renderInvitations(w http.ResponseWriter, r *http.Request) {
+	invitations, err := h.memberService.PendingInvitations(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to load invitations", http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(InvitationsPanel(invitations)).ServeHTTP(w, r)
+}
+
+// companions returns the usernames of the trip's members other than the
+// signed-in user, for trip cards.
+func companions(ctx context.Context, trip *domain.Trip) []string {
+	p, _ := service.PrincipalFromContext(ctx)
+	var names []string
+	for _, m := range trip.Members {
+		if m.UserID != p.UserID {
+			names = append(names, m.Username)
+		}
+	}
+	return names
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+const (
+	ownerID  = "00000000-0000-0000-0000-00000000000a"
+	editorID = "00000000-0000-0000-0000-00000000000b"
+	viewerID = "00000000-0000-0000-0000-00000000000c"
+)
+
+// sharedTripRepo serves a trip planned by an owner, an editor and a viewer.
+type sharedTripRepo struct {
+	mockTripRepo
+}
+
+func (m *sharedTripRepo) GetByID(ctx context.Context, id int) (*domain.Trip, error) {
+	trip, _ := m.mockTripRepo.GetByID(ctx, id)
+	trip.UserID = ownerID
+	trip.Members = []domain.TripMember{
+		{TripID: id, UserID: ownerID, Username: "bo", Role: domain.RoleOwner},
+		{TripID: id, UserID: editorID, Username: "ada", Role: domain.RoleEditor},
+		{TripID: id, UserID: viewerID, Username: "cy", Role: domain.RoleViewer},
+	}
+	return trip, nil
+}
+
+func TestTripHandler_Detail_Roles(t *testing.T) {
+	trips := &sharedTripRepo{}
+	h := NewTripHandler(service.NewTripService(trips), service.NewEventService(&mockEventRepo{}, trips), newTestTravelService(), nil)
+	router := chi.NewRouter()
+	router.Get("/trips/{id}", h.Detail)
+
+	tests := []struct {
+		name     string
+		userID   string
+		want     []string
+		dontWant []string
+		wantCode int
+	}{
+		{name: "owner", userID: ownerID, wantCode: http.StatusOK, want: []string{"+ Add Event", "/trips/1/edit"}, dontWant: []string{"Leave"}},
+		{name: "editor", userID: editorID, wantCode: http.StatusOK, want: []string{"+ Add Event", "/trips/1/edit", "Leave"}},
+		{name: "viewer", userID: viewerID, wantCode: http.StatusOK, want: []string{"View only", "Leave"}, dontWant: []string{"+ Add Event", "/trips/1/edit", "sheet-container"}},
+		{name: "stranger", userID: "00000000-0000-0000-0000-00000000000d", wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/trips/1", nil)
+			r = r.WithContext(service.WithPrincipal(r.Context(), service.Principal{UserID: tt.userID}))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantCode)
+			}
+			body := w.Body.String()
+			for _, s := range tt.want {
+				if !strings.Contains(body, s) {
+					t.Errorf("body does not contain %q", s)
+				}
+			}
+			for _, s := range tt.dontWant {
+				if strings.Contains(body, s) {
+					t.Errorf("body contains %q", s)
+				}
+			}
+		})
+	}
+}
+
+func TestTripCard_Companions(t *testing.T) {
+	trip, _ := (&sharedTripRepo{}).GetByID(context.Background(), 1)
+	ctx := service.WithPrincipal(context.Background(), service.Principal{UserID: editorID})
+
+	var b strings.Builder
+	if err := TripCard(*trip).Render(ctx, &b); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(b.String(), "With bo, cy") {
+		t.Errorf("TripCard() = %s, want the other members listed", b.String())
+	}
+}
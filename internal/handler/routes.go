@@ -7,7 +7,7 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 )
 
-func NewRouter(tripHandler *TripHandler, eventHandler *EventHandler) http.Handler {
+func NewRouter(tripHandler *TripHandler, eventHandler *EventHandler, feedHandler *FeedHandler, archiveHandler *ArchiveHandler, airportHandler *AirportHandler, authHandler *AuthHandler, shareHandler *ShareHandler, memberHandler *MemberHandler, importHandler *ImportHandler) http.Handler {
 	r := chi.NewRouter()
 
 	r.Use(middleware.Logger)
@@ -17,26 +17,79 @@ func NewRouter(tripHandler *TripHandler, eventHandler *EventHandler) http.Handle
 	// Static files
 	r.Handle("/static/*", http.StripPrefix("/static/", http.FileServer(http.Dir("static"))))
 
+	// Calendar feeds are authorised by their secret token, not a session
+	r.Get("/feeds/{token}.ics", feedHandler.Serve)
+
+	// Share links are authorised by their secret token and never change the trip
+	r.With(RejectMutations).Handle("/s/{token}", http.HandlerFunc(shareHandler.View))
+
+	// Accounts
+	r.Get("/login", authHandler.LoginPage)
+	r.Post("/login", authHandler.Login)
+	r.Get("/signup", authHandler.SignupPage)
+	r.Post("/signup", authHandler.Signup)
+
 	// Routes
 	r.Group(func(r chi.Router) {
-		// TODO: enable r.Use(authMiddleware) when Supabase auth is ready
+		r.Use(authHandler.Middleware)
+
+		r.Post("/logout", authHandler.Logout)
 
 		// Trip routes
 		r.Get("/", tripHandler.List)
 		r.Get("/trips/new", tripHandler.NewPage)
 		r.Post("/trips", tripHandler.Create)
+		r.Post("/trips/restore", archiveHandler.Import)
 		r.Get("/trips/{id}", tripHandler.Detail)
 		r.Get("/trips/{id}/edit", tripHandler.EditPage)
+		r.Get("/trips/{id}/calendar.ics", tripHandler.Calendar)
+		r.Get("/trips/{id}/export.pdf", tripHandler.ExportPDF)
+		r.Get("/trips/{id}/export.json", archiveHandler.Export)
+		r.Get("/trips/{id}/export.gpx", tripHandler.ExportGPX)
+		r.Get("/trips/{id}/export.kml", tripHandler.ExportKML)
+		r.Get("/trips/{id}/export.geojson", tripHandler.ExportGeoJSON)
+		r.Get("/trips/{id}/itinerary.md", tripHandler.ExportMarkdown)
+		r.Get("/trips/{id}/itinerary.txt", tripHandler.ExportText)
+		r.Get("/trips/{id}/import", importHandler.ImportPage)
+		r.Post("/trips/{id}/import/preview", importHandler.ImportPreview)
+		r.Post("/trips/{id}/import", importHandler.Import)
+		r.Get("/trips/{id}/import/email", importHandler.EmailImportPage)
+		r.Post("/trips/{id}/import/email/preview", importHandler.EmailImportPreview)
+		r.Post("/trips/{id}/import/email", importHandler.EmailImport)
+		r.Get("/trips/{id}/feeds", feedHandler.List)
+		r.Post("/trips/{id}/feeds", feedHandler.Create)
+		r.Delete("/trips/{id}/feeds/{feedID}", feedHandler.Revoke)
+		r.Get("/trips/{id}/shares", shareHandler.List)
+		r.Post("/trips/{id}/shares", shareHandler.Create)
+		r.Delete("/trips/{id}/shares/{shareID}", shareHandler.Revoke)
+		r.Post("/trips/{id}/shares/{shareID}/rotate", shareHandler.Rotate)
+		r.Put("/trips/{id}/shares/{shareID}/expiry", shareHandler.SetExpiry)
+		r.Get("/trips/{id}/members", memberHandler.List)
+		r.Post("/trips/{id}/members/invitations", memberHandler.Invite)
+		r.Delete("/trips/{id}/members/invitations/{invitationID}", memberHandler.CancelInvitation)
+		r.Put("/trips/{id}/members/{userID}", memberHandler.SetRole)
+		r.Delete("/trips/{id}/members/{userID}", memberHandler.Remove)
 		r.Put("/trips/{id}", tripHandler.Update)
 		r.Delete("/trips/{id}", tripHandler.Delete)
 
+		// Invitations to other users' trips
+		r.Get("/invitations", memberHandler.Invitations)
+		r.Post("/invitations/{invitationID}/accept", memberHandler.Accept)
+		r.Delete("/invitations/{invitationID}", memberHandler.Decline)
+
+		// Airport suggestions for flight forms
+		r.Get("/airports", airportHandler.Options)
+
 		// Event routes
 		r.Get("/trips/{tripID}/events/new", eventHandler.NewPage)
 		r.Post("/trips/{tripID}/events", eventHandler.Create)
+		r.Post("/trips/{tripID}/events/boarding-pass", eventHandler.BoardingPass)
 		r.Get("/trips/{tripID}/events/{id}/edit", eventHandler.EditPage)
 		r.Put("/trips/{tripID}/events/{id}", eventHandler.Update)
 		r.Delete("/trips/{tripID}/events/{id}", eventHandler.Delete)
 		r.Post("/trips/{tripID}/events/{id}/restore", eventHandler.Restore)
+		r.Put("/trips/{tripID}/events/{id}/move", eventHandler.Move)
+		r.Put("/trips/{tripID}/days/{date}/order", eventHandler.Reorder)
 	})
 
 	return r
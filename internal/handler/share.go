@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+// readOnlyKey marks a request rendered for a share link or a trip viewer,
+// whose pages must not offer anything that changes the trip.
+type readOnlyKey struct{}
+
+func withReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyKey{}, true)
+}
+
+// readOnly reports whether templates render for someone who may only view
+// the trip.
+func readOnly(ctx context.Context) bool {
+	ro, _ := ctx.Value(readOnlyKey{}).(bool)
+	return ro
+}
+
+// viaShareLink reports whether templates render for a share link rather than
+// for a signed-in member, who still has the rest of the app around the trip.
+func viaShareLink(ctx context.Context) bool {
+	_, signedIn := service.PrincipalFromContext(ctx)
+	return readOnly(ctx) && !signedIn
+}
+
+type ShareHandler struct {
+	shareService  *service.ShareService
+	eventService  *service.EventService
+	travelService *service.TravelService
+}
+
+func NewShareHandler(shareService *service.ShareService, eventService *service.EventService, travelService *service.TravelService) *ShareHandler {
+	return &ShareHandler{shareService: shareService, eventService: eventService, travelService: travelService}
+}
+
+// View renders a shared trip, read-only, to whoever holds the link. Only GET
+// and HEAD are routed here; nothing under a share link changes the trip.
+func (h *ShareHandler) View(w http.ResponseWriter, r *http.Request) {
+	ctx, trip, err := h.shareService.Open(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Link not found or expired", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to load trip", http.StatusInternalServerError)
+		return
+	}
+	ctx = withReadOnly(ctx)
+
+	days, nights, err := tripTimeline(ctx, h.eventService, h.travelService, trip)
+	if err != nil {
+		http.Error(w, "Failed to load events", http.StatusInternalServerError)
+		return
+	}
+
+	// Shared links should not leak into search engines or referrers
+	w.Header().Set("X-Robots-Tag", "noindex")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	templ.Handler(TripDetailPage(trip, days, nights)).ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RejectMutations answers every request but GET and HEAD with 405, so share
+// links stay read-only whatever is routed under them.
+func RejectMutations(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "Shared trips are read-only", http.StatusMethodNotAllowed)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// List renders the share link section of the trip edit page.
+func (h *ShareHandler) List(w http.ResponseWriter, r *http.Request) {
+	tripID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return
+	}
+	h.renderShares(w, r, tripID, "")
+}
+
+// Create issues a share link, expiring after the optional expires_on date.
+func (h *ShareHandler) Create(w http.ResponseWriter, r *http.Request) {
+	tripID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return
+	}
+	if err = r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	_, err = h.shareService.Create(r.Context(), tripID, parseExpiry(r.FormValue("expires_on")))
+	h.respond(w, r, tripID, err)
+}
+
+func (h *ShareHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	tripID, id, ok := shareIDs(w, r)
+	if !ok {
+		return
+	}
+	h.respond(w, r, tripID, h.shareService.Revoke(r.Context(), tripID, id))
+}
+
+func (h *ShareHandler) Rotate(w http.ResponseWriter, r *http.Request) {
+	tripID, id, ok := shareIDs(w, r)
+	if !ok {
+		return
+	}
+	_, err := h.shareService.Rotate(r.Context(), tripID, id)
+	h.respond(w, r, tripID, err)
+}
+
+// SetExpiry changes a link's expiry; an empty expires_on removes it.
+func (h *ShareHandler) SetExpiry(w http.ResponseWriter, r *http.Request) {
+	tripID, id, ok := shareIDs(w, r)
+	if !ok {
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+	_, err := h.shareService.SetExpiry(r.Context(), tripID, id, parseExpiry(r.FormValue("expires_on")))
+	h.respond(w, r, tripID, err)
+}
+
+// respond re-renders the share section after a change, or reports err.
+func (h *ShareHandler) respond(w http.ResponseWriter, r *http.Request, tripID int, err error) {
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			http.Error(w, "Share link not found", http.StatusNotFound)
+		case errors.Is(err, domain.ErrInvalidInput):
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			h.renderShares(w, r, tripID, formErrorMessage(err))
+		default:
+			http.Error(w, "Failed to update share links", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if r.Header.Get("HX-Request") != "true" {
+		http.Redirect(w, r, "/trips/"+strconv.Itoa(tripID)+"/edit", http.StatusSeeOther)
+		return
+	}
+	h.renderShares(w, r, tripID, "")
+}
+
+func (h *ShareHandler) renderShares(w http.ResponseWriter, r *http.Request, tripID int, formError string) {
+	shares, err := h.shareService.ListByTrip(r.Context(), tripID)
+	if err != nil {
+		http.Error(w, "Failed to load share links", http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(ShareLinksSection(tripID, shares, requestOrigin(r), formError)).ServeHTTP(w, r)
+}
+
+func shareIDs(w http.ResponseWriter, r *http.Request) (tripID, id int, ok bool) {
+	tripID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	id, err = strconv.Atoi(chi.URLParam(r, "shareID"))
+	if err != nil {
+		http.Error(w, "Invalid share ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	return tripID, id, true
+}
+
+// parseExpiry reads a YYYY-MM-DD expiry date. The link works through that
+// whole day, so it expires at the following midnight UTC. Empty means never.
+func parseExpiry(value string) *time.Time {
+	day := parseDate(value)
+	if day.IsZero() {
+		return nil
+	}
+	expiresAt := day.AddDate(0, 0, 1)
+	return &expiresAt
+}
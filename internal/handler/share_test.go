@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+// mockShareRepo serves a single share link for trip 1.
+type mockShareRepo struct {
+	share domain.TripShare
+}
+
+func (m *mockShareRepo) Create(ctx context.Context, share *domain.TripShare) error {
+	return nil
+}
+func (m *mockShareRepo) GetByToken(ctx context.Context, token string) (*domain.TripShare, error) {
+	if token != m.share.Token {
+		return nil, domain.ErrNotFound
+	}
+	return &m.share, nil
+}
+func (m *mockShareRepo) ListByTrip(ctx context.Context, tripID int) ([]domain.TripShare, error) {
+	return []domain.TripShare{m.share}, nil
+}
+func (m *mockShareRepo) Delete(ctx context.Context, tripID, id int) error {
+	return nil
+}
+func (m *mockShareRepo) Rotate(ctx context.Context, tripID, id int, token string) (*domain.TripShare, error) {
+	return &m.share, nil
+}
+func (m *mockShareRepo) SetExpiry(ctx context.Context, tripID, id int, expiresAt *time.Time) (*domain.TripShare, error) {
+	return &m.share, nil
+}
+
+// listingEventRepo lists its event in the trip timeline.
+type listingEventRepo struct {
+	mockEventRepo
+}
+
+func (m *listingEventRepo) ListByTrip(ctx context.Context, tripID int) ([]domain.Event, error) {
+	return []domain.Event{*m.event}, nil
+}
+
+func TestShareHandler_View(t *testing.T) {
+	day := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	events := &listingEventRepo{mockEventRepo{event: &domain.Event{
+		ID:        1,
+		TripID:    1,
+		EventDate: day,
+		StartTime: day.Add(10 * time.Hour),
+		EndTime:   day.Add(12 * time.Hour),
+		Title:     "Colosseum",
+		Category:  domain.CategoryActivity,
+	}}}
+	shares := &mockShareRepo{share: domain.TripShare{ID: 1, TripID: 1, Token: "s3cret"}}
+	h := NewShareHandler(
+		service.NewShareService(shares, &mockTripRepo{}),
+		service.NewEventService(events, &mockTripRepo{}),
+		newTestTravelService(),
+	)
+	router := chi.NewRouter()
+	router.With(RejectMutations).Handle("/s/{token}", http.HandlerFunc(h.View))
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+
+	t.Run("renders the trip without controls", func(t *testing.T) {
+		w := serve("GET", "/s/s3cret")
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200", w.Code)
+		}
+		body := w.Body.String()
+		if !strings.Contains(body, "Colosseum") {
+			t.Error("body does not show the shared event")
+		}
+		for _, control := range []string{"+ Add Event", "sheet-container", "hx-delete", "hx-put", "draggable", "/trips/1/edit"} {
+			if strings.Contains(body, control) {
+				t.Errorf("read-only page contains %q", control)
+			}
+		}
+		if got := w.Header().Get("X-Robots-Tag"); got != "noindex" {
+			t.Errorf("X-Robots-Tag = %q, want noindex", got)
+		}
+	})
+
+	t.Run("rejects mutations", func(t *testing.T) {
+		for _, method := range []string{"POST", "PUT", "PATCH", "DELETE"} {
+			w := serve(method, "/s/s3cret")
+			if w.Code != http.StatusMethodNotAllowed {
+				t.Errorf("%s status = %d, want 405", method, w.Code)
+			}
+		}
+	})
+
+	t.Run("unknown token is not found", func(t *testing.T) {
+		if w := serve("GET", "/s/guess"); w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want 404", w.Code)
+		}
+	})
+}
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"context"
 	"errors"
+	"log/slog"
 	"net/http"
 	"strconv"
 	"time"
@@ -14,20 +16,23 @@ import (
 )
 
 type TripHandler struct {
-	tripService  *service.TripService
-	eventService *service.EventService
+	tripService   *service.TripService
+	eventService  *service.EventService
+	travelService *service.TravelService
+	exportService *service.ExportService
 }
 
-func NewTripHandler(tripService *service.TripService, eventService *service.EventService) *TripHandler {
+func NewTripHandler(tripService *service.TripService, eventService *service.EventService, travelService *service.TravelService, exportService *service.ExportService) *TripHandler {
 	return &TripHandler{
-		tripService:  tripService,
-		eventService: eventService,
+		tripService:   tripService,
+		eventService:  eventService,
+		travelService: travelService,
+		exportService: exportService,
 	}
 }
 
 func (h *TripHandler) List(w http.ResponseWriter, r *http.Request) {
-	userID := getUserID(r)
-	trips, err := h.tripService.List(r.Context(), userID)
+	trips, err := h.tripService.List(r.Context())
 	if err != nil {
 		http.Error(w, "Failed to load trips", http.StatusInternalServerError)
 		return
@@ -51,6 +56,7 @@ func (h *TripHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Destination: r.FormValue("destination"),
 		StartDate:   parseDate(r.FormValue("start_date")),
 		EndDate:     parseDate(r.FormValue("end_date")),
+		TimeZone:    r.FormValue("time_zone"),
 	}
 
 	trip, err := h.tripService.Create(r.Context(), input)
@@ -71,6 +77,84 @@ type TimelineDayData struct {
 	Date      time.Time
 	Events    []domain.Event
 	DayNumber int
+	// SwapOOB renders the day as an HTMX out-of-band swap, used when a response
+	// updates a second day alongside its main target.
+	SwapOOB bool
+	// Connections flags consecutive events with too little time to travel between them.
+	Connections []domain.TightConnection
+	// Stays marks lodging check-ins, nights and check-outs falling on this day.
+	Stays []domain.DayStay
+	// Layovers holds the layovers of the day's connecting flights by event ID.
+	Layovers map[int][]domain.Layover
+	// AirportNames spells out the airports of the day's flights, by code.
+	AirportNames map[string]string
+}
+
+// EventBadges holds the warnings shown on an event card in the timeline.
+type EventBadges struct {
+	Tight        *domain.TightConnection // set when there is too little time to get here from the previous event
+	AirportNames map[string]string       // names of a flight's airports, by code
+	Conflict     domain.ConflictSeverity // worst overlap the event is part of, empty if none
+	TightFrom    string                  // title of the previous event when Tight is set
+	ConflictWith []string                // titles of the overlapping events
+	Layovers     []domain.Layover        // layovers of a connecting flight, short ones flagged
+}
+
+// annotateDay names the airports of a day's flights and runs its travel-time
+// analysis. Estimates are advisory, so a failure is logged and the day is
+// rendered without them.
+func annotateDay(ctx context.Context, events *service.EventService, travel *service.TravelService, day *TimelineDayData) {
+	day.AirportNames = events.AirportNames(day.Events)
+	day.Layovers = travel.Layovers(day.Events)
+	connections, err := travel.TightConnections(ctx, day.Events)
+	if err != nil {
+		slog.WarnContext(ctx, "estimating travel times", "date", day.Date.Format("2006-01-02"), "error", err)
+		return
+	}
+	day.Connections = connections
+}
+
+// Badges analyses the day's events and returns the badges for each event by ID.
+func (d TimelineDayData) Badges() map[int]EventBadges {
+	titles := make(map[int]string, len(d.Events))
+	for i := range d.Events {
+		titles[d.Events[i].ID] = d.Events[i].Title
+	}
+
+	badges := make(map[int]EventBadges)
+	mark := func(id, otherID int, severity domain.ConflictSeverity) {
+		b := badges[id]
+		if b.Conflict != domain.ConflictCritical {
+			b.Conflict = severity
+		}
+		b.ConflictWith = append(b.ConflictWith, titles[otherID])
+		badges[id] = b
+	}
+	for _, c := range service.DetectConflicts(d.Events) {
+		mark(c.EventID, c.OtherID, c.Severity)
+		mark(c.OtherID, c.EventID, c.Severity)
+	}
+	for i := range d.Connections {
+		b := badges[d.Connections[i].ToID]
+		b.Tight = &d.Connections[i]
+		b.TightFrom = titles[d.Connections[i].FromID]
+		badges[d.Connections[i].ToID] = b
+	}
+	for id, layovers := range d.Layovers {
+		b := badges[id]
+		b.Layovers = layovers
+		badges[id] = b
+	}
+	if len(d.AirportNames) > 0 {
+		for i := range d.Events {
+			if d.Events[i].Flight != nil {
+				b := badges[d.Events[i].ID]
+				b.AirportNames = d.AirportNames
+				badges[d.Events[i].ID] = b
+			}
+		}
+	}
+	return badges
 }
 
 func (h *TripHandler) Detail(w http.ResponseWriter, r *http.Request) {
@@ -91,16 +175,32 @@ func (h *TripHandler) Detail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	events, err := h.eventService.ListByTrip(r.Context(), id)
+	ctx := r.Context()
+	if service.RoleOf(ctx, trip) == domain.RoleViewer {
+		ctx = withReadOnly(ctx)
+	}
+
+	days, nights, err := tripTimeline(ctx, h.eventService, h.travelService, trip)
 	if err != nil {
 		http.Error(w, "Failed to load events", http.StatusInternalServerError)
 		return
 	}
 
-	// Build day-by-day timeline from trip date range
-	days := buildTimelineDays(trip, events)
+	templ.Handler(TripDetailPage(trip, days, nights)).ServeHTTP(w, r.WithContext(ctx))
+}
 
-	templ.Handler(TripDetailPage(trip, days)).ServeHTTP(w, r)
+// tripTimeline lays out a trip's events day by day over its date range and
+// finds the nights without lodging.
+func tripTimeline(ctx context.Context, eventService *service.EventService, travelService *service.TravelService, trip *domain.Trip) ([]TimelineDayData, []time.Time, error) {
+	events, err := eventService.ListByTrip(ctx, trip.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	days := buildTimelineDays(trip, events)
+	for i := range days {
+		annotateDay(ctx, eventService, travelService, &days[i])
+	}
+	return days, service.UncoveredNights(trip, events), nil
 }
 
 func (h *TripHandler) EditPage(w http.ResponseWriter, r *http.Request) {
@@ -120,6 +220,11 @@ func (h *TripHandler) EditPage(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to load trip", http.StatusInternalServerError)
 		return
 	}
+	// Viewers could not save the form anyway
+	if service.RoleOf(r.Context(), trip) == domain.RoleViewer {
+		http.Error(w, "Trip not found", http.StatusNotFound)
+		return
+	}
 
 	eventCount, err := h.eventService.CountByTrip(r.Context(), id)
 	if err != nil {
@@ -154,6 +259,11 @@ func (h *TripHandler) Update(w http.ResponseWriter, r *http.Request) {
 		StartDate:   &startDate,
 		EndDate:     &endDate,
 	}
+	// Forms without the field leave the zone unchanged
+	timeZone := r.FormValue("time_zone")
+	if timeZone != "" {
+		input.TimeZone = &timeZone
+	}
 
 	_, err = h.tripService.Update(r.Context(), id, input)
 	if err != nil {
@@ -172,6 +282,9 @@ func (h *TripHandler) Update(w http.ResponseWriter, r *http.Request) {
 			trip.Destination = destination
 			trip.StartDate = startDate
 			trip.EndDate = endDate
+			if timeZone != "" {
+				trip.TimeZone = timeZone
+			}
 			eventCount, countErr := h.eventService.CountByTrip(r.Context(), id)
 			if countErr != nil {
 				http.Error(w, "Failed to count events", http.StatusInternalServerError)
@@ -221,9 +334,13 @@ func (h *TripHandler) Delete(w http.ResponseWriter, r *http.Request) {
 func buildTimelineDays(trip *domain.Trip, events []domain.Event) []TimelineDayData {
 	// Build event lookup by date
 	eventsByDate := make(map[string][]domain.Event)
+	var lodgings []domain.Event
 	for i := range events {
 		key := events[i].EventDate.Format("2006-01-02")
 		eventsByDate[key] = append(eventsByDate[key], events[i])
+		if events[i].Category == domain.CategoryLodging {
+			lodgings = append(lodgings, events[i])
+		}
 	}
 
 	var days []TimelineDayData
@@ -234,12 +351,32 @@ func buildTimelineDays(trip *domain.Trip, events []domain.Event) []TimelineDayDa
 			Date:      d,
 			DayNumber: dayNum,
 			Events:    eventsByDate[key],
+			Stays:     service.StaysOn(lodgings, d),
 		})
 		dayNum++
 	}
 	return days
 }
 
+// NightRun is a stretch of consecutive nights, From and To inclusive.
+type NightRun struct {
+	From time.Time
+	To   time.Time
+}
+
+// groupNights collapses sorted nights into runs of consecutive dates.
+func groupNights(nights []time.Time) []NightRun {
+	var runs []NightRun
+	for _, night := range nights {
+		if n := len(runs); n > 0 && runs[n-1].To.AddDate(0, 0, 1).Equal(night) {
+			runs[n-1].To = night
+			continue
+		}
+		runs = append(runs, NightRun{From: night, To: night})
+	}
+	return runs
+}
+
 // FormErrors holds form validation error messages.
 type FormErrors struct {
 	General string
@@ -0,0 +1,176 @@
+// Package airports provides an offline airport directory built into the binary.
+package airports
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+var _ domain.AirportDirectory = (*Directory)(nil)
+
+// airportsCSV lists the airports travellers fly through most: every major
+// hub and a selection of regional and leisure airports. Columns are IATA,
+// ICAO, name, city, country, latitude, longitude and IANA zone.
+//
+//go:embed airports.csv
+var airportsCSV string
+
+// Directory is an in-memory airport directory. It is read-only, so one
+// Directory can be shared by all requests.
+type Directory struct {
+	byCode   map[string]*domain.Airport
+	airports []domain.Airport
+}
+
+// New loads the embedded airport list.
+func New() (*Directory, error) {
+	return Parse(airportsCSV)
+}
+
+// Parse builds a directory from CSV data in the embedded list's format,
+// header row included.
+func Parse(data string) (*Directory, error) {
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("reading airports: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("reading airports: no header")
+	}
+
+	d := &Directory{airports: make([]domain.Airport, 0, len(records)-1)}
+	for i, record := range records[1:] {
+		airport, err := parseAirport(record)
+		if err != nil {
+			return nil, fmt.Errorf("airports line %d: %w", i+2, err)
+		}
+		d.airports = append(d.airports, airport)
+	}
+
+	d.byCode = make(map[string]*domain.Airport, 2*len(d.airports))
+	for i := range d.airports {
+		a := &d.airports[i]
+		for _, code := range []string{a.IATA, a.ICAO} {
+			if code == "" {
+				continue
+			}
+			if _, dup := d.byCode[code]; dup {
+				return nil, fmt.Errorf("airports: duplicate code %s", code)
+			}
+			d.byCode[code] = a
+		}
+	}
+	return d, nil
+}
+
+func parseAirport(record []string) (domain.Airport, error) {
+	if len(record) != 8 {
+		return domain.Airport{}, fmt.Errorf("want 8 fields, got %d", len(record))
+	}
+	lat, err := strconv.ParseFloat(record[5], 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return domain.Airport{}, fmt.Errorf("invalid latitude %q", record[5])
+	}
+	lon, err := strconv.ParseFloat(record[6], 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return domain.Airport{}, fmt.Errorf("invalid longitude %q", record[6])
+	}
+	if _, err := domain.LoadTimeZone(record[7]); err != nil || record[7] == "" {
+		return domain.Airport{}, fmt.Errorf("invalid time zone %q", record[7])
+	}
+	return domain.Airport{
+		IATA:      strings.ToUpper(record[0]),
+		ICAO:      strings.ToUpper(record[1]),
+		Name:      record[2],
+		City:      record[3],
+		Country:   record[4],
+		Latitude:  lat,
+		Longitude: lon,
+		TimeZone:  record[7],
+	}, nil
+}
+
+// Lookup finds an airport by IATA or ICAO code, ignoring case.
+func (d *Directory) Lookup(code string) (*domain.Airport, bool) {
+	a, ok := d.byCode[strings.ToUpper(strings.TrimSpace(code))]
+	if !ok {
+		return nil, false
+	}
+	found := *a
+	return &found, true
+}
+
+// Match ranks, best first.
+const (
+	matchCode = iota
+	matchCodePrefix
+	matchCityPrefix
+	matchNamePrefix
+	matchWord
+	noMatch
+)
+
+// Search returns up to limit airports matching query. Exact codes come
+// first, then codes, cities and names starting with query, then names and
+// cities containing it as a word. Ties are broken by city and name.
+func (d *Directory) Search(query string, limit int) []domain.Airport {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" || limit <= 0 {
+		return nil
+	}
+
+	type match struct {
+		airport *domain.Airport
+		rank    int
+	}
+	var matches []match
+	for i := range d.airports {
+		if rank := matchRank(&d.airports[i], query); rank != noMatch {
+			matches = append(matches, match{airport: &d.airports[i], rank: rank})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		if a.airport.City != b.airport.City {
+			return a.airport.City < b.airport.City
+		}
+		return a.airport.Name < b.airport.Name
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	results := make([]domain.Airport, len(matches))
+	for i, m := range matches {
+		results[i] = *m.airport
+	}
+	return results
+}
+
+func matchRank(a *domain.Airport, query string) int {
+	iata, icao := strings.ToLower(a.IATA), strings.ToLower(a.ICAO)
+	city, name := strings.ToLower(a.City), strings.ToLower(a.Name)
+	switch {
+	case query == iata || query == icao:
+		return matchCode
+	case strings.HasPrefix(iata, query) || strings.HasPrefix(icao, query):
+		return matchCodePrefix
+	case strings.HasPrefix(city, query):
+		return matchCityPrefix
+	case strings.HasPrefix(name, query):
+		return matchNamePrefix
+	case strings.Contains(" "+name+" "+city, " "+query):
+		return matchWord
+	}
+	return noMatch
+}
@@ -0,0 +1,108 @@
+package airports
+
+import (
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if len(d.airports) < 100 {
+		t.Errorf("got %d airports, want the embedded list", len(d.airports))
+	}
+}
+
+func TestDirectory_Lookup(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		code     string
+		wantIATA string
+		wantOK   bool
+	}{
+		{code: "FCO", wantIATA: "FCO", wantOK: true},
+		{code: "fco", wantIATA: "FCO", wantOK: true},
+		{code: " LIRF ", wantIATA: "FCO", wantOK: true},
+		{code: "XXX"},
+		{code: ""},
+	}
+	for _, tt := range tests {
+		got, ok := d.Lookup(tt.code)
+		if ok != tt.wantOK {
+			t.Errorf("Lookup(%q) ok = %v, want %v", tt.code, ok, tt.wantOK)
+			continue
+		}
+		if ok && got.IATA != tt.wantIATA {
+			t.Errorf("Lookup(%q) = %s, want %s", tt.code, got.IATA, tt.wantIATA)
+		}
+	}
+
+	jfk, _ := d.Lookup("JFK")
+	if jfk.TimeZone != "America/New_York" || jfk.City != "New York" || jfk.Latitude < 40 || jfk.Latitude > 41 {
+		t.Errorf("JFK = %+v", jfk)
+	}
+}
+
+func TestDirectory_Search(t *testing.T) {
+	d, err := Parse(`iata,icao,name,city,country,latitude,longitude,time_zone
+LHR,EGLL,Heathrow Airport,London,GB,51.4706,-0.461941,Europe/London
+LGW,EGKK,Gatwick Airport,London,GB,51.1481,-0.190278,Europe/London
+LCY,EGLC,London City Airport,London,GB,51.5053,0.055278,Europe/London
+YXU,CYXU,London International Airport,London,CA,43.0356,-81.1539,America/Toronto
+CDG,LFPG,Charles de Gaulle Airport,Paris,FR,49.0097,2.54778,Europe/Paris
+`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []string
+	}{
+		{name: "exact code first", query: "lcy", limit: 10, want: []string{"LCY"}},
+		{name: "code prefix before city", query: "L", limit: 10, want: []string{"LGW", "LHR", "LCY", "CDG", "YXU"}},
+		{name: "city", query: "london", limit: 2, want: []string{"LGW", "LHR"}},
+		{name: "word in name", query: "gaulle", limit: 10, want: []string{"CDG"}},
+		{name: "no match", query: "tokyo", limit: 10},
+		{name: "empty", query: " ", limit: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := d.Search(tt.query, tt.limit)
+			var codes []string
+			for i := range got {
+				codes = append(codes, got[i].IATA)
+			}
+			if len(codes) != len(tt.want) {
+				t.Fatalf("Search(%q) = %v, want %v", tt.query, codes, tt.want)
+			}
+			for i := range codes {
+				if codes[i] != tt.want[i] {
+					t.Errorf("Search(%q) = %v, want %v", tt.query, codes, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	header := "iata,icao,name,city,country,latitude,longitude,time_zone\n"
+	tests := map[string]string{
+		"bad latitude":   "AAA,AAAA,A,A,AA,95,0,UTC\n",
+		"bad zone":       "AAA,AAAA,A,A,AA,0,0,Mars/Olympus\n",
+		"duplicate code": "AAA,AAAA,A,A,AA,0,0,UTC\nAAA,BBBB,B,B,BB,0,0,UTC\n",
+	}
+	for name, rows := range tests {
+		if _, err := Parse(header + rows); err == nil {
+			t.Errorf("%s: Parse() should fail", name)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Package bcbp reads IATA Bar Coded Boarding Passes, the text encoded in the
+// barcode of paper and mobile boarding passes.
+package bcbp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+var _ domain.BoardingPassReader = (*Parser)(nil)
+
+// Field widths of an IATA Bar Coded Boarding Pass (Resolution 792, format M).
+const (
+	bcbpHeaderLength = 23                    // format code, leg count, passenger name, e-ticket flag
+	bcbpLegLength    = 37                    // mandatory fields of each leg, up to its conditional size
+	bcbpMinLength    = bcbpHeaderLength + 24 // the first leg up to its flight date
+)
+
+// Parser reads boarding pass barcodes.
+type Parser struct{}
+
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// ReadBoardingPass reads the mandatory fields of a BCBP string, as decoded
+// from the PDF417, Aztec or QR code on a boarding pass. Conditional and
+// security data are skipped.
+func (p *Parser) ReadBoardingPass(data string) (*domain.BoardingPass, error) {
+	data = strings.TrimLeft(strings.TrimRight(data, "\r\n"), " \t")
+	if len(data) < bcbpMinLength || data[0] != 'M' {
+		return nil, errors.New("not an IATA boarding pass barcode")
+	}
+	legCount := int(data[1] - '0')
+	if legCount < 1 || legCount > 4 {
+		return nil, fmt.Errorf("invalid number of legs %q", data[1])
+	}
+	// Scanners and copy-paste often drop the trailing blanks of the last field
+	if len(data) < bcbpHeaderLength+bcbpLegLength {
+		data += strings.Repeat(" ", bcbpHeaderLength+bcbpLegLength-len(data))
+	}
+
+	pass := &domain.BoardingPass{PassengerName: strings.TrimSpace(data[2:22])}
+	rest := data[bcbpHeaderLength:]
+	for i := 0; i < legCount; i++ {
+		if len(rest) < bcbpLegLength {
+			return nil, fmt.Errorf("leg %d is truncated", i+1)
+		}
+		leg, err := parseLeg(rest[:bcbpLegLength])
+		if err != nil {
+			return nil, fmt.Errorf("leg %d: %w", i+1, err)
+		}
+		pass.Legs = append(pass.Legs, *leg)
+
+		size, err := hexFieldSize(rest[35:37])
+		if err != nil {
+			return nil, fmt.Errorf("leg %d: %w", i+1, err)
+		}
+		rest = rest[bcbpLegLength:]
+		if size > len(rest) {
+			size = len(rest)
+		}
+		rest = rest[size:]
+	}
+	return pass, nil
+}
+
+func parseLeg(field string) (*domain.BoardingPassLeg, error) {
+	leg := &domain.BoardingPassLeg{
+		BookingReference: strings.TrimSpace(field[0:7]),
+		From:             strings.TrimSpace(field[7:10]),
+		To:               strings.TrimSpace(field[10:13]),
+		Carrier:          strings.TrimSpace(field[13:16]),
+		FlightNumber:     strings.TrimLeft(strings.TrimSpace(field[16:21]), "0"),
+		Seat:             strings.TrimLeft(strings.TrimSpace(field[25:29]), "0"),
+	}
+	if len(leg.From) != 3 || len(leg.To) != 3 {
+		return nil, fmt.Errorf("invalid airports %q", field[7:13])
+	}
+	day, err := strconv.Atoi(strings.TrimSpace(field[21:24]))
+	if err != nil || day < 1 || day > 366 {
+		return nil, fmt.Errorf("invalid flight date %q", field[21:24])
+	}
+	leg.DayOfYear = day
+	return leg, nil
+}
+
+// hexFieldSize reads a two-digit hexadecimal field size; blanks mean zero.
+func hexFieldSize(field string) (int, error) {
+	if strings.TrimSpace(field) == "" {
+		return 0, nil
+	}
+	size, err := strconv.ParseUint(field, 16, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid field size %q", field)
+	}
+	return int(size), nil
+}
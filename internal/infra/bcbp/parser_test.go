@@ -0,0 +1,91 @@
+package bcbp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+// The single-leg example of IATA Resolution 792
+const sampleBoardingPass = "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 226F001A0025 100"
+
+func TestParser_ReadBoardingPass(t *testing.T) {
+	// Two legs, each with a conditional section that must be skipped
+	twoLegs := "M2DESMARAIS/LUC       EABC123 YULFRAAC 0834 226F001A0025 10B>5180MM0001" +
+		"DEF456 FRAGVALH 3664 227C012C0002 102ABCDEFGHIJ" +
+		"^160MEUCIQCh"
+
+	tests := []struct {
+		name    string
+		data    string
+		want    []domain.BoardingPassLeg
+		wantErr bool
+	}{
+		{
+			name: "single leg",
+			data: sampleBoardingPass,
+			want: []domain.BoardingPassLeg{{BookingReference: "ABC123", From: "YUL", To: "FRA", Carrier: "AC", FlightNumber: "834", Seat: "1A", DayOfYear: 226}},
+		},
+		{
+			name: "trailing blanks lost",
+			data: strings.TrimRight("M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 226F001A0025    ", " ") + "\n",
+			want: []domain.BoardingPassLeg{{BookingReference: "ABC123", From: "YUL", To: "FRA", Carrier: "AC", FlightNumber: "834", Seat: "1A", DayOfYear: 226}},
+		},
+		{
+			name: "two legs",
+			data: twoLegs,
+			want: []domain.BoardingPassLeg{
+				{BookingReference: "ABC123", From: "YUL", To: "FRA", Carrier: "AC", FlightNumber: "834", Seat: "1A", DayOfYear: 226},
+				{BookingReference: "DEF456", From: "FRA", To: "GVA", Carrier: "LH", FlightNumber: "3664", Seat: "12C", DayOfYear: 227},
+			},
+		},
+		{name: "not a boarding pass", data: "https://example.com/ticket", wantErr: true},
+		{name: "bad leg count", data: strings.Replace(sampleBoardingPass, "M1", "M7", 1), wantErr: true},
+		{name: "bad date", data: strings.Replace(sampleBoardingPass, "226", "4X6", 1), wantErr: true},
+		{name: "missing second leg", data: strings.Replace(sampleBoardingPass, "M1", "M2", 1), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pass, err := NewParser().ReadBoardingPass(tt.data)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ReadBoardingPass() = %+v, want an error", pass)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadBoardingPass() error = %v", err)
+			}
+			if pass.PassengerName != "DESMARAIS/LUC" {
+				t.Errorf("PassengerName = %q", pass.PassengerName)
+			}
+			if len(pass.Legs) != len(tt.want) {
+				t.Fatalf("got %d legs, want %d", len(pass.Legs), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if pass.Legs[i] != want {
+					t.Errorf("leg %d = %+v, want %+v", i+1, pass.Legs[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestBoardingPassLeg_Date(t *testing.T) {
+	leg := domain.BoardingPassLeg{DayOfYear: 3}
+	tests := []struct {
+		ref  time.Time
+		want time.Time
+	}{
+		{ref: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), want: time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)},
+		// Around new year, a pass for January 3 means the coming one
+		{ref: time.Date(2026, 12, 28, 0, 0, 0, 0, time.UTC), want: time.Date(2027, 1, 3, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := leg.Date(tt.ref); !got.Equal(tt.want) {
+			t.Errorf("Date(%s) = %s, want %s", tt.ref.Format("2006-01-02"), got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package bookingmail
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+)
+
+// emailHTMLBodies returns the decoded text/html parts of a raw RFC 822 message.
+func emailHTMLBodies(data []byte) ([]string, error) {
+	msg, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		return nil, errors.New("not an email message")
+	}
+	var bodies []string
+	err = collectHTMLParts(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body, &bodies)
+	return bodies, err
+}
+
+func collectHTMLParts(contentType, encoding string, body io.Reader, bodies *[]string) error {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		// RFC 2045: a missing or broken Content-Type means plain text
+		mediaType = "text/plain"
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("reading message part: %w", err)
+			}
+			// NextPart already decodes quoted-printable and drops the header
+			err = collectHTMLParts(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part, bodies)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if mediaType != "text/html" {
+		return nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, &lineStripper{r: body})
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("decoding HTML part: %w", err)
+	}
+	*bodies = append(*bodies, string(content))
+	return nil
+}
+
+// lineStripper drops the line breaks that wrap base64 bodies.
+type lineStripper struct {
+	r io.Reader
+}
+
+func (l *lineStripper) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	kept := 0
+	for _, b := range p[:n] {
+		if b != '\r' && b != '\n' {
+			p[kept] = b
+			kept++
+		}
+	}
+	return kept, err
+}
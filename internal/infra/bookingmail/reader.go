@@ -0,0 +1,177 @@
+// Package bookingmail reads flight and hotel bookings from confirmation
+// emails, using the schema.org markup airlines and booking sites embed in
+// them as JSON-LD or microdata.
+package bookingmail
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+var _ domain.ReservationReader = (*Reader)(nil)
+
+// Reader reads the reservations of booking emails.
+type Reader struct{}
+
+func NewReader() *Reader {
+	return &Reader{}
+}
+
+// ReadReservations returns the FlightReservation and LodgingReservation items
+// of the message's HTML parts that name a departure or check-in.
+func (rd *Reader) ReadReservations(data []byte, loc *time.Location) ([]domain.Reservation, error) {
+	bodies, err := emailHTMLBodies(data)
+	if err != nil {
+		return nil, err
+	}
+	var reservations []domain.Reservation
+	for _, body := range bodies {
+		for _, item := range schemaItems(body) {
+			var r *domain.Reservation
+			switch item.typ() {
+			case "FlightReservation":
+				r = flightReservation(item, loc)
+			case "LodgingReservation":
+				r = lodgingReservation(item, loc)
+			}
+			if r == nil {
+				continue
+			}
+			r.BookingReference = item.text("reservationNumber")
+			r.Cancelled = strings.HasSuffix(item.text("reservationStatus"), "ReservationCancelled")
+			reservations = append(reservations, *r)
+		}
+	}
+	return reservations, nil
+}
+
+// flightReservation reads a FlightReservation, or returns nil when it names
+// no departure time.
+func flightReservation(item schemaItem, loc *time.Location) *domain.Reservation {
+	flight := item.item("reservationFor")
+	if flight == nil {
+		return nil
+	}
+	start, ok := parseSchemaTime(flight.text("departureTime"), loc)
+	if !ok {
+		return nil
+	}
+	end, _ := parseSchemaTime(flight.text("arrivalTime"), loc)
+	return &domain.Reservation{
+		Start: start,
+		End:   end,
+		Flight: &domain.FlightDetails{
+			Airline:           flight.text("airline"),
+			FlightNumber:      schemaFlightNumber(flight),
+			DepartureAirport:  schemaAirport(flight.item("departureAirport")),
+			ArrivalAirport:    schemaAirport(flight.item("arrivalAirport")),
+			DepartureTerminal: flight.text("departureTerminal"),
+			ArrivalTerminal:   flight.text("arrivalTerminal"),
+			DepartureGate:     flight.text("departureGate"),
+			ArrivalGate:       flight.text("arrivalGate"),
+		},
+	}
+}
+
+// schemaFlightNumber joins the airline's IATA code to the flight number, which
+// schema.org gives with or without it.
+func schemaFlightNumber(flight schemaItem) string {
+	number := strings.ReplaceAll(flight.text("flightNumber"), " ", "")
+	code := ""
+	if airline := flight.item("airline"); airline != nil {
+		code = airline.text("iataCode")
+	}
+	if number == "" || code == "" || strings.HasPrefix(number, code) {
+		return number
+	}
+	return code + number
+}
+
+// schemaAirport prefers an airport's IATA code to its name.
+func schemaAirport(airport schemaItem) string {
+	if airport == nil {
+		return ""
+	}
+	if code := airport.text("iataCode"); code != "" {
+		return strings.ToUpper(code)
+	}
+	return airport.text("name")
+}
+
+// lodgingReservation reads a LodgingReservation, or returns nil when it names
+// no check-in.
+func lodgingReservation(item schemaItem, loc *time.Location) *domain.Reservation {
+	checkIn, dateOnly, ok := schemaStayTime(item, "checkinTime", "checkinDate", loc)
+	if !ok {
+		return nil
+	}
+	r := &domain.Reservation{Start: checkIn, StartDateOnly: dateOnly}
+	if checkOut, dateOnly, ok := schemaStayTime(item, "checkoutTime", "checkoutDate", loc); ok {
+		r.End, r.EndDateOnly = checkOut, dateOnly
+	}
+	if place := item.item("reservationFor"); place != nil {
+		r.Name = place.text("name")
+		r.Address = schemaAddress(place)
+		if geo := place.item("geo"); geo != nil {
+			lat, latErr := strconv.ParseFloat(geo.text("latitude"), 64)
+			lon, lonErr := strconv.ParseFloat(geo.text("longitude"), 64)
+			if latErr == nil && lonErr == nil {
+				r.Latitude, r.Longitude = &lat, &lon
+			}
+		}
+	}
+	return r
+}
+
+// schemaStayTime reads a check-in or check-out from whichever of the two
+// properties is set, and whether it was given as a date only.
+func schemaStayTime(item schemaItem, timeProp, dateProp string, loc *time.Location) (t time.Time, dateOnly, ok bool) {
+	for _, prop := range []string{timeProp, dateProp} {
+		value := item.text(prop)
+		if t, ok = parseSchemaTime(value, loc); ok {
+			return t, len(value) == len(schemaDateLayout), true
+		}
+	}
+	return time.Time{}, false, false
+}
+
+// schemaAddress formats a PostalAddress, or returns an address given as text.
+func schemaAddress(place schemaItem) string {
+	address := place.item("address")
+	if address == nil {
+		return place.text("address")
+	}
+	var parts []string
+	for _, prop := range []string{"streetAddress", "addressLocality", "postalCode", "addressRegion", "addressCountry"} {
+		if part := address.text(prop); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+const schemaDateLayout = "2006-01-02"
+
+// schemaTimeLayouts are the ISO 8601 forms booking emails use. Times without
+// an offset are read in the zone the reader is given.
+var schemaTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	schemaDateLayout,
+}
+
+func parseSchemaTime(value string, loc *time.Location) (time.Time, bool) {
+	for _, layout := range schemaTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
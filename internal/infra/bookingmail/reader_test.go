@@ -0,0 +1,139 @@
+package bookingmail
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The flight is JSON-LD in a quoted-printable part, repeated as a cancelled
+// booking; the hotel is microdata in a base64 part.
+const flightHTML = `<html><head><script type=3D"application/ld+json">
+[{"@context": "http://schema.org", "@type": "FlightReservation",
+  "reservationNumber": "QX7P2L",
+  "reservationFor": {"@type": "Flight", "flightNumber": "610",
+    "airline": {"@type": "Airline", "name": "ITA Airways", "iataCode": "AZ"},
+    "departureAirport": {"@type": "Airport", "name": "Fiumicino", "iataCode": "FCO"},
+    "arrivalAirport": {"@type": "Airport", "name": "John F. Kennedy", "iataCode": "JFK"},
+    "departureTime": "2026-05-03T10:15:00+02:00", "arrivalTime": "2026-05-03T13:50:00-04:00",
+    "departureTerminal": "1"}},
+ {"@context": "http://schema.org", "@type": "FlightReservation",
+  "reservationNumber": "OLD123", "reservationStatus": "http://schema.org/ReservationCancelled",
+  "reservationFor": {"@type": "Flight", "flightNumber": "AZ608", "departureTime": "2026-05-02T10:15:00+02:00"}}]
+</script></head><body><p>Your trip to New York is confirmed =E2=9C=88</p></body></html>`
+
+const hotelHTML = `<html><body><div itemscope itemtype="http://schema.org/LodgingReservation">
+<meta itemprop="reservationNumber" content="HTL-778">
+<div itemprop="reservationFor" itemscope itemtype="http://schema.org/LodgingBusiness">
+  <span itemprop="name">Hotel Artemide</span>
+  <div itemprop="address" itemscope itemtype="http://schema.org/PostalAddress">
+    <span itemprop="streetAddress">Via Nazionale 22</span>, <span itemprop="addressLocality">Roma</span>
+  </div>
+  <div itemprop="geo" itemscope itemtype="http://schema.org/GeoCoordinates">
+    <meta itemprop="latitude" content="41.9009"><meta itemprop="longitude" content="12.4936">
+  </div>
+</div>
+<p>Check-in <time itemprop="checkinDate" datetime="2026-05-01">May 1</time>,
+check-out <time itemprop="checkoutDate" datetime="2026-05-03">May 3</time></p>
+</div></body></html>`
+
+func sampleBookingEmail() string {
+	encoded := base64.StdEncoding.EncodeToString([]byte(hotelHTML))
+	var wrapped strings.Builder
+	for len(encoded) > 76 {
+		wrapped.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	wrapped.WriteString(encoded)
+
+	return "From: bookings@example.com\r\n" +
+		"Subject: Your booking\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/mixed; boundary=\"outer\"\r\n" +
+		"\r\n" +
+		"--outer\r\n" +
+		"Content-Type: multipart/alternative; boundary=\"inner\"\r\n" +
+		"\r\n" +
+		"--inner\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"\r\n" +
+		"Your trip is confirmed.\r\n" +
+		"--inner\r\n" +
+		"Content-Type: text/html; charset=utf-8\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n" +
+		"\r\n" +
+		strings.ReplaceAll(flightHTML, "\n", "\r\n") + "\r\n" +
+		"--inner--\r\n" +
+		"--outer\r\n" +
+		"Content-Type: text/html; charset=utf-8\r\n" +
+		"Content-Transfer-Encoding: base64\r\n" +
+		"\r\n" +
+		wrapped.String() + "\r\n" +
+		"--outer--\r\n"
+}
+
+func TestReader_ReadReservations(t *testing.T) {
+	rome, _ := time.LoadLocation("Europe/Rome")
+	reservations, err := NewReader().ReadReservations([]byte(sampleBookingEmail()), rome)
+	if err != nil {
+		t.Fatalf("ReadReservations() error = %v", err)
+	}
+	if len(reservations) != 3 {
+		t.Fatalf("got %d reservations, want the flight, the cancelled flight and the hotel", len(reservations))
+	}
+
+	flight := reservations[0]
+	fd := flight.Flight
+	if fd == nil || fd.Airline != "ITA Airways" || fd.FlightNumber != "AZ610" || fd.DepartureAirport != "FCO" ||
+		fd.ArrivalAirport != "JFK" || fd.DepartureTerminal != "1" || flight.BookingReference != "QX7P2L" {
+		t.Errorf("flight = %+v, details %+v", flight, fd)
+	}
+	if !flight.Start.Equal(time.Date(2026, 5, 3, 8, 15, 0, 0, time.UTC)) || !flight.End.Equal(time.Date(2026, 5, 3, 17, 50, 0, 0, time.UTC)) {
+		t.Errorf("flight times = %v – %v", flight.Start, flight.End)
+	}
+	if flight.Cancelled || !reservations[1].Cancelled {
+		t.Errorf("cancelled = %v, %v; want only the second flight", flight.Cancelled, reservations[1].Cancelled)
+	}
+	if !reservations[1].End.IsZero() {
+		t.Errorf("flight without arrival has end %v", reservations[1].End)
+	}
+
+	hotel := reservations[2]
+	if hotel.Flight != nil || hotel.Name != "Hotel Artemide" || hotel.Address != "Via Nazionale 22, Roma" || hotel.BookingReference != "HTL-778" {
+		t.Errorf("hotel = %+v", hotel)
+	}
+	if hotel.Latitude == nil || *hotel.Latitude != 41.9009 || hotel.Longitude == nil || *hotel.Longitude != 12.4936 {
+		t.Errorf("hotel geo = %v, %v", hotel.Latitude, hotel.Longitude)
+	}
+	if !hotel.Start.Equal(time.Date(2026, 5, 1, 0, 0, 0, 0, rome)) || !hotel.StartDateOnly ||
+		!hotel.End.Equal(time.Date(2026, 5, 3, 0, 0, 0, 0, rome)) || !hotel.EndDateOnly {
+		t.Errorf("hotel stay = %v (%v) – %v (%v)", hotel.Start, hotel.StartDateOnly, hotel.End, hotel.EndDateOnly)
+	}
+}
+
+func TestReader_ReadReservations_Microdata(t *testing.T) {
+	email := "Content-Type: text/html\r\n\r\n" + `<div itemscope itemtype="https://schema.org/FlightReservation">
+		<div itemprop="reservationFor" itemscope itemtype="https://schema.org/Flight">
+			<meta itemprop="flightNumber" content="AZ 610">
+			<div itemprop="departureAirport" itemscope itemtype="https://schema.org/Airport"><span itemprop="name">Treviso</span></div>
+			<meta itemprop="departureTime" content="2026-05-03T10:15">
+		</div></div>`
+	reservations, err := NewReader().ReadReservations([]byte(email), time.UTC)
+	if err != nil {
+		t.Fatalf("ReadReservations() error = %v", err)
+	}
+	if len(reservations) != 1 {
+		t.Fatalf("got %d reservations, want 1", len(reservations))
+	}
+	r := reservations[0]
+	if r.Flight.FlightNumber != "AZ610" || r.Flight.DepartureAirport != "Treviso" || !r.Start.Equal(time.Date(2026, 5, 3, 10, 15, 0, 0, time.UTC)) {
+		t.Errorf("reservation = %+v, flight %+v", r, r.Flight)
+	}
+}
+
+func TestReader_ReadReservations_NotAnEmail(t *testing.T) {
+	if _, err := NewReader().ReadReservations([]byte("just some text"), time.UTC); err == nil {
+		t.Error("ReadReservations() error = nil")
+	}
+}
@@ -0,0 +1,249 @@
+package bookingmail
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// schemaItem is a schema.org item as read from JSON-LD or microdata. Values
+// are strings, nested items or lists of either; "@type" holds the type name
+// without its schema.org prefix.
+type schemaItem map[string]any
+
+// schemaItems returns the top-level schema.org items of an HTML document,
+// from both its JSON-LD scripts and its microdata.
+func schemaItems(body string) []schemaItem {
+	doc, err := html.Parse(strings.NewReader(body))
+	if err != nil {
+		return nil
+	}
+	var items []schemaItem
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch {
+			case n.DataAtom == atom.Script && strings.EqualFold(attr(n, "type"), "application/ld+json"):
+				items = append(items, jsonLDItems(textContent(n))...)
+				return
+			case hasAttr(n, "itemscope") && !hasAttr(n, "itemprop"):
+				items = append(items, microdataItem(n))
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	return items
+}
+
+// jsonLDItems reads a JSON-LD script, which may hold one item, a list of
+// items or an @graph of them.
+func jsonLDItems(script string) []schemaItem {
+	var value any
+	if err := json.Unmarshal([]byte(script), &value); err != nil {
+		return nil
+	}
+	var items []schemaItem
+	var collect func(v any)
+	collect = func(v any) {
+		switch v := v.(type) {
+		case []any:
+			for _, e := range v {
+				collect(e)
+			}
+		case map[string]any:
+			if graph, ok := v["@graph"]; ok {
+				collect(graph)
+				return
+			}
+			items = append(items, normalizeJSONLD(v))
+		}
+	}
+	collect(value)
+	return items
+}
+
+// normalizeJSONLD turns decoded JSON into schemaItems, so JSON-LD and
+// microdata read the same way.
+func normalizeJSONLD(m map[string]any) schemaItem {
+	item := make(schemaItem, len(m))
+	for k, v := range m {
+		switch v := v.(type) {
+		case map[string]any:
+			item[k] = normalizeJSONLD(v)
+		case []any:
+			list := make([]any, len(v))
+			for i, e := range v {
+				if nested, ok := e.(map[string]any); ok {
+					list[i] = normalizeJSONLD(nested)
+				} else {
+					list[i] = e
+				}
+			}
+			item[k] = list
+		case float64:
+			item[k] = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			item[k] = v
+		}
+	}
+	if t, ok := item["@type"].(string); ok {
+		item["@type"] = schemaTypeName(t)
+	}
+	return item
+}
+
+// microdataItem reads the item scoped at n. Properties of nested items belong
+// to those items only.
+func microdataItem(n *html.Node) schemaItem {
+	item := schemaItem{}
+	if itemType := strings.Fields(attr(n, "itemtype")); len(itemType) > 0 {
+		item["@type"] = schemaTypeName(itemType[0])
+	}
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			props := strings.Fields(attr(c, "itemprop"))
+			var value any
+			if hasAttr(c, "itemscope") {
+				value = microdataItem(c)
+			} else if len(props) > 0 {
+				value = microdataValue(c)
+			}
+			for _, prop := range props {
+				item.add(prop, value)
+			}
+			if !hasAttr(c, "itemscope") {
+				walk(c)
+			}
+		}
+	}
+	walk(n)
+	return item
+}
+
+func (item schemaItem) add(prop string, value any) {
+	switch existing := item[prop].(type) {
+	case nil:
+		item[prop] = value
+	case []any:
+		item[prop] = append(existing, value)
+	default:
+		item[prop] = []any{existing, value}
+	}
+}
+
+// microdataValue is a property's value as the microdata spec defines it.
+func microdataValue(n *html.Node) string {
+	if hasAttr(n, "content") {
+		return attr(n, "content")
+	}
+	switch n.DataAtom {
+	case atom.A, atom.Link, atom.Area:
+		return attr(n, "href")
+	case atom.Img, atom.Audio, atom.Video, atom.Source, atom.Iframe, atom.Embed:
+		return attr(n, "src")
+	case atom.Time:
+		if hasAttr(n, "datetime") {
+			return attr(n, "datetime")
+		}
+	case atom.Data, atom.Meter:
+		return attr(n, "value")
+	}
+	return strings.Join(strings.Fields(textContent(n)), " ")
+}
+
+// schemaTypeName strips the vocabulary from a type such as
+// "http://schema.org/FlightReservation".
+func schemaTypeName(t string) string {
+	if i := strings.LastIndexAny(t, "/#:"); i >= 0 {
+		return t[i+1:]
+	}
+	return t
+}
+
+// typ is the item's type name.
+func (item schemaItem) typ() string {
+	switch t := item["@type"].(type) {
+	case string:
+		return t
+	case []any:
+		if len(t) > 0 {
+			if s, ok := t[0].(string); ok {
+				return schemaTypeName(s)
+			}
+		}
+	}
+	return ""
+}
+
+// text returns a property as text: a string as is, or the name of an item.
+func (item schemaItem) text(prop string) string {
+	switch v := first(item[prop]).(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case schemaItem:
+		return v.text("name")
+	}
+	return ""
+}
+
+// item returns a property holding a nested item, or nil.
+func (item schemaItem) item(prop string) schemaItem {
+	if v, ok := first(item[prop]).(schemaItem); ok {
+		return v
+	}
+	return nil
+}
+
+func first(v any) any {
+	if list, ok := v.([]any); ok {
+		if len(list) == 0 {
+			return nil
+		}
+		return list[0]
+	}
+	return v
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasAttr(n *html.Node, key string) bool {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return b.String()
+}
@@ -0,0 +1,279 @@
+// Package geoexport writes a trip's places as map files: GPX, KML and
+// GeoJSON. Exports hold only the events that have coordinates. Each day with
+// two or more of them also gets a route joining its places in timeline order.
+package geoexport
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+var _ domain.MapExporter = (*Exporter)(nil)
+
+// Exporter writes map files for apps such as Google Earth, OsmAnd or QGIS.
+type Exporter struct{}
+
+func NewExporter() *Exporter {
+	return &Exporter{}
+}
+
+// geolocated returns the events that have coordinates, keeping their order.
+func geolocated(events []domain.Event) []*domain.Event {
+	var placed []*domain.Event
+	for i := range events {
+		if events[i].Latitude != nil && events[i].Longitude != nil {
+			placed = append(placed, &events[i])
+		}
+	}
+	return placed
+}
+
+func dayName(day *domain.ItineraryDay) string {
+	return fmt.Sprintf("Day %d · %s", day.Number, day.Date.Format("Mon, Jan 2"))
+}
+
+func coord(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func utcTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// ExportGPX writes GPX 1.1 with a waypoint per place, carrying the event's
+// start time, its category as type, its location as comment and its notes as
+// description, then one route per day.
+func (ex *Exporter) ExportGPX(w io.Writer, trip *domain.Trip, days []domain.ItineraryDay) error {
+	x := newXMLWriter(w)
+	x.start("gpx", "version", "1.1", "creator", "traccia", "xmlns", "http://www.topografix.com/GPX/1/1")
+	x.start("metadata")
+	x.leaf("name", trip.Name)
+	x.end()
+
+	// The schema wants every waypoint before the first route
+	for i := range days {
+		for _, e := range geolocated(days[i].Events) {
+			gpxPoint(x, "wpt", e)
+		}
+	}
+	for i := range days {
+		placed := geolocated(days[i].Events)
+		if len(placed) < 2 {
+			continue
+		}
+		x.start("rte")
+		x.leaf("name", dayName(&days[i]))
+		x.leaf("number", strconv.Itoa(days[i].Number))
+		for _, e := range placed {
+			gpxPoint(x, "rtept", e)
+		}
+		x.end()
+	}
+	x.end()
+	return x.flush()
+}
+
+// gpxPoint writes a wpt or rtept; child elements follow the schema's order.
+func gpxPoint(x *xmlWriter, element string, e *domain.Event) {
+	x.start(element, "lat", coord(*e.Latitude), "lon", coord(*e.Longitude))
+	x.leaf("time", utcTime(e.StartTime))
+	x.leaf("name", e.Title)
+	x.optional("cmt", e.Location)
+	x.optional("desc", e.Notes)
+	x.leaf("type", string(e.Category))
+	x.end()
+}
+
+// ExportKML writes a KML folder per day holding its placemarks and, when there
+// are two or more, a route line through them.
+func (ex *Exporter) ExportKML(w io.Writer, trip *domain.Trip, days []domain.ItineraryDay) error {
+	x := newXMLWriter(w)
+	x.start("kml", "xmlns", "http://www.opengis.net/kml/2.2")
+	x.start("Document")
+	x.leaf("name", trip.Name)
+	for i := range days {
+		placed := geolocated(days[i].Events)
+		if len(placed) == 0 {
+			continue
+		}
+		x.start("Folder")
+		x.leaf("name", dayName(&days[i]))
+		coordinates := make([]string, len(placed))
+		for j, e := range placed {
+			coordinates[j] = coord(*e.Longitude) + "," + coord(*e.Latitude)
+			x.start("Placemark")
+			x.leaf("name", e.Title)
+			x.optional("description", e.Notes)
+			x.start("TimeSpan")
+			x.leaf("begin", utcTime(e.StartTime))
+			x.leaf("end", utcTime(e.EndTime))
+			x.end()
+			x.start("ExtendedData")
+			kmlData(x, "category", string(e.Category))
+			kmlData(x, "location", e.Location)
+			kmlData(x, "time_zone", e.TimeZone)
+			x.end()
+			x.start("Point")
+			x.leaf("coordinates", coordinates[j])
+			x.end()
+			x.end()
+		}
+		if len(placed) >= 2 {
+			x.start("Placemark")
+			x.leaf("name", dayName(&days[i])+" route")
+			x.start("LineString")
+			x.leaf("tessellate", "1")
+			x.leaf("coordinates", strings.Join(coordinates, " "))
+			x.end()
+			x.end()
+		}
+		x.end()
+	}
+	x.end()
+	x.end()
+	return x.flush()
+}
+
+func kmlData(x *xmlWriter, name, value string) {
+	if value == "" {
+		return
+	}
+	x.start("Data", "name", name)
+	x.leaf("value", value)
+	x.end()
+}
+
+type geoJSONCollection struct {
+	Type     string           `json:"type"`
+	Name     string           `json:"name"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Geometry   geoJSONGeometry `json:"geometry"`
+	Properties map[string]any  `json:"properties"`
+	Type       string          `json:"type"`
+}
+
+type geoJSONGeometry struct {
+	Coordinates any    `json:"coordinates"`
+	Type        string `json:"type"`
+}
+
+// ExportGeoJSON writes a FeatureCollection with a Point feature per place and a
+// LineString feature per day route. Coordinates are longitude first, as
+// RFC 7946 requires.
+func (ex *Exporter) ExportGeoJSON(w io.Writer, trip *domain.Trip, days []domain.ItineraryDay) error {
+	collection := geoJSONCollection{Type: "FeatureCollection", Name: trip.Name, Features: []geoJSONFeature{}}
+	for i := range days {
+		placed := geolocated(days[i].Events)
+		line := make([][2]float64, len(placed))
+		for j, e := range placed {
+			line[j] = [2]float64{*e.Longitude, *e.Latitude}
+			properties := map[string]any{
+				"name":       e.Title,
+				"category":   string(e.Category),
+				"start_time": utcTime(e.StartTime),
+				"end_time":   utcTime(e.EndTime),
+				"time_zone":  e.TimeZone,
+				"day":        days[i].Number,
+				"date":       days[i].Date.Format("2006-01-02"),
+				"order":      j + 1,
+			}
+			if e.Location != "" {
+				properties["location"] = e.Location
+			}
+			if e.Notes != "" {
+				properties["notes"] = e.Notes
+			}
+			collection.Features = append(collection.Features, geoJSONFeature{
+				Type:       "Feature",
+				Geometry:   geoJSONGeometry{Type: "Point", Coordinates: line[j]},
+				Properties: properties,
+			})
+		}
+		if len(placed) >= 2 {
+			collection.Features = append(collection.Features, geoJSONFeature{
+				Type:     "Feature",
+				Geometry: geoJSONGeometry{Type: "LineString", Coordinates: line},
+				Properties: map[string]any{
+					"name": dayName(&days[i]) + " route",
+					"day":  days[i].Number,
+					"date": days[i].Date.Format("2006-01-02"),
+				},
+			})
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(collection)
+}
+
+// xmlWriter emits elements in exactly the order written, which GPX and KML
+// schemas care about, and keeps the first error.
+type xmlWriter struct {
+	err   error
+	enc   *xml.Encoder
+	w     io.Writer
+	stack []string
+}
+
+func newXMLWriter(w io.Writer) *xmlWriter {
+	x := &xmlWriter{enc: xml.NewEncoder(w), w: w}
+	x.enc.Indent("", "  ")
+	_, x.err = io.WriteString(w, xml.Header)
+	return x
+}
+
+// start opens an element with attributes given as name, value pairs.
+func (x *xmlWriter) start(name string, attrs ...string) {
+	el := xml.StartElement{Name: xml.Name{Local: name}}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		el.Attr = append(el.Attr, xml.Attr{Name: xml.Name{Local: attrs[i]}, Value: attrs[i+1]})
+	}
+	x.stack = append(x.stack, name)
+	x.token(el)
+}
+
+func (x *xmlWriter) end() {
+	name := x.stack[len(x.stack)-1]
+	x.stack = x.stack[:len(x.stack)-1]
+	x.token(xml.EndElement{Name: xml.Name{Local: name}})
+}
+
+func (x *xmlWriter) leaf(name, value string) {
+	x.start(name)
+	x.token(xml.CharData(value))
+	x.end()
+}
+
+// optional writes a leaf only when it has a value.
+func (x *xmlWriter) optional(name, value string) {
+	if value != "" {
+		x.leaf(name, value)
+	}
+}
+
+func (x *xmlWriter) token(t xml.Token) {
+	if x.err == nil {
+		x.err = x.enc.EncodeToken(t)
+	}
+}
+
+func (x *xmlWriter) flush() error {
+	if x.err != nil {
+		return x.err
+	}
+	if err := x.enc.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(x.w, "\n")
+	return err
+}
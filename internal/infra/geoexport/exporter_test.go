@@ -0,0 +1,181 @@
+package geoexport
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+func geoTestDays() (*domain.Trip, []domain.ItineraryDay) {
+	colosseumLat, colosseumLon := 41.8902, 12.4922
+	pantheonLat, pantheonLon := 41.8986, 12.4769
+	hotelLat, hotelLon := 41.9009, 12.4936
+	trip := &domain.Trip{ID: 1, Name: "Rome & around", TimeZone: "Europe/Rome"}
+	day1 := []domain.Event{
+		{
+			Title: "Colosseum", Category: domain.CategoryActivity, Latitude: &colosseumLat, Longitude: &colosseumLon,
+			Location: "Piazza del Colosseo", Notes: "Gate <B>", TimeZone: "Europe/Rome",
+			StartTime: time.Date(2026, 5, 1, 7, 0, 0, 0, time.UTC), EndTime: time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			Title: "Lunch somewhere", Category: domain.CategoryFood,
+			StartTime: time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2026, 5, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			Title: "Pantheon", Category: domain.CategoryActivity, Latitude: &pantheonLat, Longitude: &pantheonLon,
+			StartTime: time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC), EndTime: time.Date(2026, 5, 1, 13, 0, 0, 0, time.UTC),
+		},
+	}
+	day2 := []domain.Event{
+		{
+			Title: "Hotel Artemide", Category: domain.CategoryLodging, Latitude: &hotelLat, Longitude: &hotelLon,
+			StartTime: time.Date(2026, 5, 2, 13, 0, 0, 0, time.UTC), EndTime: time.Date(2026, 5, 2, 14, 0, 0, 0, time.UTC),
+		},
+	}
+	return trip, []domain.ItineraryDay{
+		{Date: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), Number: 1, Events: day1},
+		{Date: time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC), Number: 2, Events: day2},
+		{Date: time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC), Number: 3},
+	}
+}
+
+func TestExporter_ExportGPX(t *testing.T) {
+	trip, days := geoTestDays()
+	var b strings.Builder
+	if err := NewExporter().ExportGPX(&b, trip, days); err != nil {
+		t.Fatalf("NewExporter().ExportGPX() error = %v", err)
+	}
+
+	var doc struct {
+		Name      string `xml:"metadata>name"`
+		Waypoints []struct {
+			Lat  string `xml:"lat,attr"`
+			Lon  string `xml:"lon,attr"`
+			Time string `xml:"time"`
+			Name string `xml:"name"`
+			Desc string `xml:"desc"`
+			Type string `xml:"type"`
+		} `xml:"wpt"`
+		Routes []struct {
+			Name   string `xml:"name"`
+			Points []struct {
+				Name string `xml:"name"`
+			} `xml:"rtept"`
+		} `xml:"rte"`
+	}
+	if err := xml.Unmarshal([]byte(b.String()), &doc); err != nil {
+		t.Fatalf("output is not valid XML: %v\n%s", err, b.String())
+	}
+	if doc.Name != trip.Name {
+		t.Errorf("name = %q", doc.Name)
+	}
+	if len(doc.Waypoints) != 3 {
+		t.Fatalf("got %d waypoints, want the 3 events with coordinates", len(doc.Waypoints))
+	}
+	wpt := doc.Waypoints[0]
+	if wpt.Lat != "41.8902" || wpt.Lon != "12.4922" || wpt.Name != "Colosseum" || wpt.Type != "activity" ||
+		wpt.Desc != "Gate <B>" || wpt.Time != "2026-05-01T07:00:00Z" {
+		t.Errorf("first waypoint = %+v", wpt)
+	}
+	if len(doc.Routes) != 1 {
+		t.Fatalf("got %d routes, want one for the only day with two places", len(doc.Routes))
+	}
+	if r := doc.Routes[0]; !strings.HasPrefix(r.Name, "Day 1") || len(r.Points) != 2 || r.Points[1].Name != "Pantheon" {
+		t.Errorf("route = %+v", r)
+	}
+	// Waypoints must precede routes for schema validity
+	if strings.LastIndex(b.String(), "<wpt") > strings.Index(b.String(), "<rte>") {
+		t.Error("waypoint written after a route")
+	}
+}
+
+func TestExporter_ExportKML(t *testing.T) {
+	trip, days := geoTestDays()
+	var b strings.Builder
+	if err := NewExporter().ExportKML(&b, trip, days); err != nil {
+		t.Fatalf("NewExporter().ExportKML() error = %v", err)
+	}
+
+	var doc struct {
+		Folders []struct {
+			Name       string `xml:"name"`
+			Placemarks []struct {
+				Name   string `xml:"name"`
+				Point  string `xml:"Point>coordinates"`
+				Line   string `xml:"LineString>coordinates"`
+				Begin  string `xml:"TimeSpan>begin"`
+				Values []struct {
+					Name  string `xml:"name,attr"`
+					Value string `xml:"value"`
+				} `xml:"ExtendedData>Data"`
+			} `xml:"Placemark"`
+		} `xml:"Document>Folder"`
+	}
+	if err := xml.Unmarshal([]byte(b.String()), &doc); err != nil {
+		t.Fatalf("output is not valid XML: %v\n%s", err, b.String())
+	}
+	if len(doc.Folders) != 2 {
+		t.Fatalf("got %d folders, want one per day with places", len(doc.Folders))
+	}
+	day1 := doc.Folders[0].Placemarks
+	if len(day1) != 3 {
+		t.Fatalf("day 1 has %d placemarks, want 2 places and a route", len(day1))
+	}
+	if day1[0].Point != "12.4922,41.8902" || day1[0].Begin != "2026-05-01T07:00:00Z" {
+		t.Errorf("first placemark = %+v", day1[0])
+	}
+	if len(day1[0].Values) == 0 || day1[0].Values[0].Name != "category" || day1[0].Values[0].Value != "activity" {
+		t.Errorf("category not carried: %+v", day1[0].Values)
+	}
+	if day1[2].Line != "12.4922,41.8902 12.4769,41.8986" {
+		t.Errorf("route coordinates = %q", day1[2].Line)
+	}
+}
+
+func TestExporter_ExportGeoJSON(t *testing.T) {
+	trip, days := geoTestDays()
+	var b strings.Builder
+	if err := NewExporter().ExportGeoJSON(&b, trip, days); err != nil {
+		t.Fatalf("NewExporter().ExportGeoJSON() error = %v", err)
+	}
+
+	var doc struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Geometry struct {
+				Type        string          `json:"type"`
+				Coordinates json.RawMessage `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]any `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal([]byte(b.String()), &doc); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if doc.Type != "FeatureCollection" || len(doc.Features) != 4 {
+		t.Fatalf("got %s with %d features, want 3 points and 1 route", doc.Type, len(doc.Features))
+	}
+	compact := func(raw json.RawMessage) string {
+		var buf bytes.Buffer
+		_ = json.Compact(&buf, raw)
+		return buf.String()
+	}
+	first := doc.Features[0]
+	if first.Geometry.Type != "Point" || compact(first.Geometry.Coordinates) != "[12.4922,41.8902]" {
+		t.Errorf("first geometry = %s %s", first.Geometry.Type, compact(first.Geometry.Coordinates))
+	}
+	for key, want := range map[string]any{"name": "Colosseum", "category": "activity", "notes": "Gate <B>", "start_time": "2026-05-01T07:00:00Z"} {
+		if first.Properties[key] != want {
+			t.Errorf("properties[%q] = %v, want %v", key, first.Properties[key], want)
+		}
+	}
+	route := doc.Features[2]
+	if route.Geometry.Type != "LineString" || compact(route.Geometry.Coordinates) != "[[12.4922,41.8902],[12.4769,41.8986]]" {
+		t.Errorf("route geometry = %s %s", route.Geometry.Type, compact(route.Geometry.Coordinates))
+	}
+}
@@ -0,0 +1,177 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+var _ domain.CalendarExporter = (*Exporter)(nil)
+
+// icsTimeFormat is the iCalendar UTC date-time form. Every time is written in
+// UTC so the calendar needs no VTIMEZONE blocks; phones show it in local time.
+const icsTimeFormat = "20060102T150405Z"
+
+// Exporter writes trips as iCalendar files, for download and for the feeds
+// calendar apps subscribe to.
+type Exporter struct{}
+
+func NewExporter() *Exporter {
+	return &Exporter{}
+}
+
+// ExportCalendar encodes events as an RFC 5545 VCALENDAR. UIDs derive from
+// the event ID, so re-importing an updated file replaces events instead of
+// duplicating them.
+func (e *Exporter) ExportCalendar(w io.Writer, trip *domain.Trip, events []domain.Event) error {
+	c := &icsWriter{w: bufio.NewWriter(w)}
+	c.line("BEGIN", "VCALENDAR")
+	c.line("VERSION", "2.0")
+	c.line("PRODID", "-//traccia//Trip Calendar//EN")
+	c.line("CALSCALE", "GREGORIAN")
+	c.line("METHOD", "PUBLISH")
+	c.line("X-WR-CALNAME", icsText(trip.Name))
+	if trip.TimeZone != "" {
+		c.line("X-WR-TIMEZONE", trip.TimeZone)
+	}
+	for i := range events {
+		writeEvent(c, &events[i])
+	}
+	c.line("END", "VCALENDAR")
+	if c.err != nil {
+		return c.err
+	}
+	return c.w.Flush()
+}
+
+func writeEvent(c *icsWriter, event *domain.Event) {
+	c.line("BEGIN", "VEVENT")
+	c.line("UID", EventUID(event.ID))
+	c.line("DTSTAMP", event.UpdatedAt.UTC().Format(icsTimeFormat))
+	c.line("CREATED", event.CreatedAt.UTC().Format(icsTimeFormat))
+	c.line("LAST-MODIFIED", event.UpdatedAt.UTC().Format(icsTimeFormat))
+	c.line("DTSTART", event.StartTime.UTC().Format(icsTimeFormat))
+	c.line("DTEND", event.EndTime.UTC().Format(icsTimeFormat))
+	c.line("SUMMARY", icsText(event.Title))
+	c.line("CATEGORIES", icsText(string(event.Category)))
+	if event.Location != "" {
+		c.line("LOCATION", icsText(event.Location))
+	}
+	if event.Latitude != nil && event.Longitude != nil {
+		c.line("GEO", fmt.Sprintf("%.6f;%.6f", *event.Latitude, *event.Longitude))
+	}
+	if description := eventDescription(event); description != "" {
+		c.line("DESCRIPTION", icsText(description))
+	}
+	c.line("END", "VEVENT")
+}
+
+// EventUID is the stable iCalendar UID of an event.
+func EventUID(id int) string {
+	return fmt.Sprintf("event-%d@traccia", id)
+}
+
+// eventDescription lists the category details a calendar app has no field for,
+// followed by the event's notes.
+func eventDescription(event *domain.Event) string {
+	var details []string
+	switch {
+	case event.Flight != nil:
+		fd := event.Flight
+		if flight := strings.TrimSpace(fd.Airline + " " + fd.FlightNumber); flight != "" {
+			details = append(details, "Flight: "+flight)
+		}
+		if fd.DepartureAirport != "" || fd.ArrivalAirport != "" {
+			details = append(details, "Route: "+airportDetail(fd.DepartureAirport, fd.DepartureTerminal, fd.DepartureGate)+
+				" → "+airportDetail(fd.ArrivalAirport, fd.ArrivalTerminal, fd.ArrivalGate))
+		}
+		if fd.BookingReference != "" {
+			details = append(details, "Booking reference: "+fd.BookingReference)
+		}
+	case event.Lodging != nil:
+		ld := event.Lodging
+		if ld.CheckInTime != nil {
+			details = append(details, "Check-in: "+ld.CheckInTime.In(domain.ZoneOrUTC(event.TimeZone)).Format("Mon, Jan 2 3:04 PM MST"))
+		}
+		if ld.CheckOutTime != nil {
+			details = append(details, "Check-out: "+ld.CheckOutTime.In(domain.ZoneOrUTC(event.TimeZone)).Format("Mon, Jan 2 3:04 PM MST"))
+		}
+		if ld.BookingReference != "" {
+			details = append(details, "Booking reference: "+ld.BookingReference)
+		}
+	case event.Transit != nil:
+		td := event.Transit
+		if td.Origin != "" || td.Destination != "" {
+			route := td.Origin + " → " + td.Destination
+			if td.TransportMode != "" {
+				route = td.TransportMode + ": " + route
+			}
+			details = append(details, route)
+		}
+	}
+
+	description := strings.Join(details, "\n")
+	if event.Notes != "" {
+		if description != "" {
+			description += "\n\n"
+		}
+		description += event.Notes
+	}
+	return description
+}
+
+// airportDetail formats an airport code with its terminal and gate when known.
+func airportDetail(airport, terminal, gate string) string {
+	parts := []string{airport}
+	if terminal != "" {
+		parts = append(parts, "T"+strings.TrimPrefix(terminal, "T"))
+	}
+	if gate != "" {
+		parts = append(parts, "gate "+gate)
+	}
+	return strings.Join(parts, " ")
+}
+
+// icsText escapes a TEXT property value.
+func icsText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// icsWriter writes content lines, folding them at 75 octets as RFC 5545
+// requires. The first write error is kept and later writes are skipped.
+type icsWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (c *icsWriter) line(name, value string) {
+	if c.err != nil {
+		return
+	}
+	const limit = 75
+	s := name + ":" + value
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		size := utf8.RuneLen(r)
+		if width+size > limit {
+			// Continuation lines start with a space, which counts towards the limit
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+	_, c.err = c.w.WriteString(b.String())
+}
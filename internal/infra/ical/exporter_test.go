@@ -0,0 +1,132 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+func TestExporter_ExportCalendar(t *testing.T) {
+	lat, lon := 41.8902, 12.4922
+	checkIn := time.Date(2026, 5, 1, 13, 0, 0, 0, time.UTC)
+	checkOut := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	trip := &domain.Trip{ID: 1, Name: "Rome, again", TimeZone: "Europe/Rome"}
+	events := []domain.Event{
+		{
+			ID:        7,
+			Title:     "Colosseum; guided tour",
+			Category:  domain.CategoryActivity,
+			Location:  "Piazza del Colosseo, Roma",
+			Latitude:  &lat,
+			Longitude: &lon,
+			StartTime: time.Date(2026, 5, 2, 8, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2026, 5, 2, 10, 0, 0, 0, time.UTC),
+			Notes:     "Bring tickets\nMeet at gate B",
+		},
+		{
+			ID:        8,
+			Title:     "Flight home",
+			Category:  domain.CategoryFlight,
+			StartTime: time.Date(2026, 5, 4, 10, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2026, 5, 4, 12, 30, 0, 0, time.UTC),
+			Flight: &domain.FlightDetails{
+				Airline:           "AZ",
+				FlightNumber:      "610",
+				DepartureAirport:  "FCO",
+				DepartureTerminal: "3",
+				ArrivalAirport:    "LHR",
+				BookingReference:  "ABC123",
+			},
+		},
+		{
+			ID:        9,
+			Title:     "Hotel Artemide",
+			Category:  domain.CategoryLodging,
+			TimeZone:  "Europe/Rome",
+			StartTime: checkIn,
+			EndTime:   checkIn.Add(time.Hour),
+			Lodging:   &domain.LodgingDetails{CheckInTime: &checkIn, CheckOutTime: &checkOut},
+		},
+	}
+
+	var b strings.Builder
+	if err := NewExporter().ExportCalendar(&b, trip, events); err != nil {
+		t.Fatalf("ExportCalendar() error = %v", err)
+	}
+	ics := b.String()
+
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+	// Unfold continuation lines before looking for properties
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+
+	want := []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Rome\\, again\r\n",
+		"UID:event-7@traccia\r\n",
+		"DTSTART:20260502T080000Z\r\n",
+		"DTEND:20260502T100000Z\r\n",
+		"SUMMARY:Colosseum\\; guided tour\r\n",
+		"LOCATION:Piazza del Colosseo\\, Roma\r\n",
+		"GEO:41.890200;12.492200\r\n",
+		"DESCRIPTION:Bring tickets\\nMeet at gate B\r\n",
+		"UID:event-8@traccia\r\n",
+		"DESCRIPTION:Flight: AZ 610\\nRoute: FCO T3 → LHR\\nBooking reference: ABC123\r\n",
+		"DESCRIPTION:Check-in: Fri\\, May 1 3:00 PM CEST\\nCheck-out: Mon\\, May 4 11:00 AM CEST\r\n",
+		"END:VCALENDAR\r\n",
+	}
+	for _, w := range want {
+		if !strings.Contains(unfolded, w) {
+			t.Errorf("calendar missing %q\nGot:\n%s", w, unfolded)
+		}
+	}
+	if got := strings.Count(unfolded, "BEGIN:VEVENT"); got != len(events) {
+		t.Errorf("got %d VEVENTs, want %d", got, len(events))
+	}
+}
+
+func TestExporter_RoundTrip(t *testing.T) {
+	lat, lon := 35.6586, 139.7454
+	trip := &domain.Trip{ID: 1, Name: "Tokyo", TimeZone: "Asia/Tokyo"}
+	exported := []domain.Event{{
+		ID:        5,
+		Title:     "Tokyo Tower; observation deck, top floor",
+		Category:  domain.CategoryActivity,
+		Location:  "4 Chome-2-8 Shibakoen, Minato City",
+		Latitude:  &lat,
+		Longitude: &lon,
+		StartTime: time.Date(2026, 5, 2, 1, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2026, 5, 2, 3, 0, 0, 0, time.UTC),
+		Notes:     "Sunset \\ night view",
+	}}
+
+	var b strings.Builder
+	if err := NewExporter().ExportCalendar(&b, trip, exported); err != nil {
+		t.Fatalf("ExportCalendar() error = %v", err)
+	}
+	events, err := NewParser().ParseCalendar(b.String(), time.UTC)
+	if err != nil {
+		t.Fatalf("ParseCalendar() error = %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	got := events[0]
+	if got.Summary != exported[0].Title || got.Location != exported[0].Location || got.Description != exported[0].Notes {
+		t.Errorf("text fields changed in round trip: %+v", got)
+	}
+	if !got.Start.Equal(exported[0].StartTime) || !got.End.Equal(exported[0].EndTime) {
+		t.Errorf("times changed in round trip: %v – %v", got.Start, got.End)
+	}
+	if got.UID != EventUID(5) {
+		t.Errorf("UID = %q, want %q", got.UID, EventUID(5))
+	}
+	if domain.EventCategory(strings.ToLower(got.Categories)) != domain.CategoryActivity {
+		t.Errorf("exported category not kept: %q", got.Categories)
+	}
+}
@@ -0,0 +1,211 @@
+// Package ical reads and writes iCalendar (RFC 5545) files: those exported by
+// calendar apps, and traccia's own exports and feeds.
+package ical
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+var _ domain.CalendarParser = (*Parser)(nil)
+
+// Parser reads the events of iCalendar files.
+type Parser struct{}
+
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// ParseCalendar reads the VEVENTs of an iCalendar file. Floating times and
+// zones that are not IANA names (Outlook writes Windows zone names) are read
+// in loc.
+func (p *Parser) ParseCalendar(data string, loc *time.Location) ([]domain.CalendarEntry, error) {
+	var (
+		events  []domain.CalendarEntry
+		current *domain.CalendarEntry
+		sawCal  bool
+	)
+	for _, line := range unfold(data) {
+		name, params, value, ok := parseLine(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCALENDAR"):
+			sawCal = true
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			current = &domain.CalendarEntry{}
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if current != nil {
+				events = append(events, *current)
+			}
+			current = nil
+		case current != nil:
+			if err := set(current, name, params, value, loc); err != nil {
+				return nil, fmt.Errorf("event %d: %w", len(events)+1, err)
+			}
+		}
+	}
+	if !sawCal {
+		return nil, errors.New("not an iCalendar file")
+	}
+	return events, nil
+}
+
+func set(e *domain.CalendarEntry, name string, params map[string]string, value string, loc *time.Location) error {
+	switch name {
+	case "UID":
+		e.UID = value
+	case "SUMMARY":
+		e.Summary = unescape(value)
+	case "LOCATION":
+		e.Location = unescape(value)
+	case "DESCRIPTION":
+		e.Description = unescape(value)
+	case "CATEGORIES":
+		e.Categories = unescape(value)
+	case "GEO":
+		lat, lon, ok := strings.Cut(value, ";")
+		if !ok {
+			return fmt.Errorf("invalid GEO %q", value)
+		}
+		latitude, err := strconv.ParseFloat(lat, 64)
+		if err != nil {
+			return fmt.Errorf("invalid GEO %q", value)
+		}
+		longitude, err := strconv.ParseFloat(lon, 64)
+		if err != nil {
+			return fmt.Errorf("invalid GEO %q", value)
+		}
+		e.Latitude, e.Longitude = &latitude, &longitude
+	case "DTSTART":
+		t, zone, allDay, err := parseTime(params, value, loc)
+		if err != nil {
+			return fmt.Errorf("invalid DTSTART: %w", err)
+		}
+		e.Start, e.TimeZone, e.AllDay = t, zone, allDay
+	case "DTEND":
+		t, _, _, err := parseTime(params, value, loc)
+		if err != nil {
+			return fmt.Errorf("invalid DTEND: %w", err)
+		}
+		e.End, e.HasEnd = t, true
+	case "DURATION":
+		d, err := parseDuration(value)
+		if err != nil {
+			return err
+		}
+		e.Duration = d
+	}
+	return nil
+}
+
+// unfold splits data into content lines, joining folded continuations.
+func unfold(data string) []string {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	var lines []string
+	for _, line := range strings.Split(data, "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parseLine splits a content line into its upper-cased name, parameters and
+// raw value. Colons inside quoted parameter values do not end the name.
+func parseLine(line string) (name string, params map[string]string, value string, ok bool) {
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params = make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(p, "=")
+		params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:], true
+}
+
+// parseTime reads a DATE or DATE-TIME value. zone is the IANA zone the value
+// was given in, empty when it was UTC or floating.
+func parseTime(params map[string]string, value string, loc *time.Location) (t time.Time, zone string, allDay bool, err error) {
+	if params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err = time.ParseInLocation("20060102", value, loc)
+		return t, "", true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.Parse("20060102T150405Z", value)
+		return t, "", false, err
+	}
+	if tzid := params["TZID"]; tzid != "" {
+		if tzLoc, loadErr := domain.LoadTimeZone(tzid); loadErr == nil {
+			loc, zone = tzLoc, tzid
+		}
+	}
+	t, err = time.ParseInLocation("20060102T150405", value, loc)
+	return t, zone, false, err
+}
+
+var durationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration reads a DURATION such as PT1H30M or P2D.
+func parseDuration(value string) (time.Duration, error) {
+	m := durationPattern.FindStringSubmatch(value)
+	if m == nil {
+		return 0, fmt.Errorf("invalid DURATION %q", value)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, _ := strconv.Atoi(m[i+2])
+		d += time.Duration(n) * unit
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+// unescape reverses the escaping of a TEXT value.
+func unescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleCalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VTIMEZONE\r\n" +
+	"TZID:Europe/Rome\r\n" +
+	"END:VTIMEZONE\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:abc-1\r\n" +
+	"SUMMARY:Flight AZ 610 FCO-JFK\r\n" +
+	"DTSTART;TZID=Europe/Rome:20260503T101500\r\n" +
+	"DTEND;TZID=America/New_York:20260503T135000\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:abc-2\r\n" +
+	"SUMMARY:Hotel Artemide\r\n" +
+	"DTSTART;VALUE=DATE:20260501\r\n" +
+	"DTEND;VALUE=DATE:20260503\r\n" +
+	"LOCATION:Via Nazionale 22\\, Roma\r\n" +
+	"GEO:41.9009;12.4936\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:abc-3\r\n" +
+	"SUMMARY:Dinner at Roscioli\r\n" +
+	"DTSTART:20260501T180000Z\r\n" +
+	"DURATION:PT1H30M\r\n" +
+	"DESCRIPTION:Ask for the carbonara\\nTable for two\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:event-42@traccia\r\n" +
+	"SUMMARY:Vatican Museums with a very long title that has to be folded by the\r\n" +
+	"  exporting calendar\r\n" +
+	"DTSTART;TZID=W. Europe Standard Time:20260502T090000\r\n" +
+	"DTEND;TZID=W. Europe Standard Time:20260502T120000\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParser_ParseCalendar(t *testing.T) {
+	rome, _ := time.LoadLocation("Europe/Rome")
+	events, err := NewParser().ParseCalendar(sampleCalendar, rome)
+	if err != nil {
+		t.Fatalf("ParseCalendar() error = %v", err)
+	}
+	if len(events) != 4 {
+		t.Fatalf("got %d events, want 4", len(events))
+	}
+
+	flight := events[0]
+	if flight.UID != "abc-1" || flight.TimeZone != "Europe/Rome" || !flight.HasEnd {
+		t.Errorf("flight = %+v", flight)
+	}
+	if want := time.Date(2026, 5, 3, 8, 15, 0, 0, time.UTC); !flight.Start.Equal(want) {
+		t.Errorf("flight start = %v, want %v", flight.Start, want)
+	}
+	if want := time.Date(2026, 5, 3, 17, 50, 0, 0, time.UTC); !flight.End.Equal(want) {
+		t.Errorf("flight end = %v, want %v", flight.End, want)
+	}
+
+	hotel := events[1]
+	if !hotel.AllDay || hotel.Location != "Via Nazionale 22, Roma" {
+		t.Errorf("hotel = %+v", hotel)
+	}
+	if hotel.Latitude == nil || *hotel.Latitude != 41.9009 || hotel.Longitude == nil || *hotel.Longitude != 12.4936 {
+		t.Errorf("hotel geo = %v, %v", hotel.Latitude, hotel.Longitude)
+	}
+	if got := hotel.Start.In(rome).Format("Jan 2 15:04"); got != "May 1 00:00" {
+		t.Errorf("hotel start = %s", got)
+	}
+
+	dinner := events[2]
+	if dinner.Duration != 90*time.Minute || dinner.HasEnd || dinner.TimeZone != "" {
+		t.Errorf("dinner = %+v", dinner)
+	}
+	if dinner.Description != "Ask for the carbonara\nTable for two" {
+		t.Errorf("dinner description = %q", dinner.Description)
+	}
+
+	museum := events[3]
+	if !strings.HasSuffix(museum.Summary, "has to be folded by the exporting calendar") {
+		t.Errorf("folded summary = %q", museum.Summary)
+	}
+	if got := museum.Start.In(rome).Format("15:04"); got != "09:00" || museum.TimeZone != "" {
+		t.Errorf("unknown zone should read in loc, got %s in %q", got, museum.TimeZone)
+	}
+}
+
+func TestParser_ParseCalendar_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "not a calendar", data: "hello"},
+		{name: "bad start", data: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:tomorrow\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"},
+		{name: "bad duration", data: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDURATION:an hour\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"},
+		{name: "bad geo", data: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nGEO:north\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewParser().ParseCalendar(tt.data, time.UTC); err == nil {
+				t.Error("ParseCalendar() error = nil")
+			}
+		})
+	}
+}
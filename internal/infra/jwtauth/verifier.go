@@ -0,0 +1,253 @@
+// Package jwtauth verifies bearer tokens issued by an external identity
+// provider, such as Supabase Auth.
+package jwtauth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+var _ domain.TokenVerifier = (*Verifier)(nil)
+
+const (
+	// jwksRefreshInterval is how often a remote key set may be fetched again
+	// to pick up a key it did not have yet.
+	jwksRefreshInterval = time.Minute
+	// maxJWKSBytes bounds the size of a key set document.
+	maxJWKSBytes = 1 << 20
+)
+
+// Config describes which tokens a Verifier accepts. At least one of Secret
+// and JWKS is required.
+type Config struct {
+	// Secret is the shared HS256 signing secret.
+	Secret string
+	// JWKS is the path or http(s) URL of a JSON Web Key Set with the RS256
+	// public keys.
+	JWKS string
+	// Issuer is the required "iss" claim; empty accepts any issuer.
+	Issuer string
+	// Audience is the required "aud" claim; empty accepts any audience.
+	Audience string
+	// Leeway tolerates clock skew when checking expiry.
+	Leeway time.Duration
+}
+
+// Verifier checks the signature, issuer, audience and expiry of JWTs.
+type Verifier struct {
+	lastFetch time.Time
+	client    *http.Client
+	keys      map[string]*rsa.PublicKey
+	parser    *jwt.Parser
+	cfg       Config
+	mu        sync.Mutex
+}
+
+// New returns a Verifier for cfg, loading its key set if one is configured.
+func New(ctx context.Context, cfg Config) (*Verifier, error) {
+	var methods []string
+	if cfg.Secret != "" {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if cfg.JWKS != "" {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("jwtauth: a secret or a JWKS is required")
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(cfg.Leeway),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	v := &Verifier{
+		cfg:    cfg,
+		parser: jwt.NewParser(opts...),
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+	if cfg.JWKS != "" {
+		if err := v.loadKeys(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// claims are the registered claims plus the user's email, which Supabase
+// includes in its access tokens. Supabase reports whether the email is
+// verified in the user's metadata; other providers use the OpenID Connect
+// claim.
+type claims struct {
+	jwt.RegisteredClaims
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	UserMetadata  struct {
+		EmailVerified bool `json:"email_verified"`
+	} `json:"user_metadata"`
+}
+
+// Verify checks token and returns the identity in its "sub" and "email"
+// claims. Invalid tokens are reported as ErrInvalidInput.
+func (v *Verifier) Verify(ctx context.Context, token string) (*domain.TokenIdentity, error) {
+	var c claims
+	_, err := v.parser.ParseWithClaims(token, &c, func(t *jwt.Token) (any, error) {
+		return v.key(ctx, t)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+	if c.Subject == "" {
+		return nil, fmt.Errorf("%w: token has no subject", domain.ErrInvalidInput)
+	}
+	return &domain.TokenIdentity{
+		Subject:       c.Subject,
+		Email:         c.Email,
+		EmailVerified: c.Email != "" && (c.EmailVerified || c.UserMetadata.EmailVerified),
+	}, nil
+}
+
+// key picks the verification key for t's algorithm.
+func (v *Verifier) key(ctx context.Context, t *jwt.Token) (any, error) {
+	if t.Method == jwt.SigningMethodHS256 {
+		return []byte(v.cfg.Secret), nil
+	}
+	kid, _ := t.Header["kid"].(string)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if key, ok := v.lookup(kid); ok {
+		return key, nil
+	}
+	// The provider may have rotated its keys since they were last fetched
+	if isURL(v.cfg.JWKS) && time.Since(v.lastFetch) >= jwksRefreshInterval {
+		if err := v.fetchKeys(ctx); err != nil {
+			return nil, err
+		}
+		if key, ok := v.lookup(kid); ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// lookup finds the key with kid. Tokens without a kid are accepted when the
+// set holds a single key. The caller holds v.mu.
+func (v *Verifier) lookup(kid string) (*rsa.PublicKey, bool) {
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+	key, ok := v.keys[kid]
+	return key, ok
+}
+
+func (v *Verifier) loadKeys(ctx context.Context) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.fetchKeys(ctx)
+}
+
+// fetchKeys reads the key set from its file or URL. The caller holds v.mu.
+func (v *Verifier) fetchKeys(ctx context.Context) error {
+	v.lastFetch = time.Now()
+
+	var data []byte
+	if isURL(v.cfg.JWKS) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.cfg.JWKS, http.NoBody)
+		if err != nil {
+			return fmt.Errorf("jwtauth: fetching key set: %w", err)
+		}
+		resp, err := v.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("jwtauth: fetching key set: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("jwtauth: fetching key set: %s", resp.Status)
+		}
+		if data, err = io.ReadAll(io.LimitReader(resp.Body, maxJWKSBytes)); err != nil {
+			return fmt.Errorf("jwtauth: reading key set: %w", err)
+		}
+	} else {
+		var err error
+		if data, err = os.ReadFile(v.cfg.JWKS); err != nil {
+			return fmt.Errorf("jwtauth: reading key set: %w", err)
+		}
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+	v.keys = keys
+	return nil
+}
+
+// jwk is the subset of a JSON Web Key needed for RSA signature keys.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// parseJWKS reads the RSA signing keys of a key set, by key ID. Keys of other
+// types, such as the EC keys some providers publish alongside, are skipped.
+func parseJWKS(data []byte) (map[string]*rsa.PublicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("jwtauth: parsing key set: %w", err)
+	}
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("jwtauth: key %q: invalid modulus: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("jwtauth: key %q: invalid exponent", k.Kid)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("jwtauth: key set has no RSA signing keys")
+	}
+	return keys, nil
+}
+
+func isURL(s string) bool {
+	return strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://")
+}
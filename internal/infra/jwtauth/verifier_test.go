@@ -0,0 +1,249 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+const (
+	testSubject = "7b6f3c1e-2f4a-4c2b-9d1e-0a5b6c7d8e9f"
+	testIssuer  = "https://example.supabase.co/auth/v1"
+)
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   testSubject,
+		"email": "ada@example.com",
+		"iss":   testIssuer,
+		"aud":   "authenticated",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	return s
+}
+
+func jwksJSON(t *testing.T, keys map[string]*rsa.PublicKey) []byte {
+	t.Helper()
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	for kid, key := range keys {
+		set.Keys = append(set.Keys, jwk{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	return data
+}
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	return key
+}
+
+func TestVerifier_HS256(t *testing.T) {
+	secret := []byte("super-secret-jwt-token-with-at-least-32-characters")
+	v, err := New(context.Background(), Config{Secret: string(secret), Issuer: testIssuer, Audience: "authenticated"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	rsaKey := newRSAKey(t)
+	tests := []struct {
+		name    string
+		token   func() string
+		wantErr bool
+	}{
+		{name: "valid", token: func() string { return sign(t, jwt.SigningMethodHS256, secret, "", validClaims()) }},
+		{name: "wrong secret", wantErr: true, token: func() string {
+			return sign(t, jwt.SigningMethodHS256, []byte("another-secret"), "", validClaims())
+		}},
+		{name: "expired", wantErr: true, token: func() string {
+			c := validClaims()
+			c["exp"] = time.Now().Add(-time.Hour).Unix()
+			return sign(t, jwt.SigningMethodHS256, secret, "", c)
+		}},
+		{name: "no expiry", wantErr: true, token: func() string {
+			c := validClaims()
+			delete(c, "exp")
+			return sign(t, jwt.SigningMethodHS256, secret, "", c)
+		}},
+		{name: "wrong issuer", wantErr: true, token: func() string {
+			c := validClaims()
+			c["iss"] = "https://elsewhere.example.com"
+			return sign(t, jwt.SigningMethodHS256, secret, "", c)
+		}},
+		{name: "wrong audience", wantErr: true, token: func() string {
+			c := validClaims()
+			c["aud"] = "anon"
+			return sign(t, jwt.SigningMethodHS256, secret, "", c)
+		}},
+		{name: "no subject", wantErr: true, token: func() string {
+			c := validClaims()
+			delete(c, "sub")
+			return sign(t, jwt.SigningMethodHS256, secret, "", c)
+		}},
+		{name: "algorithm not configured", wantErr: true, token: func() string {
+			return sign(t, jwt.SigningMethodRS256, rsaKey, "", validClaims())
+		}},
+		{name: "unsigned", wantErr: true, token: func() string {
+			return sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", validClaims())
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := v.Verify(context.Background(), tt.token())
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidInput) {
+					t.Errorf("Verify() error = %v, want ErrInvalidInput", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if identity.Subject != testSubject || identity.Email != "ada@example.com" {
+				t.Errorf("Verify() = %+v", identity)
+			}
+		})
+	}
+}
+
+func TestVerifier_EmailVerified(t *testing.T) {
+	secret := []byte("super-secret-jwt-token-with-at-least-32-characters")
+	v, err := New(context.Background(), Config{Secret: string(secret), Issuer: testIssuer, Audience: "authenticated"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		extra jwt.MapClaims
+		name  string
+		want  bool
+	}{
+		{name: "not claimed"},
+		{name: "OpenID Connect claim", extra: jwt.MapClaims{"email_verified": true}, want: true},
+		{name: "Supabase metadata", extra: jwt.MapClaims{"user_metadata": map[string]any{"email_verified": true}}, want: true},
+		{name: "claimed false", extra: jwt.MapClaims{"email_verified": false}},
+		{name: "no email", extra: jwt.MapClaims{"email": "", "email_verified": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validClaims()
+			for k, val := range tt.extra {
+				c[k] = val
+			}
+			identity, err := v.Verify(context.Background(), sign(t, jwt.SigningMethodHS256, secret, "", c))
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if identity.EmailVerified != tt.want {
+				t.Errorf("EmailVerified = %v, want %v", identity.EmailVerified, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifier_RS256_File(t *testing.T) {
+	key := newRSAKey(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, jwksJSON(t, map[string]*rsa.PublicKey{"key-1": &key.PublicKey}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	v, err := New(context.Background(), Config{JWKS: path, Audience: "authenticated"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for _, kid := range []string{"key-1", ""} {
+		if _, err := v.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, key, kid, validClaims())); err != nil {
+			t.Errorf("Verify(kid %q) error = %v", kid, err)
+		}
+	}
+	other := newRSAKey(t)
+	if _, err := v.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, other, "key-1", validClaims())); err == nil {
+		t.Error("Verify() accepted a token signed with another key")
+	}
+	// HS256 signed with the public key must not pass for RS256
+	hmacKey := jwksJSON(t, map[string]*rsa.PublicKey{"key-1": &key.PublicKey})
+	if _, err := v.Verify(context.Background(), sign(t, jwt.SigningMethodHS256, hmacKey, "key-1", validClaims())); err == nil {
+		t.Error("Verify() accepted an HS256 token")
+	}
+}
+
+func TestVerifier_RS256_URL_Rotation(t *testing.T) {
+	first, second := newRSAKey(t), newRSAKey(t)
+	keys := map[string]*rsa.PublicKey{"first": &first.PublicKey}
+	fetches := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches++
+		_, _ = w.Write(jwksJSON(t, keys))
+	}))
+	defer srv.Close()
+
+	v, err := New(context.Background(), Config{JWKS: srv.URL})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err = v.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, first, "first", validClaims())); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	// The provider rotates in a new key; it is fetched once the refresh
+	// interval has passed
+	keys["second"] = &second.PublicKey
+	token := sign(t, jwt.SigningMethodRS256, second, "second", validClaims())
+	if _, err = v.Verify(context.Background(), token); err == nil {
+		t.Error("Verify() refetched the key set within the refresh interval")
+	}
+	v.lastFetch = time.Now().Add(-jwksRefreshInterval)
+	if _, err = v.Verify(context.Background(), token); err != nil {
+		t.Errorf("Verify() after refresh error = %v", err)
+	}
+	if fetches != 2 {
+		t.Errorf("fetches = %d, want 2", fetches)
+	}
+}
+
+func TestNew_NoKeys(t *testing.T) {
+	if _, err := New(context.Background(), Config{Issuer: testIssuer}); err == nil {
+		t.Error("New() without keys succeeded")
+	}
+}
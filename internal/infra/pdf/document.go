@@ -0,0 +1,198 @@
+package pdf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// A4 in points.
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+)
+
+// document is a minimal PDF 1.4 writer: pages of text, lines and filled
+// rectangles drawn with the standard Helvetica fonts.
+type document struct {
+	missing map[rune]bool // characters drawn as '?' for want of a glyph
+	title   string
+	pages   []*page
+}
+
+// page accumulates the content stream of one page. Coordinates are in points
+// from the bottom-left corner, as in PDF itself.
+type page struct {
+	doc     *document
+	content bytes.Buffer
+}
+
+func (d *document) addPage() *page {
+	p := &page{doc: d}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// missingGlyphs returns the characters drawn so far that the fonts could not
+// show, in code point order.
+func (d *document) missingGlyphs() []rune {
+	missing := make([]rune, 0, len(d.missing))
+	for r := range d.missing {
+		missing = append(missing, r)
+	}
+	slices.Sort(missing)
+	return missing
+}
+
+// text draws a line of UTF-8 text with its baseline at (x, y). Characters
+// WinAnsi lacks are drawn as '?' and remembered by the document.
+func (p *page) text(x, y float64, f *font, size, gray float64, s string) {
+	for _, r := range unrepresentable(s) {
+		if p.doc.missing == nil {
+			p.doc.missing = make(map[rune]bool)
+		}
+		p.doc.missing[r] = true
+	}
+	fmt.Fprintf(&p.content, "%s g BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		num(gray), f.name, num(size), num(x), num(y), escapeString(encodeWinAnsi(s)))
+}
+
+// line strokes a hairline from (x1, y1) to (x2, y2).
+func (p *page) line(x1, y1, x2, y2, gray float64) {
+	fmt.Fprintf(&p.content, "%s G 0.5 w %s %s m %s %s l S\n",
+		num(gray), num(x1), num(y1), num(x2), num(y2))
+}
+
+// qr draws a QR code as filled squares with its bottom-left corner at (x, y),
+// scaled to size points including the quiet zone the standard requires.
+func (p *page) qr(x, y, size float64, q *qrCode) {
+	const quietZone = 4
+	module := size / float64(q.size+2*quietZone)
+	left := x + quietZone*module
+	top := y + size - quietZone*module
+	p.content.WriteString("0 g\n")
+	for row := 0; row < q.size; row++ {
+		// One rectangle per run of dark modules keeps the stream small
+		for col := 0; col < q.size; {
+			if !q.modules[row][col] {
+				col++
+				continue
+			}
+			start := col
+			for col < q.size && q.modules[row][col] {
+				col++
+			}
+			fmt.Fprintf(&p.content, "%s %s %s %s re\n",
+				num(left+float64(start)*module), num(top-float64(row+1)*module),
+				num(float64(col-start)*module), num(module))
+		}
+	}
+	p.content.WriteString("f\n")
+}
+
+// writeTo serialises the document with a cross-reference table.
+func (d *document) writeTo(w io.Writer) error {
+	out := &pdfWriter{w: bufio.NewWriter(w)}
+	out.raw("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Object numbers: 1 catalog, 2 page tree, 3 info, 4-5 fonts, 6 resources,
+	// then a page object and its content stream for each page.
+	const firstPage = 7
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	out.object(1, "<< /Type /Catalog /Pages 2 0 R >>")
+	out.object(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	out.object(3, fmt.Sprintf("<< /Title %s /Producer (traccia) >>", textString(d.title)))
+	for i, f := range []*font{helvetica, helveticaBold} {
+		out.object(4+i, fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", f.baseFont))
+	}
+	out.object(6, fmt.Sprintf("<< /Font << /%s 4 0 R /%s 5 0 R >> >>", helvetica.name, helveticaBold.name))
+	for i, p := range d.pages {
+		n := firstPage + 2*i
+		out.object(n, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources 6 0 R /Contents %d 0 R >>",
+			num(pageWidth), num(pageHeight), n+1))
+		out.stream(n+1, p.content.Bytes())
+	}
+
+	xref := out.offset
+	size := firstPage + 2*len(d.pages)
+	out.raw(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", size))
+	for _, offset := range out.offsets[1:] {
+		out.raw(fmt.Sprintf("%010d 00000 n \n", offset))
+	}
+	out.raw(fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", size, xref))
+	if out.err != nil {
+		return out.err
+	}
+	return out.w.Flush()
+}
+
+// pdfWriter tracks byte offsets for the cross-reference table and keeps the
+// first write error, like bufio.Writer.
+type pdfWriter struct {
+	err     error
+	w       *bufio.Writer
+	offsets []int // by object number; objects must be written in order
+	offset  int
+}
+
+func (p *pdfWriter) raw(s string) {
+	if p.err != nil {
+		return
+	}
+	n, err := p.w.WriteString(s)
+	p.offset += n
+	p.err = err
+}
+
+func (p *pdfWriter) object(n int, body string) {
+	if len(p.offsets) == 0 {
+		p.offsets = []int{0}
+	}
+	if n != len(p.offsets) {
+		panic(fmt.Sprintf("pdf: object %d written out of order", n))
+	}
+	p.offsets = append(p.offsets, p.offset)
+	p.raw(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", n, body))
+}
+
+func (p *pdfWriter) stream(n int, data []byte) {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	_, _ = zw.Write(data)
+	_ = zw.Close()
+	p.object(n, fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.String()))
+}
+
+// num formats a coordinate with at most two decimals, which is finer than
+// any printer resolves.
+func num(f float64) string {
+	s := strings.TrimRight(strconv.FormatFloat(f, 'f', 2, 64), "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// escapeString escapes a literal string for use between parentheses.
+func escapeString(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`).Replace(s)
+}
+
+// textString encodes metadata text as UTF-16BE with a byte order mark, the
+// form readers accept for characters outside PDFDocEncoding.
+func textString(s string) string {
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", u)
+	}
+	b.WriteString(">")
+	return b.String()
+}
@@ -0,0 +1,426 @@
+// Package pdf renders printable trip itineraries without external services:
+// a small PDF writer using the standard fonts and a QR encoder for map links.
+package pdf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+var _ domain.PDFExporter = (*Exporter)(nil)
+
+// Page layout in points.
+const (
+	margin       = 48.0
+	footerHeight = 24.0
+	contentWidth = pageWidth - 2*margin
+	qrSize       = 64.0
+	qrGap        = 12.0
+	eventSpacing = 10.0
+	leading      = 1.3 // line height as a multiple of the font size
+)
+
+// Gray levels, 0 being black.
+const (
+	grayText  = 0.0
+	grayMuted = 0.4
+	grayRule  = 0.8
+)
+
+// mapsSearchURL opens the query in Google Maps, or the platform's map app on
+// phones, whichever way the code is scanned.
+const mapsSearchURL = "https://www.google.com/maps/search/?api=1&query="
+
+// Exporter lays out a trip as an A4 itinerary meant to be printed and carried:
+// every address, booking reference and transport detail on paper, plus a QR
+// code per place that opens it on a map once a phone is back online.
+type Exporter struct{}
+
+func NewExporter() *Exporter {
+	return &Exporter{}
+}
+
+// ExportPDF writes the itinerary of trip to w.
+func (e *Exporter) ExportPDF(ctx context.Context, w io.Writer, trip *domain.Trip, days []domain.ItineraryDay) error {
+	l := &layout{doc: &document{title: trip.Name}}
+	l.newPage()
+	l.header(trip)
+	for i := range days {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		l.day(&days[i])
+	}
+	l.footers(trip)
+	if missing := l.doc.missingGlyphs(); len(missing) > 0 {
+		// The standard fonts only cover Western European scripts; say so on
+		// paper rather than leave unexplained question marks
+		slog.WarnContext(ctx, "ExportPDF: characters the standard fonts lack were printed as '?'", "trip_id", trip.ID, "characters", string(missing))
+		l.missingGlyphsNote()
+	}
+	return l.doc.writeTo(w)
+}
+
+// textLine is one line of text in an event block.
+type textLine struct {
+	font *font
+	text string
+	size float64
+	gray float64
+}
+
+func (t textLine) height() float64 {
+	return t.size * leading
+}
+
+// layout places content top to bottom, starting new pages as they fill up.
+type layout struct {
+	doc  *document
+	page *page
+	y    float64 // top of the free space on the current page
+}
+
+func (l *layout) newPage() {
+	l.page = l.doc.addPage()
+	l.y = pageHeight - margin
+}
+
+// fits reports whether height more points fit above the footer.
+func (l *layout) fits(height float64) bool {
+	return l.y-height >= margin+footerHeight
+}
+
+func (l *layout) write(x float64, t textLine) {
+	l.y -= t.height()
+	l.page.text(x, l.y+t.size*(leading-1), t.font, t.size, t.gray, t.text)
+}
+
+func (l *layout) header(trip *domain.Trip) {
+	for _, line := range wrap(textLine{font: helveticaBold, size: 20, gray: grayText, text: trip.Name}, contentWidth) {
+		l.write(margin, line)
+	}
+	subtitle := trip.StartDate.Format("Jan 2") + " – " + trip.EndDate.Format("Jan 2, 2006")
+	if trip.Destination != "" {
+		subtitle = trip.Destination + " · " + subtitle
+	}
+	if trip.TimeZone != "" {
+		subtitle += " · " + trip.TimeZone
+	}
+	l.write(margin, textLine{font: helvetica, size: 10, gray: grayMuted, text: subtitle})
+	l.y -= 8
+}
+
+// footers numbers the pages once their count is known.
+func (l *layout) footers(trip *domain.Trip) {
+	for i, p := range l.doc.pages {
+		text := fmt.Sprintf("%s · Page %d of %d", trip.Name, i+1, len(l.doc.pages))
+		x := (pageWidth - helvetica.width(encodeWinAnsi(text), 8)) / 2
+		p.text(max(x, margin), margin-8, helvetica, 8, grayMuted, text)
+	}
+}
+
+// missingGlyphsNote explains, under the first footer, why some text shows as
+// question marks.
+func (l *layout) missingGlyphsNote() {
+	const note = "Characters outside the Latin alphabet cannot be printed with this PDF's fonts and are shown as ?"
+	x := (pageWidth - helvetica.width(encodeWinAnsi(note), 7)) / 2
+	l.doc.pages[0].text(max(x, margin), margin-20, helvetica, 7, grayMuted, note)
+}
+
+// day renders a day heading, its lodging stays and its events. An event is
+// never split across pages; the heading is repeated when a day continues.
+func (l *layout) day(day *domain.ItineraryDay) {
+	heading := fmt.Sprintf("Day %d · %s", day.Number, day.Date.Format("Monday, January 2"))
+	stays := stayLines(day.Stays)
+	first := eventSpacing
+	if len(day.Events) > 0 {
+		first = eventBlockHeight(&day.Events[0])
+	}
+	if !l.fits(22 + float64(len(stays))*12 + first) {
+		l.newPage()
+	}
+	l.dayHeading(heading)
+	for _, line := range stays {
+		l.write(margin, line)
+	}
+	if len(day.Events) == 0 {
+		l.write(margin, textLine{font: helvetica, size: 9.5, gray: grayMuted, text: "Nothing planned"})
+	}
+	for i := range day.Events {
+		event := &day.Events[i]
+		if !l.fits(eventBlockHeight(event)) {
+			l.newPage()
+			l.dayHeading(heading + " (continued)")
+		}
+		l.event(event)
+	}
+	l.y -= eventSpacing
+}
+
+func (l *layout) dayHeading(heading string) {
+	l.y -= 8
+	l.write(margin, textLine{font: helveticaBold, size: 13, gray: grayText, text: heading})
+	l.y -= 3
+	l.page.line(margin, l.y, pageWidth-margin, l.y, grayRule)
+	l.y -= 4
+}
+
+func stayLines(stays []domain.DayStay) []textLine {
+	lines := make([]textLine, 0, len(stays))
+	for _, stay := range stays {
+		var text string
+		switch stay.Kind {
+		case domain.StayCheckIn:
+			text = "Check-in: " + stay.Lodging.Title
+		case domain.StayCheckOut:
+			text = "Check-out: " + stay.Lodging.Title
+		default:
+			text = "Staying at " + stay.Lodging.Title
+		}
+		if stay.Lodging.Location != "" {
+			text += ", " + stay.Lodging.Location
+		}
+		lines = append(lines, textLine{font: helvetica, size: 9, gray: grayMuted, text: text})
+	}
+	return lines
+}
+
+func (l *layout) event(event *domain.Event) {
+	code := eventQR(event)
+	textWidth := contentWidth
+	if code != nil {
+		textWidth -= qrSize + qrGap
+	}
+
+	top := l.y - eventSpacing/2
+	l.y = top
+	for _, line := range eventLines(event, textWidth) {
+		l.write(margin, line)
+	}
+	if code != nil {
+		l.page.qr(pageWidth-margin-qrSize, top-qrSize, qrSize, code)
+		l.y = min(l.y, top-qrSize)
+	}
+	l.y -= eventSpacing / 2
+}
+
+func eventBlockHeight(event *domain.Event) float64 {
+	hasQR := eventQR(event) != nil
+	textWidth := contentWidth
+	if hasQR {
+		textWidth -= qrSize + qrGap
+	}
+	height := 0.0
+	for _, line := range eventLines(event, textWidth) {
+		height += line.height()
+	}
+	if hasQR {
+		height = max(height, qrSize)
+	}
+	return height + eventSpacing
+}
+
+// eventLines lays out an event's text: time and category, title, address,
+// category details and notes, wrapped to width.
+func eventLines(event *domain.Event, width float64) []textLine {
+	regular := func(text string) textLine {
+		return textLine{font: helvetica, size: 9.5, gray: grayText, text: text}
+	}
+	muted := func(text string) textLine {
+		return textLine{font: helvetica, size: 9, gray: grayMuted, text: text}
+	}
+
+	lines := []textLine{
+		muted(timeRange(event) + " · " + categoryLabel(event.Category)),
+		{font: helveticaBold, size: 11.5, gray: grayText, text: event.Title},
+	}
+	if address := eventAddress(event); address != "" {
+		lines = append(lines, regular(address))
+	}
+	for _, detail := range eventDetails(event) {
+		lines = append(lines, regular(detail))
+	}
+	if event.Notes != "" {
+		for _, paragraph := range strings.Split(event.Notes, "\n") {
+			if strings.TrimSpace(paragraph) != "" {
+				lines = append(lines, muted(paragraph))
+			}
+		}
+	}
+
+	var wrapped []textLine
+	for _, line := range lines {
+		wrapped = append(wrapped, wrap(line, width)...)
+	}
+	return wrapped
+}
+
+// timeRange shows local start and end times, naming the zones when the event
+// crosses one, as flights do.
+func timeRange(event *domain.Event) string {
+	start, end := event.LocalStart(), event.LocalEnd()
+	startText, endText := start.Format("15:04"), end.Format("15:04")
+	if event.StartZone() != event.EndZone() {
+		startText += " " + start.Format("MST")
+		endText += " " + end.Format("MST")
+	}
+	if start.YearDay() != end.YearDay() || start.Year() != end.Year() {
+		endText = end.Format("Mon Jan 2 ") + endText
+	}
+	return startText + " – " + endText
+}
+
+func categoryLabel(c domain.EventCategory) string {
+	s := string(c)
+	if s == "" {
+		return ""
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func eventAddress(event *domain.Event) string {
+	address := event.Location
+	if event.Latitude != nil && event.Longitude != nil {
+		coords := fmt.Sprintf("%.5f, %.5f", *event.Latitude, *event.Longitude)
+		if address == "" {
+			return coords
+		}
+		address += " (" + coords + ")"
+	}
+	return address
+}
+
+// eventDetails are the category-specific lines: flight route, terminals,
+// gates and booking references, lodging check-in and check-out, transit route.
+func eventDetails(event *domain.Event) []string {
+	var details []string
+	switch {
+	case event.Flight != nil:
+		fd := event.Flight
+		if flight := strings.TrimSpace(fd.Airline + " " + fd.FlightNumber); flight != "" {
+			details = append(details, "Flight "+flight)
+		}
+		if fd.DepartureAirport != "" || fd.ArrivalAirport != "" {
+			details = append(details, airport(fd.DepartureAirport, fd.DepartureTerminal, fd.DepartureGate)+
+				" → "+airport(fd.ArrivalAirport, fd.ArrivalTerminal, fd.ArrivalGate))
+		}
+		if fd.BookingReference != "" {
+			details = append(details, "Booking reference: "+fd.BookingReference)
+		}
+	case event.Lodging != nil:
+		ld := event.Lodging
+		loc, err := domain.LoadTimeZone(event.TimeZone)
+		if err != nil {
+			loc = time.UTC
+		}
+		if ld.CheckInTime != nil {
+			details = append(details, "Check-in: "+ld.CheckInTime.In(loc).Format("Mon Jan 2, 15:04"))
+		}
+		if ld.CheckOutTime != nil {
+			details = append(details, "Check-out: "+ld.CheckOutTime.In(loc).Format("Mon Jan 2, 15:04"))
+		}
+		if ld.BookingReference != "" {
+			details = append(details, "Booking reference: "+ld.BookingReference)
+		}
+	case event.Transit != nil:
+		td := event.Transit
+		if td.Origin != "" || td.Destination != "" {
+			route := td.Origin + " → " + td.Destination
+			if td.TransportMode != "" {
+				route = td.TransportMode + ": " + route
+			}
+			details = append(details, route)
+		}
+	}
+	return details
+}
+
+func airport(code, terminal, gate string) string {
+	if terminal != "" {
+		code += " terminal " + terminal
+	}
+	if gate != "" {
+		code += " gate " + gate
+	}
+	return code
+}
+
+// mapsURL links to the event's coordinates, or searches for its location when
+// it has none. Events with neither get no link.
+func mapsURL(event *domain.Event) string {
+	var query string
+	switch {
+	case event.Latitude != nil && event.Longitude != nil:
+		query = fmt.Sprintf("%.6f,%.6f", *event.Latitude, *event.Longitude)
+	case strings.TrimSpace(event.Location) != "":
+		query = strings.TrimSpace(event.Location)
+	default:
+		return ""
+	}
+	return mapsSearchURL + url.QueryEscape(query)
+}
+
+// eventQR encodes the event's maps link. Links too long for the supported QR
+// versions are dropped; the address is still printed.
+func eventQR(event *domain.Event) *qrCode {
+	link := mapsURL(event)
+	if link == "" {
+		return nil
+	}
+	code, err := encodeQR([]byte(link))
+	if errors.Is(err, errQRTooLong) {
+		return nil
+	}
+	return code
+}
+
+// wrap breaks a line into lines no wider than width, at spaces where possible.
+func wrap(line textLine, width float64) []textLine {
+	words := strings.Fields(line.text)
+	var lines []textLine
+	current := ""
+	flush := func() {
+		if current != "" {
+			next := line
+			next.text = current
+			lines = append(lines, next)
+			current = ""
+		}
+	}
+	for _, word := range words {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if line.font.width(encodeWinAnsi(candidate), line.size) <= width {
+			current = candidate
+			continue
+		}
+		flush()
+		// Break words longer than a whole line, such as URLs
+		for line.font.width(encodeWinAnsi(word), line.size) > width {
+			cut := len(word)
+			for cut > 1 && line.font.width(encodeWinAnsi(word[:cut]), line.size) > width {
+				cut--
+			}
+			for cut > 1 && !utf8.RuneStart(word[cut]) {
+				cut--
+			}
+			current = word[:cut]
+			flush()
+			word = word[cut:]
+		}
+		current = word
+	}
+	flush()
+	return lines
+}
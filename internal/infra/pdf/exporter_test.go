@@ -0,0 +1,213 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+func TestExporter_ExportPDF(t *testing.T) {
+	lat, lon := 41.9009, 12.4936
+	checkIn := time.Date(2026, 5, 1, 13, 0, 0, 0, time.UTC)
+	checkOut := time.Date(2026, 5, 3, 9, 0, 0, 0, time.UTC)
+	hotel := domain.Event{
+		ID:        1,
+		Title:     "Hotel Artemide",
+		Category:  domain.CategoryLodging,
+		Location:  "Via Nazionale 22, Roma",
+		Latitude:  &lat,
+		Longitude: &lon,
+		TimeZone:  "Europe/Rome",
+		EventDate: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+		StartTime: checkIn,
+		EndTime:   checkOut,
+		Lodging:   &domain.LodgingDetails{CheckInTime: &checkIn, CheckOutTime: &checkOut, BookingReference: "HTL-778"},
+	}
+	flight := domain.Event{
+		ID:        2,
+		Title:     "Flight to New York",
+		Category:  domain.CategoryFlight,
+		TimeZone:  "Europe/Rome",
+		EventDate: time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC),
+		StartTime: time.Date(2026, 5, 3, 8, 15, 0, 0, time.UTC),
+		EndTime:   time.Date(2026, 5, 3, 17, 50, 0, 0, time.UTC),
+		Notes:     "Seat 14A (window)",
+		Flight: &domain.FlightDetails{
+			Airline: "ITA", FlightNumber: "AZ610", DepartureAirport: "FCO", ArrivalAirport: "JFK",
+			DepartureTerminal: "1", DepartureTimeZone: "Europe/Rome", ArrivalTimeZone: "America/New_York",
+			BookingReference: "QX7P2L",
+		},
+	}
+	trip := &domain.Trip{ID: 1, Name: "Roma → New York", Destination: "Italy", TimeZone: "Europe/Rome"}
+
+	// Enough busy days to need several pages
+	var days []domain.ItineraryDay
+	for n := 1; n <= 12; n++ {
+		date := time.Date(2026, 5, n, 0, 0, 0, 0, time.UTC)
+		day := domain.ItineraryDay{Date: date, Number: n}
+		for i := 0; i < 3; i++ {
+			e := flight
+			if n == 1 {
+				e = hotel
+			}
+			e.ID = n*10 + i
+			day.Events = append(day.Events, e)
+		}
+		if n == 2 {
+			day.Stays = []domain.DayStay{{Lodging: &hotel, Kind: domain.StayNight}}
+		}
+		days = append(days, day)
+	}
+	days = append(days, domain.ItineraryDay{Date: time.Date(2026, 5, 13, 0, 0, 0, 0, time.UTC), Number: 13})
+
+	var buf bytes.Buffer
+	if err := NewExporter().ExportPDF(context.Background(), &buf, trip, days); err != nil {
+		t.Fatalf("ExportPDF() error = %v", err)
+	}
+	data := buf.Bytes()
+
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatal("output is not framed as a PDF")
+	}
+	checkXref(t, data)
+
+	text := contentText(t, data)
+	for _, want := range []string{
+		"Roma -> New York",
+		"Day 1 · Friday, May 1",
+		"Day 13 · Wednesday, May 13",
+		"Nothing planned",
+		"Staying at Hotel Artemide, Via Nazionale 22, Roma",
+		"Via Nazionale 22, Roma (41.90090, 12.49360)",
+		"Check-in: Fri May 1, 15:00",
+		"Booking reference: HTL-778",
+		"10:15 CEST – 13:50 EDT · Flight",
+		"Flight ITA AZ610",
+		"FCO terminal 1 -> JFK",
+		"Booking reference: QX7P2L",
+		"Seat 14A (window)",
+		"(continued)",
+		"Page 1 of",
+	} {
+		if !strings.Contains(text, escapeString(encodeWinAnsi(want))) {
+			t.Errorf("PDF text missing %q", want)
+		}
+	}
+	if strings.Count(text, " re\n") == 0 {
+		t.Error("no QR codes drawn")
+	}
+	if strings.Contains(text, "shown as ?") {
+		t.Error("missing glyph note on a Latin-only itinerary")
+	}
+}
+
+func TestExporter_ExportPDF_MissingGlyphs(t *testing.T) {
+	trip := &domain.Trip{ID: 1, Name: "東京 · Tokyo"}
+	days := []domain.ItineraryDay{{
+		Date:   time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		Number: 1,
+		Events: []domain.Event{{ID: 1, Title: "Москва transfer", StartTime: time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)}},
+	}}
+
+	doc := &document{}
+	l := &layout{doc: doc}
+	l.newPage()
+	l.header(trip)
+	l.day(&days[0])
+	if got := string(doc.missingGlyphs()); got != "Мавкос京東" {
+		t.Errorf("missingGlyphs() = %q", got)
+	}
+
+	var buf bytes.Buffer
+	if err := NewExporter().ExportPDF(context.Background(), &buf, trip, days); err != nil {
+		t.Fatalf("ExportPDF() error = %v", err)
+	}
+	text := contentText(t, buf.Bytes())
+	if !strings.Contains(text, escapeString(encodeWinAnsi("?? · Tokyo"))) {
+		t.Error("unrepresentable title not drawn as question marks")
+	}
+	if !strings.Contains(text, "are shown as ?") {
+		t.Error("PDF lacks the note explaining the question marks")
+	}
+}
+
+func TestMapsURL(t *testing.T) {
+	lat, lon := 35.6586, 139.7454
+	tests := []struct {
+		name  string
+		event domain.Event
+		want  string
+	}{
+		{name: "coordinates win", event: domain.Event{Latitude: &lat, Longitude: &lon, Location: "Tokyo Tower"}, want: mapsSearchURL + "35.658600%2C139.745400"},
+		{name: "location", event: domain.Event{Location: " Shibuya Crossing "}, want: mapsSearchURL + "Shibuya+Crossing"},
+		{name: "nowhere", event: domain.Event{}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mapsURL(&tt.event); got != tt.want {
+				t.Errorf("mapsURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	line := textLine{font: helvetica, size: 10, text: "A fairly long line of text that will not fit " + strings.Repeat("x", 80)}
+	lines := wrap(line, 150)
+	if len(lines) < 3 {
+		t.Fatalf("wrap() gave %d lines, want at least 3", len(lines))
+	}
+	for _, l := range lines {
+		if w := helvetica.width(encodeWinAnsi(l.text), 10); w > 150 {
+			t.Errorf("line %q is %.1fpt wide, over 150", l.text, w)
+		}
+	}
+}
+
+// checkXref verifies that every cross-reference entry points at its object.
+func checkXref(t *testing.T, data []byte) {
+	t.Helper()
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	if m == nil {
+		t.Fatal("startxref missing")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(data[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at the xref table", xref)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if want := fmt.Sprintf("%d 0 obj", i+1); !bytes.HasPrefix(data[offset:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q", i+1, data[offset:offset+10])
+		}
+	}
+}
+
+// contentText inflates and concatenates every content stream.
+func contentText(t *testing.T, data []byte) string {
+	t.Helper()
+	var text strings.Builder
+	for _, m := range regexp.MustCompile(`(?s)/Length (\d+) /Filter /FlateDecode >>\nstream\n`).FindAllSubmatchIndex(data, -1) {
+		length, _ := strconv.Atoi(string(data[m[2]:m[3]]))
+		r, err := zlib.NewReader(bytes.NewReader(data[m[1] : m[1]+length]))
+		if err != nil {
+			t.Fatalf("inflating stream: %v", err)
+		}
+		content, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("inflating stream: %v", err)
+		}
+		text.Write(content)
+	}
+	return text.String()
+}
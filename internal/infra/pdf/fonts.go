@@ -0,0 +1,132 @@
+package pdf
+
+import (
+	"strings"
+	"unicode"
+)
+
+// font is one of the standard Type 1 fonts every PDF reader ships, so nothing
+// has to be embedded. Text is encoded as WinAnsi (Windows-1252).
+type font struct {
+	name     string // resource name used in content streams
+	baseFont string
+	widths   [256]int // glyph widths in 1/1000 em, by WinAnsi code
+}
+
+// The AFM widths of the printable ASCII range, space to tilde.
+var (
+	helveticaASCII = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldASCII = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// latinBase maps WinAnsi 0xC0-0xFF to the ASCII letter whose width the
+// accented glyph shares; '-' marks the few with widths of their own.
+const latinBase = "AAAAAA-CEEEEIIIIDNOOOOO-OUUUUY-saaaaaa-ceeeeiiiidnooooo-ouuuuy-y"
+
+var (
+	helvetica     = newFont("F1", "Helvetica", &helveticaASCII, map[byte]int{0xC6: 1000, 0xD7: 584, 0xDE: 667, 0xE6: 889, 0xF7: 584, 0xFE: 556})
+	helveticaBold = newFont("F2", "Helvetica-Bold", &helveticaBoldASCII, map[byte]int{0xC6: 1000, 0xD7: 584, 0xDE: 667, 0xE6: 889, 0xF7: 584, 0xFE: 611})
+)
+
+func newFont(name, baseFont string, ascii *[95]int, own map[byte]int) *font {
+	f := &font{name: name, baseFont: baseFont}
+	for i := range f.widths {
+		f.widths[i] = 556
+	}
+	for i, w := range ascii {
+		f.widths[32+i] = w
+	}
+	for i := 0; i < len(latinBase); i++ {
+		if latinBase[i] != '-' {
+			f.widths[0xC0+i] = f.widths[latinBase[i]]
+		}
+	}
+	for c, w := range own {
+		f.widths[c] = w
+	}
+	for _, c := range []byte{0x85, 0x97, 0x99} { // … — ™
+		f.widths[c] = 1000
+	}
+	for _, c := range []byte{0x91, 0x92, 0xA0} { // ‘ ’ nbsp
+		f.widths[c] = ascii[0]
+	}
+	f.widths[0xB7] = ascii['.'-32] // middle dot
+	return f
+}
+
+// width returns the width of WinAnsi-encoded text in points.
+func (f *font) width(s string, size float64) float64 {
+	total := 0
+	for i := 0; i < len(s); i++ {
+		total += f.widths[s[i]]
+	}
+	return float64(total) * size / 1000
+}
+
+// winAnsiSpecials are the characters WinAnsi places in 0x80-0x9F.
+var winAnsiSpecials = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91,
+	'’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98,
+	'™': 0x99, 'š': 0x9A, '›': 0x9B, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// winAnsiSubstitutes spell out common characters WinAnsi lacks.
+var winAnsiSubstitutes = map[rune]string{
+	'→': "->", '←': "<-", '↔': "<->", '≈': "~", '−': "-",
+}
+
+// encodeWinAnsi converts UTF-8 text to WinAnsi. Whitespace collapses to single
+// spaces and characters the standard fonts cannot show become '?'; see
+// unrepresentable.
+func encodeWinAnsi(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if enc, ok := winAnsi(r); ok {
+			b.WriteString(enc)
+		} else {
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// unrepresentable returns the characters of s that encodeWinAnsi replaces
+// with '?', such as Japanese or Cyrillic text.
+func unrepresentable(s string) []rune {
+	var missing []rune
+	for _, r := range s {
+		if _, ok := winAnsi(r); !ok && !unicode.IsSpace(r) {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+// winAnsi encodes a single character, reporting whether WinAnsi can show it.
+func winAnsi(r rune) (string, bool) {
+	switch {
+	case r >= 0x20 && r < 0x7F, r >= 0xA0 && r <= 0xFF:
+		return string([]byte{byte(r)}), true
+	case winAnsiSpecials[r] != 0:
+		return string([]byte{winAnsiSpecials[r]}), true
+	}
+	sub, ok := winAnsiSubstitutes[r]
+	return sub, ok
+}
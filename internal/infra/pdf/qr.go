@@ -0,0 +1,460 @@
+package pdf
+
+import (
+	"errors"
+)
+
+// errQRTooLong is returned for payloads beyond the largest supported version.
+var errQRTooLong = errors.New("qr: data too long")
+
+// qrVersion describes the error-correction block layout of one QR version at
+// level M, the level used for every code: it survives a crease or a coffee
+// stain on a printed page while keeping map URLs in small versions.
+type qrVersion struct {
+	alignment  []int // centre coordinates of alignment patterns
+	ecPerBlock int
+	blocks1    int // blocks in the first group
+	dataBlock1 int // data codewords per block in the first group
+	blocks2    int
+	dataBlock2 int
+}
+
+// qrVersions holds versions 1 to 10 at level M, indexed by version-1.
+var qrVersions = []qrVersion{
+	{nil, 10, 1, 16, 0, 0},
+	{[]int{6, 18}, 16, 1, 28, 0, 0},
+	{[]int{6, 22}, 26, 1, 44, 0, 0},
+	{[]int{6, 26}, 18, 2, 32, 0, 0},
+	{[]int{6, 30}, 24, 2, 43, 0, 0},
+	{[]int{6, 34}, 16, 4, 27, 0, 0},
+	{[]int{6, 22, 38}, 18, 4, 31, 0, 0},
+	{[]int{6, 24, 42}, 22, 2, 38, 2, 39},
+	{[]int{6, 26, 46}, 22, 3, 36, 2, 37},
+	{[]int{6, 28, 50}, 26, 4, 43, 1, 44},
+}
+
+func (v *qrVersion) dataCodewords() int {
+	return v.blocks1*v.dataBlock1 + v.blocks2*v.dataBlock2
+}
+
+// qrCode is an encoded QR symbol; modules[y][x] is true for dark modules.
+type qrCode struct {
+	modules    [][]bool
+	isFunction [][]bool
+	size       int
+	version    int
+}
+
+// encodeQR encodes data in byte mode at error-correction level M, using the
+// smallest version that fits.
+func encodeQR(data []byte) (*qrCode, error) {
+	version := 0
+	for v := 1; v <= len(qrVersions); v++ {
+		countBits := 8
+		if v >= 10 {
+			countBits = 16
+		}
+		if 4+countBits+8*len(data) <= qrVersions[v-1].dataCodewords()*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, errQRTooLong
+	}
+	info := &qrVersions[version-1]
+
+	codewords := qrDataCodewords(data, version, info.dataCodewords())
+	codewords = qrAddErrorCorrection(codewords, info)
+
+	size := version*4 + 17
+	q := &qrCode{version: version, size: size}
+	q.modules = make([][]bool, size)
+	q.isFunction = make([][]bool, size)
+	for i := range q.modules {
+		q.modules[i] = make([]bool, size)
+		q.isFunction[i] = make([]bool, size)
+	}
+	q.drawFunctionPatterns(info)
+	q.drawCodewords(codewords)
+
+	// Keep the mask with the lowest penalty, as the standard recommends
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormatBits(mask)
+		if p := q.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		q.applyMask(mask) // masking is its own inverse
+	}
+	q.applyMask(best)
+	q.drawFormatBits(best)
+	return q, nil
+}
+
+// qrDataCodewords builds the byte-mode bit stream, padded to capacity.
+func qrDataCodewords(data []byte, version, capacity int) []byte {
+	var bits bitBuffer
+	bits.append(0b0100, 4)
+	if version >= 10 {
+		bits.append(len(data), 16)
+	} else {
+		bits.append(len(data), 8)
+	}
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+	terminator := min(4, capacity*8-len(bits))
+	bits.append(0, terminator)
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity*8; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	out := make([]byte, capacity)
+	for i, bit := range bits {
+		if bit {
+			out[i>>3] |= 1 << (7 - i&7)
+		}
+	}
+	return out
+}
+
+// qrAddErrorCorrection splits data into blocks, appends Reed-Solomon codewords
+// to each and interleaves the result.
+func qrAddErrorCorrection(data []byte, info *qrVersion) []byte {
+	divisor := rsDivisor(info.ecPerBlock)
+	var blocks, ecBlocks [][]byte
+	offset := 0
+	for i := 0; i < info.blocks1+info.blocks2; i++ {
+		n := info.dataBlock1
+		if i >= info.blocks1 {
+			n = info.dataBlock2
+		}
+		block := data[offset : offset+n]
+		offset += n
+		blocks = append(blocks, block)
+		ecBlocks = append(ecBlocks, rsRemainder(block, divisor))
+	}
+
+	var out []byte
+	maxLen := max(info.dataBlock1, info.dataBlock2)
+	for i := 0; i < maxLen; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				out = append(out, block[i])
+			}
+		}
+	}
+	for i := 0; i < info.ecPerBlock; i++ {
+		for _, ec := range ecBlocks {
+			out = append(out, ec[i])
+		}
+	}
+	return out
+}
+
+func (q *qrCode) setFunction(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.isFunction[y][x] = true
+}
+
+func (q *qrCode) drawFunctionPatterns(info *qrVersion) {
+	for i := 0; i < q.size; i++ {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+
+	q.drawFinder(3, 3)
+	q.drawFinder(q.size-4, 3)
+	q.drawFinder(3, q.size-4)
+
+	n := len(info.alignment)
+	for i, cx := range info.alignment {
+		for j, cy := range info.alignment {
+			// Skip the three corners taken by finder patterns
+			if (i == 0 && j == 0) || (i == 0 && j == n-1) || (i == n-1 && j == 0) {
+				continue
+			}
+			q.drawAlignment(cx, cy)
+		}
+	}
+
+	// Reserve the format areas; drawFormatBits fills them in
+	q.drawFormatBits(0)
+	q.drawVersionBits()
+}
+
+// drawFinder draws a finder pattern with its separator around centre (cx, cy).
+func (q *qrCode) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || x >= q.size || y < 0 || y >= q.size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			q.setFunction(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (q *qrCode) drawAlignment(cx, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			q.setFunction(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormatBits writes both copies of the format information: level M and
+// the mask, protected by a BCH code.
+func (q *qrCode) drawFormatBits(mask int) {
+	bits := formatBits(mask)
+
+	for i := 0; i <= 5; i++ {
+		q.setFunction(8, i, bit(bits, i))
+	}
+	q.setFunction(8, 7, bit(bits, 6))
+	q.setFunction(8, 8, bit(bits, 7))
+	q.setFunction(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		q.setFunction(14-i, 8, bit(bits, i))
+	}
+
+	for i := 0; i < 8; i++ {
+		q.setFunction(q.size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunction(8, q.size-15+i, bit(bits, i))
+	}
+	q.setFunction(8, q.size-8, true) // the dark module
+}
+
+// formatBits returns the 15-bit format information for level M and mask.
+func formatBits(mask int) int {
+	const levelM = 0b00
+	data := levelM<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// drawVersionBits writes the version information blocks of versions 7 and up.
+func (q *qrCode) drawVersionBits() {
+	if q.version < 7 {
+		return
+	}
+	bits := versionBits(q.version)
+	for i := 0; i < 18; i++ {
+		a, b := q.size-11+i%3, i/3
+		q.setFunction(a, b, bit(bits, i))
+		q.setFunction(b, a, bit(bits, i))
+	}
+}
+
+// versionBits returns the 18-bit version information, protected by a Golay code.
+func versionBits(version int) int {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	return version<<12 | rem
+}
+
+// drawCodewords places the codewords in the zigzag order of the standard,
+// two columns at a time from the bottom-right corner.
+func (q *qrCode) drawCodewords(codewords []byte) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		for vert := 0; vert < q.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = q.size - 1 - vert
+				}
+				if q.isFunction[y][x] || i >= len(codewords)*8 {
+					continue
+				}
+				q.modules[y][x] = codewords[i>>3]>>(7-i&7)&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+func (q *qrCode) applyMask(mask int) {
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if q.isFunction[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores the symbol with the four rules of the standard: runs of one
+// colour, 2x2 blocks, finder-like patterns and dark/light imbalance.
+func (q *qrCode) penalty() int {
+	score := 0
+	for y := 0; y < q.size; y++ {
+		score += linePenalty(q.size, func(i int) bool { return q.modules[y][i] })
+	}
+	for x := 0; x < q.size; x++ {
+		score += linePenalty(q.size, func(i int) bool { return q.modules[i][x] })
+	}
+
+	dark := 0
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			c := q.modules[y][x]
+			if c {
+				dark++
+			}
+			if x < q.size-1 && y < q.size-1 && c == q.modules[y][x+1] && c == q.modules[y+1][x] && c == q.modules[y+1][x+1] {
+				score += 3
+			}
+		}
+	}
+
+	total := q.size * q.size
+	deviation := abs(dark*20 - total*10)
+	score += ((deviation+total-1)/total - 1) * 10
+	return score
+}
+
+// linePenalty scores one row or column for rules 1 and 3.
+func linePenalty(size int, at func(int) bool) int {
+	score := 0
+	run := 1
+	for i := 1; i <= size; i++ {
+		if i < size && at(i) == at(i-1) {
+			run++
+			continue
+		}
+		if run >= 5 {
+			score += 3 + run - 5
+		}
+		run = 1
+	}
+
+	// 1:1:3:1:1 finder-like runs with four light modules on either side
+	pattern := []bool{true, false, true, true, true, false, true}
+	for i := 0; i+len(pattern) <= size; i++ {
+		match := true
+		for j, want := range pattern {
+			if at(i+j) != want {
+				match = false
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+		if lightRun(at, i-4, i, size) || lightRun(at, i+len(pattern), i+len(pattern)+4, size) {
+			score += 40
+		}
+	}
+	return score
+}
+
+// lightRun reports whether modules from..to-1 are light, counting those
+// outside the symbol as light quiet zone.
+func lightRun(at func(int) bool, from, to, size int) bool {
+	for i := from; i < to; i++ {
+		if i >= 0 && i < size && at(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// rsDivisor returns the Reed-Solomon generator polynomial of the given degree,
+// highest coefficient first and the leading 1 omitted.
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := 0; j < degree; j++ {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < degree {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// rsRemainder returns the error-correction codewords of data.
+func rsRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i := range result {
+			result[i] ^= gfMultiply(divisor[i], factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies in GF(2^8) modulo the QR polynomial 0x11D.
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 == 1)
+	}
+}
+
+func bit(value, i int) bool {
+	return (value>>i)&1 == 1
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
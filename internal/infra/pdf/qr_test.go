@@ -0,0 +1,83 @@
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRSRemainder(t *testing.T) {
+	// "HELLO WORLD" at version 1-M, the worked example of the standard
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	if got := rsRemainder(data, rsDivisor(len(want))); !bytes.Equal(got, want) {
+		t.Errorf("rsRemainder() = %v, want %v", got, want)
+	}
+}
+
+func TestFormatAndVersionBits(t *testing.T) {
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{name: "format M mask 0", got: formatBits(0), want: 0b101010000010010},
+		{name: "format M mask 5", got: formatBits(5), want: 0b100000011001110},
+		{name: "format M mask 7", got: formatBits(7), want: 0b100101010100000},
+		{name: "version 7", got: versionBits(7), want: 0b000111110010010100},
+		{name: "version 10", got: versionBits(10), want: 0b001010010011010011},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %b, want %b", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeQR(t *testing.T) {
+	tests := []struct {
+		name        string
+		length      int
+		wantVersion int
+	}{
+		{name: "fits version 1", length: 14, wantVersion: 1},
+		{name: "just over version 1", length: 15, wantVersion: 2},
+		{name: "coordinates link", length: len(mapsSearchURL + "41.900900%2C12.493600"), wantVersion: 5},
+		{name: "largest supported", length: 213, wantVersion: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := encodeQR([]byte(strings.Repeat("a", tt.length)))
+			if err != nil {
+				t.Fatalf("encodeQR() error = %v", err)
+			}
+			if q.version != tt.wantVersion {
+				t.Errorf("version = %d, want %d", q.version, tt.wantVersion)
+			}
+			if q.size != 17+4*tt.wantVersion {
+				t.Errorf("size = %d, want %d", q.size, 17+4*tt.wantVersion)
+			}
+
+			// Finder pattern corners: dark ring, light separator outside it
+			for _, corner := range [][2]int{{0, 0}, {q.size - 7, 0}, {0, q.size - 7}} {
+				x, y := corner[0], corner[1]
+				if !q.modules[y][x] || !q.modules[y+6][x+6] || q.modules[y+1][x+1] || !q.modules[y+3][x+3] {
+					t.Errorf("finder pattern at (%d, %d) malformed", x, y)
+				}
+			}
+			if !q.modules[q.size-8][8] {
+				t.Error("dark module missing")
+			}
+		})
+	}
+
+	t.Run("too long", func(t *testing.T) {
+		if _, err := encodeQR([]byte(strings.Repeat("a", 214))); !errors.Is(err, errQRTooLong) {
+			t.Errorf("encodeQR() error = %v, want errQRTooLong", err)
+		}
+	})
+}
@@ -0,0 +1,305 @@
+// Package textexport writes a trip's itinerary as Markdown or plain text, to
+// paste into notes, chats and wikis.
+package textexport
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+var _ domain.ItineraryExporter = (*Exporter)(nil)
+
+// Exporter writes itineraries as text.
+type Exporter struct{}
+
+func NewExporter() *Exporter {
+	return &Exporter{}
+}
+
+// ExportMarkdown writes the itinerary as Markdown, for pasting into wikis and
+// chats.
+func (e *Exporter) ExportMarkdown(w io.Writer, trip *domain.Trip, days []domain.ItineraryDay) error {
+	return writeItinerary(w, trip, days, true)
+}
+
+// ExportText writes the itinerary as plain text.
+func (e *Exporter) ExportText(w io.Writer, trip *domain.Trip, days []domain.ItineraryDay) error {
+	return writeItinerary(w, trip, days, false)
+}
+
+// writeItinerary writes the trip day by day as the detail page shows it: the
+// day's stays, then each event with its time, location, notes and the same
+// category details as the expanded event cards.
+func writeItinerary(w io.Writer, trip *domain.Trip, days []domain.ItineraryDay, markdown bool) error {
+	it := &itineraryWriter{markdown: markdown}
+
+	summary := trip.StartDate.Format("Jan 2") + " – " + trip.EndDate.Format("Jan 2, 2006")
+	if trip.Destination != "" {
+		summary = it.escape(trip.Destination) + " · " + summary
+	}
+	it.heading(1, it.escape(trip.Name))
+	it.line(summary)
+
+	for i := range days {
+		day := &days[i]
+		it.blank()
+		it.heading(2, fmt.Sprintf("Day %d · %s", day.Number, day.Date.Format("Monday, January 2")))
+		for _, stay := range day.Stays {
+			it.item(0, it.emphasis(it.stay(stay)))
+		}
+		if len(day.Events) == 0 && len(day.Stays) == 0 {
+			it.item(0, it.emphasis("Nothing planned"))
+		}
+		for j := range day.Events {
+			it.event(&day.Events[j])
+		}
+	}
+
+	_, err := io.WriteString(w, it.b.String())
+	return err
+}
+
+// itineraryWriter builds the itinerary as Markdown or, when markdown is false,
+// as plain text with underlined headings and no inline markup.
+type itineraryWriter struct {
+	b        strings.Builder
+	markdown bool
+}
+
+func (it *itineraryWriter) line(s string) {
+	it.b.WriteString(s)
+	it.b.WriteByte('\n')
+}
+
+func (it *itineraryWriter) blank() {
+	it.b.WriteByte('\n')
+}
+
+func (it *itineraryWriter) heading(level int, text string) {
+	if it.markdown {
+		it.line(strings.Repeat("#", level) + " " + text)
+		it.blank()
+		return
+	}
+	underline := "="
+	if level > 1 {
+		underline = "-"
+	}
+	it.line(text)
+	it.line(strings.Repeat(underline, len([]rune(text))))
+	it.blank()
+}
+
+func (it *itineraryWriter) item(depth int, text string) {
+	it.line(strings.Repeat("  ", depth) + "- " + text)
+}
+
+func (it *itineraryWriter) strong(s string) string {
+	if it.markdown {
+		return "**" + s + "**"
+	}
+	return s
+}
+
+func (it *itineraryWriter) emphasis(s string) string {
+	if it.markdown {
+		return "_" + s + "_"
+	}
+	return s
+}
+
+func (it *itineraryWriter) code(s string) string {
+	if it.markdown {
+		return "`" + strings.ReplaceAll(s, "`", "'") + "`"
+	}
+	return s
+}
+
+// markdownEscaper escapes the characters that would otherwise turn user text
+// into markup.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`, "#", `\#`, "|", `\|`,
+)
+
+func (it *itineraryWriter) escape(s string) string {
+	if it.markdown {
+		return markdownEscaper.Replace(s)
+	}
+	return s
+}
+
+func (it *itineraryWriter) stay(stay domain.DayStay) string {
+	lodging := stay.Lodging
+	switch stay.Kind {
+	case domain.StayCheckIn:
+		text := "Check-in: " + it.escape(lodging.Title)
+		if lodging.Lodging.CheckInTime != nil {
+			text += ", " + lodging.Lodging.CheckInTime.In(domain.ZoneOrUTC(lodging.TimeZone)).Format("3:04 PM")
+		}
+		return text
+	case domain.StayCheckOut:
+		return "Check-out: " + it.escape(lodging.Title) + ", " +
+			lodging.Lodging.CheckOutTime.In(domain.ZoneOrUTC(lodging.TimeZone)).Format("3:04 PM")
+	default:
+		return "Staying at " + it.escape(lodging.Title)
+	}
+}
+
+func (it *itineraryWriter) event(event *domain.Event) {
+	summary := it.strong(eventTimeRange(*event)) + " " + it.escape(event.Title)
+	if event.Location != "" {
+		summary += " · " + it.escape(event.Location)
+	}
+	it.item(0, summary)
+	for _, detail := range it.details(event) {
+		it.item(1, detail)
+	}
+	if event.Notes != "" {
+		// Keep multi-line notes inside their list item
+		notes := strings.Split(strings.TrimSpace(event.Notes), "\n")
+		for i := range notes {
+			notes[i] = it.escape(strings.TrimSpace(notes[i]))
+		}
+		it.item(1, strings.Join(notes, "\n    "))
+	}
+}
+
+// details mirrors the flight, lodging and transit sections of the expanded
+// event cards.
+func (it *itineraryWriter) details(event *domain.Event) []string {
+	var details []string
+	switch {
+	case event.Category == domain.CategoryFlight && event.Flight != nil:
+		fd := event.Flight
+		route := it.code(fd.DepartureAirport) + " → " + it.code(fd.ArrivalAirport)
+		if fd.FlightNumber != "" {
+			route += " · " + it.escape(strings.TrimSpace(fd.Airline+" "+fd.FlightNumber))
+		}
+		details = append(details, route)
+		layovers := fd.Layovers()
+		for i := range fd.Segments {
+			seg := &fd.Segments[i]
+			leg := it.code(seg.DepartureAirport) + " → " + it.code(seg.ArrivalAirport)
+			if seg.FlightNumber != "" {
+				leg += " · " + it.escape(strings.TrimSpace(seg.Airline+" "+seg.FlightNumber))
+			}
+			details = append(details, leg+" · "+segmentTimes(seg, event.TimeZone))
+			if i < len(layovers) {
+				details = append(details, "Layover in "+it.escape(layoverPlace(&layovers[i]))+" · "+formatDuration(layovers[i].Duration))
+			}
+		}
+		if where := terminalAndGate(fd.DepartureTerminal, fd.DepartureGate); where != "" {
+			details = append(details, "Dep: "+it.escape(where))
+		}
+		if where := terminalAndGate(fd.ArrivalTerminal, fd.ArrivalGate); where != "" {
+			details = append(details, "Arr: "+it.escape(where))
+		}
+		if fd.BookingReference != "" {
+			details = append(details, "Ref: "+it.code(fd.BookingReference))
+		}
+	case event.Category == domain.CategoryLodging && event.Lodging != nil:
+		ld := event.Lodging
+		var times []string
+		if ld.CheckInTime != nil {
+			times = append(times, "Check-in: "+ld.CheckInTime.In(domain.ZoneOrUTC(event.TimeZone)).Format("Mon 2 Jan, 15:04"))
+		}
+		if ld.CheckOutTime != nil {
+			times = append(times, "Check-out: "+ld.CheckOutTime.In(domain.ZoneOrUTC(event.TimeZone)).Format("Mon 2 Jan, 15:04"))
+		}
+		if len(times) > 0 {
+			details = append(details, strings.Join(times, " · "))
+		}
+		if ld.BookingReference != "" {
+			details = append(details, "Ref: "+it.code(ld.BookingReference))
+		}
+	case event.Category == domain.CategoryTransit && event.Transit != nil:
+		td := event.Transit
+		var stops []string
+		for _, stop := range []string{td.Origin, td.Destination} {
+			if stop != "" {
+				stops = append(stops, it.escape(stop))
+			}
+		}
+		if len(stops) > 0 {
+			details = append(details, strings.Join(stops, " → "))
+		}
+		if td.TransportMode != "" {
+			details = append(details, "Mode: "+it.escape(td.TransportMode))
+		}
+	}
+	return details
+}
+
+func terminalAndGate(terminal, gate string) string {
+	var parts []string
+	if terminal != "" {
+		parts = append(parts, "Terminal "+terminal)
+	}
+	if gate != "" {
+		parts = append(parts, "Gate "+gate)
+	}
+	return strings.Join(parts, " ")
+}
+
+// eventTimeRange formats the event's local times, naming the zones when it
+// starts and ends in different ones.
+func eventTimeRange(event domain.Event) string {
+	start, end := event.LocalStart(), event.LocalEnd()
+	if event.StartZone() == event.EndZone() {
+		return start.Format("3:04 PM") + " – " + end.Format("3:04 PM")
+	}
+	return start.Format("3:04 PM MST") + " – " + end.Format("3:04 PM MST") + dayOffset(start, end)
+}
+
+// segmentTimes formats a segment's local departure and arrival times, zones
+// falling back to the event's zone.
+func segmentTimes(seg *domain.FlightSegment, zone string) string {
+	depZone, arrZone := seg.DepartureTimeZone, seg.ArrivalTimeZone
+	if depZone == "" {
+		depZone = zone
+	}
+	if arrZone == "" {
+		arrZone = zone
+	}
+	dep := seg.DepartureTime.In(domain.ZoneOrUTC(depZone))
+	arr := seg.ArrivalTime.In(domain.ZoneOrUTC(arrZone))
+	return dep.Format("3:04 PM") + " – " + arr.Format("3:04 PM") + dayOffset(dep, arr)
+}
+
+// dayOffset returns " +1" style suffixes when end falls on a later local date than start.
+func dayOffset(start, end time.Time) string {
+	startDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	endDay := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(endDay.Sub(startDay).Hours() / 24)
+	if days <= 0 {
+		return ""
+	}
+	return " +" + strconv.Itoa(days)
+}
+
+// layoverPlace names where a layover is spent, both airports when it means
+// changing airport.
+func layoverPlace(l *domain.Layover) string {
+	if l.ArrivalAirport == l.DepartureAirport {
+		return l.ArrivalAirport
+	}
+	return l.ArrivalAirport + " → " + l.DepartureAirport
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < time.Hour {
+		return fmt.Sprintf("%d min", int(d.Minutes()))
+	}
+	h := int(d.Hours())
+	m := int(d.Minutes()) - h*60
+	if m == 0 {
+		return fmt.Sprintf("%dh", h)
+	}
+	return fmt.Sprintf("%dh %dm", h, m)
+}
@@ -0,0 +1,103 @@
+package textexport
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+func itineraryTestDays() (*domain.Trip, []domain.ItineraryDay) {
+	checkIn := time.Date(2026, 5, 1, 13, 0, 0, 0, time.UTC)
+	checkOut := time.Date(2026, 5, 3, 9, 0, 0, 0, time.UTC)
+	hotel := domain.Event{
+		Title: "Hotel *Artemide*", Category: domain.CategoryLodging, Location: "Via Nazionale 22", TimeZone: "Europe/Rome",
+		StartTime: checkIn, EndTime: checkIn.Add(time.Hour),
+		Lodging: &domain.LodgingDetails{CheckInTime: &checkIn, CheckOutTime: &checkOut, BookingReference: "HTL-778"},
+	}
+	flight := domain.Event{
+		Title: "Flight to Rome", Category: domain.CategoryFlight, TimeZone: "Europe/London", Notes: "Seat 14A\nwindow",
+		StartTime: time.Date(2026, 5, 1, 7, 0, 0, 0, time.UTC), EndTime: time.Date(2026, 5, 1, 9, 30, 0, 0, time.UTC),
+		Flight: &domain.FlightDetails{
+			Airline: "BA", FlightNumber: "BA548", DepartureAirport: "LHR", ArrivalAirport: "FCO",
+			DepartureTerminal: "5", DepartureGate: "B32", DepartureTimeZone: "Europe/London", ArrivalTimeZone: "Europe/Rome",
+			BookingReference: "QX7P2L",
+		},
+	}
+	train := domain.Event{
+		Title: "Train to Florence", Category: domain.CategoryTransit, TimeZone: "Europe/Rome",
+		StartTime: time.Date(2026, 5, 3, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2026, 5, 3, 11, 30, 0, 0, time.UTC),
+		Transit: &domain.TransitDetails{Origin: "Roma Termini", Destination: "Firenze SMN", TransportMode: "train"},
+	}
+	trip := &domain.Trip{
+		ID: 1, Name: "Rome & Florence", Destination: "Italy", TimeZone: "Europe/Rome",
+		StartDate: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC),
+	}
+	return trip, []domain.ItineraryDay{
+		{Date: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), Number: 1, Events: []domain.Event{flight, hotel}},
+		{Date: time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC), Number: 2, Stays: []domain.DayStay{{Lodging: &hotel, Kind: domain.StayNight}}},
+		{
+			Date: time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC), Number: 3, Events: []domain.Event{train},
+			Stays: []domain.DayStay{{Lodging: &hotel, Kind: domain.StayCheckOut}},
+		},
+		{Date: time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC), Number: 4},
+	}
+}
+
+func TestWriteItinerary(t *testing.T) {
+	tests := []struct {
+		name     string
+		want     []string
+		markdown bool
+	}{
+		{
+			name:     "markdown",
+			markdown: true,
+			want: []string{
+				"# Rome & Florence\n\nItaly · May 1 – May 4, 2026\n",
+				"## Day 1 · Friday, May 1\n",
+				"- **8:00 AM BST – 11:30 AM CEST** Flight to Rome\n",
+				"  - `LHR` → `FCO` · BA BA548\n",
+				"  - Dep: Terminal 5 Gate B32\n",
+				"  - Ref: `QX7P2L`\n",
+				"  - Seat 14A\n    window\n",
+				"- **3:00 PM – 4:00 PM** Hotel \\*Artemide\\* · Via Nazionale 22\n",
+				"  - Check-in: Fri 1 May, 15:00 · Check-out: Sun 3 May, 11:00\n",
+				"- _Staying at Hotel \\*Artemide\\*_\n",
+				"- _Check-out: Hotel \\*Artemide\\*, 11:00 AM_\n",
+				"  - Roma Termini → Firenze SMN\n  - Mode: train\n",
+				"## Day 4 · Monday, May 4\n\n- _Nothing planned_\n",
+			},
+		},
+		{
+			name: "plain text",
+			want: []string{
+				"Rome & Florence\n===============\n\nItaly · May 1 – May 4, 2026\n",
+				"Day 1 · Friday, May 1\n---------------------\n",
+				"- 8:00 AM BST – 11:30 AM CEST Flight to Rome\n",
+				"  - LHR → FCO · BA BA548\n",
+				"  - Ref: QX7P2L\n",
+				"- Staying at Hotel *Artemide*\n",
+				"- Nothing planned\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trip, days := itineraryTestDays()
+			var b strings.Builder
+			if err := writeItinerary(&b, trip, days, tt.markdown); err != nil {
+				t.Fatalf("writeItinerary() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(b.String(), want) {
+					t.Errorf("itinerary missing %q\n%s", want, b.String())
+				}
+			}
+			if !tt.markdown && strings.ContainsAny(b.String(), "`#") {
+				t.Errorf("plain text has markup:\n%s", b.String())
+			}
+		})
+	}
+}
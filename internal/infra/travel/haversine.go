@@ -0,0 +1,70 @@
+// Package travel provides offline travel-time estimation between coordinates.
+package travel
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+var _ domain.TravelTimeEstimator = (*HaversineEstimator)(nil)
+
+const earthRadiusKm = 6371.0
+
+// Speed describes how fast a travel mode covers ground, plus a fixed overhead
+// for getting started (waiting for a bus, finding parking).
+type Speed struct {
+	Overhead time.Duration
+	KPH      float64
+}
+
+// DefaultSpeeds are conservative city speeds for each travel mode.
+var DefaultSpeeds = map[domain.TravelMode]Speed{
+	domain.TravelWalking: {KPH: 4.5},
+	domain.TravelTransit: {KPH: 20, Overhead: 10 * time.Minute},
+	domain.TravelDriving: {KPH: 30, Overhead: 5 * time.Minute},
+}
+
+// detourFactor stretches the straight-line distance to approximate real routes.
+const detourFactor = 1.3
+
+// HaversineEstimator estimates travel time from the great-circle distance between
+// two points and a per-mode speed. It needs no network access.
+type HaversineEstimator struct {
+	speeds map[domain.TravelMode]Speed
+}
+
+// NewHaversineEstimator returns an estimator using speeds, or DefaultSpeeds when nil.
+func NewHaversineEstimator(speeds map[domain.TravelMode]Speed) *HaversineEstimator {
+	if speeds == nil {
+		speeds = DefaultSpeeds
+	}
+	return &HaversineEstimator{speeds: speeds}
+}
+
+func (e *HaversineEstimator) Estimate(_ context.Context, from, to domain.Coordinates, mode domain.TravelMode) (time.Duration, error) {
+	speed, ok := e.speeds[mode]
+	if !ok || speed.KPH <= 0 {
+		return 0, fmt.Errorf("%w: unsupported travel mode %q", domain.ErrInvalidInput, mode)
+	}
+	km := DistanceKm(from, to) * detourFactor
+	if km == 0 {
+		return 0, nil
+	}
+	hours := km / speed.KPH
+	return speed.Overhead + time.Duration(hours*float64(time.Hour)).Round(time.Minute), nil
+}
+
+// DistanceKm returns the great-circle distance between two points in kilometres.
+func DistanceKm(from, to domain.Coordinates) float64 {
+	lat1 := from.Latitude * math.Pi / 180
+	lat2 := to.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (to.Longitude - from.Longitude) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
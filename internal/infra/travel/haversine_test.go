@@ -0,0 +1,82 @@
+package travel
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+func TestDistanceKm(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to domain.Coordinates
+		want     float64
+	}{
+		{
+			name: "same point",
+			from: domain.Coordinates{Latitude: 41.8902, Longitude: 12.4922},
+			to:   domain.Coordinates{Latitude: 41.8902, Longitude: 12.4922},
+			want: 0,
+		},
+		{
+			name: "Paris to London",
+			from: domain.Coordinates{Latitude: 48.8566, Longitude: 2.3522},
+			to:   domain.Coordinates{Latitude: 51.5074, Longitude: -0.1278},
+			want: 343.6,
+		},
+		{
+			name: "across the antimeridian",
+			from: domain.Coordinates{Latitude: 0, Longitude: 179.5},
+			to:   domain.Coordinates{Latitude: 0, Longitude: -179.5},
+			want: 111.2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DistanceKm(tt.from, tt.to)
+			if math.Abs(got-tt.want) > 0.5 {
+				t.Errorf("DistanceKm() = %.1f, want %.1f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHaversineEstimator_Estimate(t *testing.T) {
+	colosseum := domain.Coordinates{Latitude: 41.8902, Longitude: 12.4922}
+	pantheon := domain.Coordinates{Latitude: 41.8986, Longitude: 12.4769}
+
+	tests := []struct {
+		wantErr error
+		name    string
+		mode    domain.TravelMode
+		want    time.Duration
+	}{
+		{name: "walking", mode: domain.TravelWalking, want: 27 * time.Minute},
+		{name: "transit includes overhead", mode: domain.TravelTransit, want: 16 * time.Minute},
+		{name: "unknown mode", mode: "teleport", wantErr: domain.ErrInvalidInput},
+	}
+
+	e := NewHaversineEstimator(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.Estimate(context.Background(), colosseum, pantheon, tt.mode)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Estimate() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Estimate() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Estimate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/repository/sqlcgen"
+)
+
+var _ domain.CalendarFeedRepository = (*CalendarFeedStore)(nil)
+
+type CalendarFeedStore struct {
+	queries *sqlcgen.Queries
+}
+
+func NewCalendarFeedStore(db *pgxpool.Pool) *CalendarFeedStore {
+	return &CalendarFeedStore{
+		queries: sqlcgen.New(db),
+	}
+}
+
+func (s *CalendarFeedStore) Create(ctx context.Context, feed *domain.CalendarFeed) error {
+	row, err := s.queries.CreateCalendarFeed(ctx, sqlcgen.CreateCalendarFeedParams{
+		TripID: int32(feed.TripID),
+		Token:  feed.Token,
+	})
+	if err != nil {
+		return err
+	}
+	*feed = calendarFeedRowToDomain(&row)
+	return nil
+}
+
+func (s *CalendarFeedStore) GetByToken(ctx context.Context, token string) (*domain.CalendarFeed, error) {
+	row, err := s.queries.GetCalendarFeedByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	feed := calendarFeedRowToDomain(&row)
+	return &feed, nil
+}
+
+func (s *CalendarFeedStore) ListByTrip(ctx context.Context, tripID int) ([]domain.CalendarFeed, error) {
+	rows, err := s.queries.ListCalendarFeedsByTrip(ctx, int32(tripID))
+	if err != nil {
+		return nil, err
+	}
+
+	feeds := make([]domain.CalendarFeed, len(rows))
+	for i := range rows {
+		feeds[i] = calendarFeedRowToDomain(&rows[i])
+	}
+	return feeds, nil
+}
+
+func (s *CalendarFeedStore) Delete(ctx context.Context, tripID, id int) error {
+	rows, err := s.queries.DeleteCalendarFeed(ctx, sqlcgen.DeleteCalendarFeedParams{
+		ID:     int32(id),
+		TripID: int32(tripID),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// TripLastModified relies on soft deletes and restores bumping updated_at, so
+// removing an event moves the timestamp forward like any other change.
+func (s *CalendarFeedStore) TripLastModified(ctx context.Context, tripID int) (time.Time, error) {
+	ts, err := s.queries.GetTripLastModified(ctx, int32(tripID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return time.Time{}, domain.ErrNotFound
+		}
+		return time.Time{}, err
+	}
+	return ts.Time, nil
+}
+
+func calendarFeedRowToDomain(row *sqlcgen.CalendarFeed) domain.CalendarFeed {
+	return domain.CalendarFeed{
+		ID:        int(row.ID),
+		TripID:    int(row.TripID),
+		Token:     row.Token,
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
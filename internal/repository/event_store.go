@@ -42,7 +42,7 @@ func (s *EventStore) Create(ctx context.Context, event *domain.Event) error {
 	if err != nil {
 		return err
 	}
-	position := maxPos + 1000
+	position := maxPos + positionGap
 	if event.Position > 0 {
 		position = int32(event.Position)
 	}
@@ -149,6 +149,7 @@ func toCreateEventParams(event *domain.Event, position int32) sqlcgen.CreateEven
 		Pinned:    toPgBool(event.Pinned),
 		Position:  position,
 		Notes:     toPgText(event.Notes),
+		TimeZone:  event.TimeZone,
 	}
 }
 
@@ -160,22 +161,40 @@ func (s *EventStore) GetByID(ctx context.Context, id int) (*domain.Event, error)
 		}
 		return nil, err
 	}
-	event := eventRowToDomain(&row)
-	if event.Category == domain.CategoryFlight {
-		events := s.loadFlightDetails(ctx, []domain.Event{event})
-		event = events[0]
-	}
-	if event.Category == domain.CategoryLodging {
-		events := s.loadLodgingDetails(ctx, []domain.Event{event})
-		event = events[0]
-	}
-	if event.Category == domain.CategoryTransit {
-		events := s.loadTransitDetails(ctx, []domain.Event{event})
-		event = events[0]
+	event := s.loadDetails(ctx, eventRowToDomain(&row))
+	return &event, nil
+}
+
+// GetByTripAndID returns the event only if it belongs to tripID, so a request
+// naming one trip cannot reach another trip's events.
+func (s *EventStore) GetByTripAndID(ctx context.Context, tripID, id int) (*domain.Event, error) {
+	row, err := s.queries.GetEventByTripAndID(ctx, sqlcgen.GetEventByTripAndIDParams{
+		ID:     int32(id),
+		TripID: int32(tripID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
 	}
+	event := s.loadDetails(ctx, eventRowToDomain(&row))
 	return &event, nil
 }
 
+// loadDetails enriches a single event with its category-specific detail row.
+func (s *EventStore) loadDetails(ctx context.Context, event domain.Event) domain.Event {
+	switch event.Category {
+	case domain.CategoryFlight:
+		return s.loadFlightDetails(ctx, []domain.Event{event})[0]
+	case domain.CategoryLodging:
+		return s.loadLodgingDetails(ctx, []domain.Event{event})[0]
+	case domain.CategoryTransit:
+		return s.loadTransitDetails(ctx, []domain.Event{event})[0]
+	}
+	return event
+}
+
 func (s *EventStore) ListByTrip(ctx context.Context, tripID int) ([]domain.Event, error) {
 	rows, err := s.queries.ListEventsByTrip(ctx, int32(tripID))
 	if err != nil {
@@ -319,6 +338,7 @@ func toUpdateEventParams(id int32, event *domain.Event) sqlcgen.UpdateEventParam
 		Position:  int32(event.Position),
 		EventDate: toPgDate(event.EventDate),
 		Notes:     toPgText(event.Notes),
+		TimeZone:  event.TimeZone,
 	}
 }
 
@@ -328,8 +348,11 @@ func (s *EventStore) Delete(ctx context.Context, id int) error {
 	return s.queries.SoftDeleteEvent(ctx, int32(id))
 }
 
-func (s *EventStore) Restore(ctx context.Context, id int) (*domain.Event, error) {
-	row, err := s.queries.RestoreEvent(ctx, int32(id))
+func (s *EventStore) Restore(ctx context.Context, tripID, id int) (*domain.Event, error) {
+	row, err := s.queries.RestoreEvent(ctx, sqlcgen.RestoreEventParams{
+		ID:     int32(id),
+		TripID: int32(tripID),
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -340,6 +363,132 @@ func (s *EventStore) Restore(ctx context.Context, id int) (*domain.Event, error)
 	return &event, nil
 }
 
+// Reorder rewrites the positions of a day's events so they follow orderedIDs.
+// orderedIDs must list every active event of the day exactly once; the whole
+// operation runs in a single transaction so a failed reorder leaves positions intact.
+func (s *EventStore) Reorder(ctx context.Context, tripID int, date time.Time, orderedIDs []int) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	txq := sqlcgen.New(tx)
+	rows, err := txq.ListEventPositionsForUpdate(ctx, sqlcgen.ListEventPositionsForUpdateParams{
+		TripID:    int32(tripID),
+		EventDate: toPgDate(date),
+	})
+	if err != nil {
+		return fmt.Errorf("locking day positions: %w", err)
+	}
+	if len(rows) != len(orderedIDs) {
+		return fmt.Errorf("%w: day has %d events, order lists %d", domain.ErrConflict, len(rows), len(orderedIDs))
+	}
+
+	currentByID := make(map[int]int, len(rows))
+	for _, row := range rows {
+		currentByID[int(row.ID)] = int(row.Position)
+	}
+	current := make([]int, len(orderedIDs))
+	for i, id := range orderedIDs {
+		pos, ok := currentByID[id]
+		if !ok {
+			return fmt.Errorf("%w: event %d is not on this day", domain.ErrConflict, id)
+		}
+		current[i] = pos
+	}
+
+	planned := planPositions(current)
+	for i, id := range orderedIDs {
+		if planned[i] == current[i] {
+			continue
+		}
+		if err := txq.UpdateEventPosition(ctx, sqlcgen.UpdateEventPositionParams{
+			ID:       int32(id),
+			Position: int32(planned[i]),
+		}); err != nil {
+			return fmt.Errorf("updating position of event %d: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
+
+// MoveToDay moves an event to eventDate with the given times and appends it after
+// the last event of that day. Positions in the source day are left as-is.
+func (s *EventStore) MoveToDay(ctx context.Context, id int, eventDate, startTime, endTime time.Time) (*domain.Event, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	txq := sqlcgen.New(tx)
+	current, err := txq.GetEventByID(ctx, int32(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("fetching event %d: %w", id, err)
+	}
+
+	maxPos, err := txq.GetMaxPositionByTripAndDate(ctx, sqlcgen.GetMaxPositionByTripAndDateParams{
+		TripID:    current.TripID,
+		EventDate: toPgDate(eventDate),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading max position: %w", err)
+	}
+
+	if _, err = txq.MoveEventToDay(ctx, sqlcgen.MoveEventToDayParams{
+		ID:        int32(id),
+		EventDate: toPgDate(eventDate),
+		StartTime: toPgTimestamptz(startTime),
+		EndTime:   toPgTimestamptz(endTime),
+		Position:  maxPos + positionGap,
+	}); err != nil {
+		return nil, fmt.Errorf("moving event %d: %w", id, err)
+	}
+
+	// A lodging stay moves with its event so every night of it follows, and a
+	// connecting flight so its segments stay within the journey
+	days := int(eventDate.Sub(current.EventDate.Time).Hours() / 24)
+	loc := domain.ZoneOrUTC(current.TimeZone)
+	switch domain.EventCategory(current.Category) {
+	case domain.CategoryLodging:
+		if err = s.lodging.ShiftDays(ctx, txq, id, days, loc); err != nil {
+			return nil, fmt.Errorf("moving stay of event %d: %w", id, err)
+		}
+	case domain.CategoryFlight:
+		if err = s.flight.ShiftDays(ctx, txq, id, days, loc); err != nil {
+			return nil, fmt.Errorf("moving segments of event %d: %w", id, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return s.GetByID(ctx, id)
+}
+
+// ListLodgingByTrip returns the trip's lodging events with their details, in
+// start time order. Stays span several days, so they are listed trip-wide.
+func (s *EventStore) ListLodgingByTrip(ctx context.Context, tripID int) ([]domain.Event, error) {
+	rows, err := s.queries.ListLodgingEventsByTrip(ctx, int32(tripID))
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]domain.Event, len(rows))
+	for i := range rows {
+		events[i] = eventRowToDomain(&rows[i])
+	}
+	return s.loadLodgingDetails(ctx, events), nil
+}
+
 func (s *EventStore) GetLastEventByTrip(ctx context.Context, tripID int) (*domain.Event, error) {
 	row, err := s.queries.GetLastEventByTrip(ctx, int32(tripID))
 	if err != nil {
@@ -360,6 +509,27 @@ func (s *EventStore) CountByTrip(ctx context.Context, tripID int) (int, error) {
 	return int(count), nil
 }
 
+// ListOutsideTripRange returns every live event dated outside its trip's range.
+func (s *EventStore) ListOutsideTripRange(ctx context.Context) ([]domain.OutOfRangeEvent, error) {
+	rows, err := s.queries.ListEventsOutsideTripRange(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.OutOfRangeEvent, len(rows))
+	for i := range rows {
+		result[i] = domain.OutOfRangeEvent{
+			EventID:       int(rows[i].ID),
+			TripID:        int(rows[i].TripID),
+			EventDate:     rows[i].EventDate.Time,
+			Title:         rows[i].Title,
+			TripName:      rows[i].TripName,
+			TripStartDate: rows[i].TripStartDate.Time,
+			TripEndDate:   rows[i].TripEndDate.Time,
+		}
+	}
+	return result, nil
+}
+
 // loadFlightDetails enriches flight events with their detail row.
 // No-op for non-flight events. Errors are logged but not fatal.
 func (s *EventStore) loadFlightDetails(ctx context.Context, events []domain.Event) []domain.Event {
@@ -463,6 +633,7 @@ func eventRowToDomain(row *sqlcgen.Event) domain.Event {
 		Pinned:    row.Pinned.Bool,
 		Position:  int(row.Position),
 		Notes:     row.Notes.String,
+		TimeZone:  row.TimeZone,
 		CreatedAt: row.CreatedAt.Time,
 		UpdatedAt: row.UpdatedAt.Time,
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 
@@ -30,11 +31,16 @@ func (s *FlightDetailsStore) Create(ctx context.Context, q *sqlcgen.Queries, eve
 		DepartureGate:     toPgText(fd.DepartureGate),
 		ArrivalGate:       toPgText(fd.ArrivalGate),
 		BookingReference:  toPgText(fd.BookingReference),
+		DepartureTimeZone: toPgText(fd.DepartureTimeZone),
+		ArrivalTimeZone:   toPgText(fd.ArrivalTimeZone),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("inserting flight_details for event %d: %w", eventID, err)
 	}
 	result := flightRowToDomain(&row)
+	if result.Segments, err = s.createSegments(ctx, q, eventID, fd.Segments); err != nil {
+		return nil, err
+	}
 	return &result, nil
 }
 
@@ -48,6 +54,11 @@ func (s *FlightDetailsStore) GetByEventID(ctx context.Context, q *sqlcgen.Querie
 		return nil, fmt.Errorf("fetching flight_details for event %d: %w", eventID, err)
 	}
 	result := flightRowToDomain(&row)
+	segments, err := s.listSegments(ctx, q, []int32{int32(eventID)})
+	if err != nil {
+		return nil, err
+	}
+	result.Segments = segments[eventID]
 	return &result, nil
 }
 
@@ -64,15 +75,22 @@ func (s *FlightDetailsStore) GetByEventIDs(ctx context.Context, q *sqlcgen.Queri
 	if err != nil {
 		return nil, fmt.Errorf("fetching flight_details by ids: %w", err)
 	}
+	segments, err := s.listSegments(ctx, q, ids)
+	if err != nil {
+		return nil, err
+	}
 	results := make(map[int]*domain.FlightDetails)
 	for i := range rows {
 		fd := flightRowToDomain(&rows[i])
-		results[int(rows[i].EventID)] = &fd
+		fd.Segments = segments[fd.EventID]
+		results[fd.EventID] = &fd
 	}
 	return results, nil
 }
 
-// Update updates existing flight_details. Uses the caller-provided queries (can be tx-scoped).
+// Update updates existing flight_details and replaces their segments. Uses the
+// caller-provided queries, which should be tx-scoped so that the segments are
+// replaced all or nothing.
 func (s *FlightDetailsStore) Update(ctx context.Context, q *sqlcgen.Queries, eventID int, fd *domain.FlightDetails) (*domain.FlightDetails, error) {
 	row, err := q.UpdateFlightDetails(ctx, sqlcgen.UpdateFlightDetailsParams{
 		EventID:           int32(eventID),
@@ -85,14 +103,106 @@ func (s *FlightDetailsStore) Update(ctx context.Context, q *sqlcgen.Queries, eve
 		DepartureGate:     toPgText(fd.DepartureGate),
 		ArrivalGate:       toPgText(fd.ArrivalGate),
 		BookingReference:  toPgText(fd.BookingReference),
+		DepartureTimeZone: toPgText(fd.DepartureTimeZone),
+		ArrivalTimeZone:   toPgText(fd.ArrivalTimeZone),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("updating flight_details for event %d: %w", eventID, err)
 	}
+	if err = q.DeleteFlightSegmentsByEventID(ctx, int32(eventID)); err != nil {
+		return nil, fmt.Errorf("deleting flight_segments for event %d: %w", eventID, err)
+	}
 	result := flightRowToDomain(&row)
+	if result.Segments, err = s.createSegments(ctx, q, eventID, fd.Segments); err != nil {
+		return nil, err
+	}
 	return &result, nil
 }
 
+// ShiftDays moves every segment by whole days, keeping the local wall-clock
+// times of its departure and arrival in their own zones; loc stands in for a
+// zone a segment lacks. A missing row is not an error: there is nothing to shift.
+func (s *FlightDetailsStore) ShiftDays(ctx context.Context, q *sqlcgen.Queries, eventID, days int, loc *time.Location) error {
+	fd, err := s.GetByEventID(ctx, q, eventID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if len(fd.Segments) == 0 {
+		return nil
+	}
+	shiftSegments(fd.Segments, days, loc)
+	_, err = s.Update(ctx, q, eventID, fd)
+	return err
+}
+
+// shiftSegments moves segments by whole days in their departure and arrival zones.
+func shiftSegments(segments []domain.FlightSegment, days int, loc *time.Location) {
+	zone := func(name string) *time.Location {
+		if name == "" {
+			return loc
+		}
+		return domain.ZoneOrUTC(name)
+	}
+	for i := range segments {
+		seg := &segments[i]
+		if !seg.DepartureTime.IsZero() {
+			seg.DepartureTime = seg.DepartureTime.In(zone(seg.DepartureTimeZone)).AddDate(0, 0, days)
+		}
+		if !seg.ArrivalTime.IsZero() {
+			seg.ArrivalTime = seg.ArrivalTime.In(zone(seg.ArrivalTimeZone)).AddDate(0, 0, days)
+		}
+	}
+}
+
+// createSegments inserts segments in order, numbering their positions from 0.
+func (s *FlightDetailsStore) createSegments(ctx context.Context, q *sqlcgen.Queries, eventID int, segments []domain.FlightSegment) ([]domain.FlightSegment, error) {
+	if len(segments) == 0 {
+		return nil, nil
+	}
+	created := make([]domain.FlightSegment, 0, len(segments))
+	for i := range segments {
+		seg := &segments[i]
+		row, err := q.CreateFlightSegment(ctx, sqlcgen.CreateFlightSegmentParams{
+			EventID:           int32(eventID),
+			Position:          int32(i),
+			Airline:           toPgText(seg.Airline),
+			FlightNumber:      toPgText(seg.FlightNumber),
+			DepartureAirport:  toPgText(seg.DepartureAirport),
+			ArrivalAirport:    toPgText(seg.ArrivalAirport),
+			DepartureTerminal: toPgText(seg.DepartureTerminal),
+			ArrivalTerminal:   toPgText(seg.ArrivalTerminal),
+			DepartureGate:     toPgText(seg.DepartureGate),
+			ArrivalGate:       toPgText(seg.ArrivalGate),
+			DepartureTime:     toPgTimestamptz(seg.DepartureTime),
+			ArrivalTime:       toPgTimestamptz(seg.ArrivalTime),
+			DepartureTimeZone: toPgText(seg.DepartureTimeZone),
+			ArrivalTimeZone:   toPgText(seg.ArrivalTimeZone),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("inserting flight_segment %d for event %d: %w", i+1, eventID, err)
+		}
+		created = append(created, segmentRowToDomain(&row))
+	}
+	return created, nil
+}
+
+// listSegments loads the segments of several flights, in order, by event ID.
+func (s *FlightDetailsStore) listSegments(ctx context.Context, q *sqlcgen.Queries, eventIDs []int32) (map[int][]domain.FlightSegment, error) {
+	rows, err := q.ListFlightSegmentsByEventIDs(ctx, eventIDs)
+	if err != nil {
+		return nil, fmt.Errorf("fetching flight_segments: %w", err)
+	}
+	segments := make(map[int][]domain.FlightSegment)
+	for i := range rows {
+		eventID := int(rows[i].EventID)
+		segments[eventID] = append(segments[eventID], segmentRowToDomain(&rows[i]))
+	}
+	return segments, nil
+}
+
 func flightRowToDomain(row *sqlcgen.FlightDetail) domain.FlightDetails {
 	return domain.FlightDetails{
 		ID:                int(row.ID),
@@ -106,5 +216,25 @@ func flightRowToDomain(row *sqlcgen.FlightDetail) domain.FlightDetails {
 		DepartureGate:     row.DepartureGate.String,
 		ArrivalGate:       row.ArrivalGate.String,
 		BookingReference:  row.BookingReference.String,
+		DepartureTimeZone: row.DepartureTimeZone.String,
+		ArrivalTimeZone:   row.ArrivalTimeZone.String,
+	}
+}
+
+func segmentRowToDomain(row *sqlcgen.FlightSegment) domain.FlightSegment {
+	return domain.FlightSegment{
+		ID:                int(row.ID),
+		Airline:           row.Airline.String,
+		FlightNumber:      row.FlightNumber.String,
+		DepartureAirport:  row.DepartureAirport.String,
+		ArrivalAirport:    row.ArrivalAirport.String,
+		DepartureTerminal: row.DepartureTerminal.String,
+		ArrivalTerminal:   row.ArrivalTerminal.String,
+		DepartureGate:     row.DepartureGate.String,
+		ArrivalGate:       row.ArrivalGate.String,
+		DepartureTime:     row.DepartureTime.Time,
+		ArrivalTime:       row.ArrivalTime.Time,
+		DepartureTimeZone: row.DepartureTimeZone.String,
+		ArrivalTimeZone:   row.ArrivalTimeZone.String,
 	}
 }
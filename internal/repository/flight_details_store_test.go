@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/repository/sqlcgen"
+)
+
+func Test_segmentRowToDomain(t *testing.T) {
+	departure := time.Date(2026, 6, 1, 8, 5, 0, 0, time.UTC)
+	arrival := time.Date(2026, 6, 1, 10, 40, 0, 0, time.UTC)
+	row := &sqlcgen.FlightSegment{
+		ID:                3,
+		EventID:           7,
+		Position:          1,
+		Airline:           pgtype.Text{String: "LH", Valid: true},
+		FlightNumber:      pgtype.Text{String: "901", Valid: true},
+		DepartureAirport:  pgtype.Text{String: "LHR", Valid: true},
+		ArrivalAirport:    pgtype.Text{String: "FRA", Valid: true},
+		DepartureTerminal: pgtype.Text{String: "2", Valid: true},
+		ArrivalTerminal:   pgtype.Text{String: "1", Valid: true},
+		DepartureGate:     pgtype.Text{String: "B42", Valid: true},
+		ArrivalGate:       pgtype.Text{},
+		DepartureTime:     pgtype.Timestamptz{Time: departure, Valid: true},
+		ArrivalTime:       pgtype.Timestamptz{Time: arrival, Valid: true},
+		DepartureTimeZone: pgtype.Text{String: "Europe/London", Valid: true},
+		ArrivalTimeZone:   pgtype.Text{String: "Europe/Berlin", Valid: true},
+	}
+
+	got := segmentRowToDomain(row)
+
+	if got.ID != 3 {
+		t.Errorf("ID = %d, want 3", got.ID)
+	}
+	if got.Airline != "LH" || got.FlightNumber != "901" {
+		t.Errorf("flight = %q %q, want LH 901", got.Airline, got.FlightNumber)
+	}
+	if got.DepartureAirport != "LHR" || got.ArrivalAirport != "FRA" {
+		t.Errorf("route = %q → %q, want LHR → FRA", got.DepartureAirport, got.ArrivalAirport)
+	}
+	if got.DepartureTerminal != "2" || got.ArrivalTerminal != "1" || got.DepartureGate != "B42" || got.ArrivalGate != "" {
+		t.Errorf("terminals and gates = %+v", got)
+	}
+	if !got.DepartureTime.Equal(departure) || !got.ArrivalTime.Equal(arrival) {
+		t.Errorf("times = %v – %v, want %v – %v", got.DepartureTime, got.ArrivalTime, departure, arrival)
+	}
+	if got.DepartureTimeZone != "Europe/London" || got.ArrivalTimeZone != "Europe/Berlin" {
+		t.Errorf("zones = %q, %q", got.DepartureTimeZone, got.ArrivalTimeZone)
+	}
+}
+
+func Test_shiftSegments(t *testing.T) {
+	london := mustLoadLocation(t, "Europe/London")
+	frankfurt := mustLoadLocation(t, "Europe/Berlin")
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+
+	// Two days later the clocks in Europe have gone forward: the segments keep
+	// their local times, not their UTC ones
+	segments := []domain.FlightSegment{
+		{
+			DepartureTime:     time.Date(2026, 3, 27, 7, 0, 0, 0, london),
+			ArrivalTime:       time.Date(2026, 3, 27, 9, 40, 0, 0, frankfurt),
+			DepartureTimeZone: "Europe/London",
+			ArrivalTimeZone:   "Europe/Berlin",
+		},
+		{
+			DepartureTime:     time.Date(2026, 3, 27, 10, 30, 0, 0, frankfurt),
+			ArrivalTime:       time.Date(2026, 3, 28, 6, 15, 0, 0, tokyo),
+			DepartureTimeZone: "Europe/Berlin",
+			ArrivalTimeZone:   "Asia/Tokyo",
+		},
+	}
+	shiftSegments(segments, 2, time.UTC)
+
+	want := []struct{ dep, arr time.Time }{
+		{time.Date(2026, 3, 29, 7, 0, 0, 0, london), time.Date(2026, 3, 29, 9, 40, 0, 0, frankfurt)},
+		{time.Date(2026, 3, 29, 10, 30, 0, 0, frankfurt), time.Date(2026, 3, 30, 6, 15, 0, 0, tokyo)},
+	}
+	for i, w := range want {
+		if !segments[i].DepartureTime.Equal(w.dep) {
+			t.Errorf("segment %d DepartureTime = %v, want %v", i+1, segments[i].DepartureTime, w.dep)
+		}
+		if !segments[i].ArrivalTime.Equal(w.arr) {
+			t.Errorf("segment %d ArrivalTime = %v, want %v", i+1, segments[i].ArrivalTime, w.arr)
+		}
+	}
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("LoadLocation(%q) error = %v", name, err)
+	}
+	return loc
+}
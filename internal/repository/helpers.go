@@ -24,6 +24,15 @@ func toPgText(s string) pgtype.Text {
 	return pgtype.Text{String: s, Valid: true}
 }
 
+// toPgUUID converts a UUID string, leaving empty and malformed IDs NULL.
+func toPgUUID(id string) pgtype.UUID {
+	var uid pgtype.UUID
+	if id != "" && uid.Scan(id) != nil {
+		return pgtype.UUID{}
+	}
+	return uid
+}
+
 func toPgFloat8(f *float64) pgtype.Float8 {
 	if f == nil {
 		return pgtype.Float8{}
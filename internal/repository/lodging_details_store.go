@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 
@@ -81,6 +82,28 @@ func (s *LodgingDetailsStore) Update(ctx context.Context, q *sqlcgen.Queries, ev
 	return &result, nil
 }
 
+// ShiftDays moves check-in and check-out by whole days in loc, keeping their
+// local wall-clock times. A missing row is not an error: there is nothing to shift.
+func (s *LodgingDetailsStore) ShiftDays(ctx context.Context, q *sqlcgen.Queries, eventID, days int, loc *time.Location) error {
+	ld, err := s.GetByEventID(ctx, q, eventID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if ld.CheckInTime != nil {
+		t := ld.CheckInTime.In(loc).AddDate(0, 0, days)
+		ld.CheckInTime = &t
+	}
+	if ld.CheckOutTime != nil {
+		t := ld.CheckOutTime.In(loc).AddDate(0, 0, days)
+		ld.CheckOutTime = &t
+	}
+	_, err = s.Update(ctx, q, eventID, ld)
+	return err
+}
+
 func lodgingRowToDomain(row *sqlcgen.LodgingDetail) domain.LodgingDetails {
 	return domain.LodgingDetails{
 		ID:               int(row.ID),
@@ -0,0 +1,88 @@
+package repository
+
+// positionGap is the distance between consecutive positions assigned to new events.
+// Gaps let an event be placed between two others without touching its neighbours.
+const positionGap = 1000
+
+// planPositions computes new gap-based positions for a day's events.
+// current holds each event's existing position, listed in the desired order.
+// Events that are already in increasing order keep their position; the rest are
+// placed midway between their neighbours. When no integer gap is left, the whole
+// day is renumbered to 1000, 2000, 3000...
+func planPositions(current []int) []int {
+	planned := make([]int, len(current))
+	keep := longestIncreasingRun(current)
+
+	for i := 0; i < len(current); {
+		if keep[i] {
+			planned[i] = current[i]
+			i++
+			continue
+		}
+
+		// Collect the run of events that must move and the anchors around it.
+		start := i
+		for i < len(current) && !keep[i] {
+			i++
+		}
+		lo := 0
+		if start > 0 {
+			lo = planned[start-1]
+		}
+		count := i - start
+		hi := lo + (count+1)*positionGap
+		if i < len(current) {
+			hi = current[i]
+		}
+
+		step := (hi - lo) / (count + 1)
+		if step < 1 {
+			return renumberPositions(len(current))
+		}
+		for j := 0; j < count; j++ {
+			planned[start+j] = lo + step*(j+1)
+		}
+	}
+	return planned
+}
+
+// renumberPositions returns evenly spaced positions for n events.
+func renumberPositions(n int) []int {
+	positions := make([]int, n)
+	for i := range positions {
+		positions[i] = (i + 1) * positionGap
+	}
+	return positions
+}
+
+// longestIncreasingRun marks the longest subsequence of strictly increasing,
+// positive positions. Those events can stay where they are. Ties favour the run
+// ending last, so an event dragged upwards is the one that gets a new position.
+func longestIncreasingRun(positions []int) []bool {
+	n := len(positions)
+	length := make([]int, n)
+	prev := make([]int, n)
+	best := -1
+	for i := 0; i < n; i++ {
+		prev[i] = -1
+		if positions[i] <= 0 {
+			continue
+		}
+		length[i] = 1
+		for j := 0; j < i; j++ {
+			if length[j] > 0 && positions[j] < positions[i] && length[j]+1 > length[i] {
+				length[i] = length[j] + 1
+				prev[i] = j
+			}
+		}
+		if best < 0 || length[i] >= length[best] {
+			best = i
+		}
+	}
+
+	keep := make([]bool, n)
+	for i := best; i >= 0; i = prev[i] {
+		keep[i] = true
+	}
+	return keep
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"slices"
+	"testing"
+)
+
+func Test_planPositions(t *testing.T) {
+	tests := []struct {
+		name    string
+		current []int
+		want    []int
+	}{
+		{
+			name:    "already ordered keeps positions",
+			current: []int{1000, 2000, 3000},
+			want:    []int{1000, 2000, 3000},
+		},
+		{
+			name:    "move last to first lands before first anchor",
+			current: []int{3000, 1000, 2000},
+			want:    []int{500, 1000, 2000},
+		},
+		{
+			name:    "move first to last appends after last anchor",
+			current: []int{2000, 3000, 1000},
+			want:    []int{2000, 3000, 4000},
+		},
+		{
+			name:    "move into the middle splits the gap",
+			current: []int{1000, 3000, 2000},
+			want:    []int{1000, 1500, 2000},
+		},
+		{
+			name:    "exhausted gap renumbers the day",
+			current: []int{1000, 1002, 1001},
+			want:    []int{1000, 2000, 3000},
+		},
+		{
+			name:    "duplicate positions are separated",
+			current: []int{1000, 1000, 2000},
+			want:    []int{1000, 1500, 2000},
+		},
+		{
+			name:    "unset positions are renumbered",
+			current: []int{0, 0},
+			want:    []int{1000, 2000},
+		},
+		{
+			name:    "empty day",
+			current: []int{},
+			want:    []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := planPositions(tt.current)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("planPositions(%v) = %v, want %v", tt.current, got, tt.want)
+			}
+			for i := 1; i < len(got); i++ {
+				if got[i] <= got[i-1] {
+					t.Errorf("planPositions(%v) = %v is not strictly increasing", tt.current, got)
+				}
+			}
+		})
+	}
+}
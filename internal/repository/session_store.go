@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/repository/sqlcgen"
+)
+
+var _ domain.SessionRepository = (*SessionStore)(nil)
+
+type SessionStore struct {
+	queries *sqlcgen.Queries
+}
+
+func NewSessionStore(db *pgxpool.Pool) *SessionStore {
+	return &SessionStore{
+		queries: sqlcgen.New(db),
+	}
+}
+
+func (s *SessionStore) Create(ctx context.Context, session *domain.Session) error {
+	row, err := s.queries.CreateSession(ctx, sqlcgen.CreateSessionParams{
+		TokenHash: session.TokenHash,
+		UserID:    toPgUUID(session.UserID),
+		ExpiresAt: toPgTimestamptz(session.ExpiresAt),
+	})
+	if err != nil {
+		return err
+	}
+	*session = sessionRowToDomain(&row)
+	return nil
+}
+
+func (s *SessionStore) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Session, error) {
+	row, err := s.queries.GetSessionByTokenHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	session := sessionRowToDomain(&row)
+	return &session, nil
+}
+
+func (s *SessionStore) Delete(ctx context.Context, tokenHash string) error {
+	return s.queries.DeleteSession(ctx, tokenHash)
+}
+
+func (s *SessionStore) DeleteExpired(ctx context.Context) error {
+	return s.queries.DeleteExpiredSessions(ctx)
+}
+
+func sessionRowToDomain(row *sqlcgen.Session) domain.Session {
+	return domain.Session{
+		TokenHash: row.TokenHash,
+		UserID:    row.UserID.String(),
+		ExpiresAt: row.ExpiresAt.Time,
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,20 @@
+-- name: CreateCalendarFeed :one
+INSERT INTO calendar_feeds (trip_id, token)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: DeleteCalendarFeed :execrows
+DELETE FROM calendar_feeds WHERE id = $1 AND trip_id = $2;
+
+-- name: GetCalendarFeedByToken :one
+SELECT * FROM calendar_feeds WHERE token = $1;
+
+-- name: GetTripLastModified :one
+SELECT GREATEST(t.updated_at, MAX(e.updated_at))::timestamptz AS last_modified
+FROM trips t
+LEFT JOIN events e ON e.trip_id = t.id
+WHERE t.id = $1
+GROUP BY t.id;
+
+-- name: ListCalendarFeedsByTrip :many
+SELECT * FROM calendar_feeds WHERE trip_id = $1 ORDER BY created_at ASC, id ASC;
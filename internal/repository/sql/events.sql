@@ -1,11 +1,14 @@
 -- name: CreateEvent :one
-INSERT INTO events (trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+INSERT INTO events (trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, time_zone)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 RETURNING *;
 
 -- name: GetEventByID :one
 SELECT * FROM events WHERE id = $1 AND deleted_at IS NULL;
 
+-- name: GetEventByTripAndID :one
+SELECT * FROM events WHERE id = $1 AND trip_id = $2 AND deleted_at IS NULL;
+
 -- name: ListEventsByTrip :many
 SELECT * FROM events
 WHERE trip_id = $1 AND deleted_at IS NULL
@@ -20,15 +23,15 @@ ORDER BY position ASC;
 UPDATE events
 SET title = $2, category = $3, location = $4, latitude = $5, longitude = $6,
     start_time = $7, end_time = $8, pinned = $9, position = $10,
-    event_date = $11, notes = $12, updated_at = NOW()
+    event_date = $11, notes = $12, time_zone = $13, updated_at = NOW()
 WHERE id = $1
 RETURNING *;
 
 -- name: SoftDeleteEvent :exec
-UPDATE events SET deleted_at = NOW() WHERE id = $1;
+UPDATE events SET deleted_at = NOW(), updated_at = NOW() WHERE id = $1;
 
 -- name: RestoreEvent :one
-UPDATE events SET deleted_at = NULL WHERE id = $1
+UPDATE events SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND trip_id = $2
 RETURNING *;
 
 -- name: GetMaxPositionByTripAndDate :one
@@ -53,3 +56,31 @@ WHERE trip_id = $1
   AND deleted_at IS NULL
 GROUP BY event_date
 ORDER BY event_date;
+
+-- name: ListEventPositionsForUpdate :many
+SELECT id, position FROM events
+WHERE trip_id = $1 AND event_date = $2 AND deleted_at IS NULL
+ORDER BY position ASC
+FOR UPDATE;
+
+-- name: UpdateEventPosition :exec
+UPDATE events SET position = $2, updated_at = NOW() WHERE id = $1;
+
+-- name: MoveEventToDay :one
+UPDATE events
+SET event_date = $2, start_time = $3, end_time = $4, position = $5, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: ListEventsOutsideTripRange :many
+SELECT e.id, e.trip_id, e.event_date, e.title, t.name AS trip_name, t.start_date AS trip_start_date, t.end_date AS trip_end_date
+FROM events e
+JOIN trips t ON t.id = e.trip_id
+WHERE e.deleted_at IS NULL
+  AND (e.event_date < t.start_date OR e.event_date > t.end_date)
+ORDER BY e.trip_id, e.event_date, e.position;
+
+-- name: ListLodgingEventsByTrip :many
+SELECT * FROM events
+WHERE trip_id = $1 AND category = 'lodging' AND deleted_at IS NULL
+ORDER BY start_time ASC;
@@ -1,6 +1,6 @@
 -- name: CreateFlightDetails :one
-INSERT INTO flight_details (event_id, airline, flight_number, departure_airport, arrival_airport, departure_terminal, arrival_terminal, departure_gate, arrival_gate, booking_reference)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+INSERT INTO flight_details (event_id, airline, flight_number, departure_airport, arrival_airport, departure_terminal, arrival_terminal, departure_gate, arrival_gate, booking_reference, departure_time_zone, arrival_time_zone)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 RETURNING *;
 
 -- name: GetFlightDetailsByEventID :one
@@ -13,6 +13,6 @@ SELECT * FROM flight_details WHERE event_id = ANY(@event_ids::int[]);
 UPDATE flight_details
 SET airline = $2, flight_number = $3, departure_airport = $4, arrival_airport = $5,
     departure_terminal = $6, arrival_terminal = $7, departure_gate = $8, arrival_gate = $9,
-    booking_reference = $10
+    booking_reference = $10, departure_time_zone = $11, arrival_time_zone = $12
 WHERE event_id = $1
 RETURNING *;
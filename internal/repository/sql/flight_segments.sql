@@ -0,0 +1,10 @@
+-- name: CreateFlightSegment :one
+INSERT INTO flight_segments (event_id, position, airline, flight_number, departure_airport, arrival_airport, departure_terminal, arrival_terminal, departure_gate, arrival_gate, departure_time, arrival_time, departure_time_zone, arrival_time_zone)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+RETURNING *;
+
+-- name: ListFlightSegmentsByEventIDs :many
+SELECT * FROM flight_segments WHERE event_id = ANY(@event_ids::int[]) ORDER BY event_id, position;
+
+-- name: DeleteFlightSegmentsByEventID :exec
+DELETE FROM flight_segments WHERE event_id = $1;
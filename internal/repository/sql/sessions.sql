@@ -0,0 +1,13 @@
+-- name: CreateSession :one
+INSERT INTO sessions (token_hash, user_id, expires_at)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: DeleteExpiredSessions :exec
+DELETE FROM sessions WHERE expires_at <= NOW();
+
+-- name: DeleteSession :exec
+DELETE FROM sessions WHERE token_hash = $1;
+
+-- name: GetSessionByTokenHash :one
+SELECT * FROM sessions WHERE token_hash = $1;
@@ -0,0 +1,57 @@
+-- name: AddTripMember :one
+INSERT INTO trip_members (trip_id, user_id, username, role)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListTripMembers :many
+SELECT * FROM trip_members
+WHERE trip_id = $1
+ORDER BY role = 'owner' DESC, username ASC;
+
+-- name: ListTripMembersByTrips :many
+SELECT * FROM trip_members
+WHERE trip_id = ANY(@trip_ids::int[])
+ORDER BY trip_id, role = 'owner' DESC, username ASC;
+
+-- name: UpdateTripMemberRole :one
+UPDATE trip_members SET role = $3
+WHERE trip_id = $1 AND user_id = $2 AND role <> 'owner'
+RETURNING *;
+
+-- name: DeleteTripMember :execrows
+DELETE FROM trip_members
+WHERE trip_id = $1 AND user_id = $2 AND role <> 'owner';
+
+-- name: CreateTripInvitation :one
+INSERT INTO trip_invitations (trip_id, invitee, role, invited_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetTripInvitation :one
+SELECT i.id, i.trip_id, i.invitee, i.role, i.invited_by, i.created_at, t.name AS trip_name
+FROM trip_invitations i
+JOIN trips t ON t.id = i.trip_id
+WHERE i.id = $1;
+
+-- name: ListTripInvitationsByTrip :many
+SELECT * FROM trip_invitations WHERE trip_id = $1 ORDER BY created_at ASC, id ASC;
+
+-- name: ListTripInvitationsFor :many
+SELECT i.id, i.trip_id, i.invitee, i.role, i.invited_by, i.created_at, t.name AS trip_name
+FROM trip_invitations i
+JOIN trips t ON t.id = i.trip_id
+WHERE i.invitee = ANY(@invitees::text[])
+ORDER BY i.created_at ASC, i.id ASC;
+
+-- name: DeleteTripInvitation :execrows
+DELETE FROM trip_invitations WHERE id = $1 AND trip_id = $2;
+
+-- name: AcceptTripInvitation :one
+WITH invitation AS (
+    DELETE FROM trip_invitations WHERE trip_invitations.id = @id
+    RETURNING trip_id, role
+)
+INSERT INTO trip_members (trip_id, user_id, username, role)
+SELECT invitation.trip_id, @user_id::uuid, @username::text, invitation.role
+FROM invitation
+RETURNING *;
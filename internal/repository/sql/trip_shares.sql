@@ -0,0 +1,21 @@
+-- name: CreateTripShare :one
+INSERT INTO trip_shares (trip_id, token, expires_at)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: DeleteTripShare :execrows
+DELETE FROM trip_shares WHERE id = $1 AND trip_id = $2;
+
+-- name: GetTripShareByToken :one
+SELECT * FROM trip_shares WHERE token = $1;
+
+-- name: ListTripSharesByTrip :many
+SELECT * FROM trip_shares WHERE trip_id = $1 ORDER BY created_at ASC, id ASC;
+
+-- name: RotateTripShareToken :one
+UPDATE trip_shares SET token = $3 WHERE id = $1 AND trip_id = $2
+RETURNING *;
+
+-- name: SetTripShareExpiry :one
+UPDATE trip_shares SET expires_at = $3 WHERE id = $1 AND trip_id = $2
+RETURNING *;
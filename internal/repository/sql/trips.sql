@@ -1,6 +1,6 @@
 -- name: CreateTrip :one
-INSERT INTO trips (name, destination, start_date, end_date, user_id)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO trips (name, destination, start_date, end_date, user_id, time_zone)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: GetTripByID :one
@@ -8,12 +8,13 @@ SELECT * FROM trips WHERE id = $1;
 
 -- name: ListTrips :many
 SELECT * FROM trips
-WHERE (user_id = $1 OR $1 IS NULL)
+WHERE (user_id IS NOT DISTINCT FROM sqlc.narg(user_id)::uuid
+    OR id IN (SELECT trip_id FROM trip_members WHERE trip_members.user_id = sqlc.narg(user_id)))
 ORDER BY start_date DESC, created_at DESC;
 
 -- name: UpdateTrip :one
 UPDATE trips
-SET name = $2, destination = $3, start_date = $4, end_date = $5, updated_at = NOW()
+SET name = $2, destination = $3, start_date = $4, end_date = $5, time_zone = $6, updated_at = NOW()
 WHERE id = $1
 RETURNING *;
 
@@ -25,3 +26,16 @@ SELECT COUNT(*)::int AS event_count
 FROM events
 WHERE trip_id = $1
   AND (event_date < $2 OR event_date > $3);
+
+-- name: CountOwnerlessTrips :one
+SELECT COUNT(*)::int AS trip_count FROM trips WHERE user_id IS NULL;
+
+-- name: ClaimOwnerlessTrips :execrows
+WITH claimed AS (
+    UPDATE trips SET user_id = @user_id::uuid, updated_at = NOW()
+    WHERE user_id IS NULL
+    RETURNING id
+)
+INSERT INTO trip_members (trip_id, user_id, username, role)
+SELECT claimed.id, @user_id::uuid, @username::text, 'owner'
+FROM claimed;
@@ -64,9 +64,9 @@ func (q *Queries) CountEventsByTripGroupedByDate(ctx context.Context, arg CountE
 }
 
 const createEvent = `-- name: CreateEvent :one
-INSERT INTO events (trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-RETURNING id, trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, deleted_at, created_at, updated_at
+INSERT INTO events (trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, time_zone)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+RETURNING id, trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, deleted_at, created_at, updated_at, time_zone
 `
 
 type CreateEventParams struct {
@@ -82,6 +82,7 @@ type CreateEventParams struct {
 	Pinned    pgtype.Bool
 	Position  int32
 	Notes     pgtype.Text
+	TimeZone  string
 }
 
 func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) (Event, error) {
@@ -98,6 +99,7 @@ func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) (Event
 		arg.Pinned,
 		arg.Position,
 		arg.Notes,
+		arg.TimeZone,
 	)
 	var i Event
 	err := row.Scan(
@@ -117,12 +119,13 @@ func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) (Event
 		&i.DeletedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.TimeZone,
 	)
 	return i, err
 }
 
 const getEventByID = `-- name: GetEventByID :one
-SELECT id, trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, deleted_at, created_at, updated_at FROM events WHERE id = $1 AND deleted_at IS NULL
+SELECT id, trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, deleted_at, created_at, updated_at, time_zone FROM events WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetEventByID(ctx context.Context, id int32) (Event, error) {
@@ -145,12 +148,47 @@ func (q *Queries) GetEventByID(ctx context.Context, id int32) (Event, error) {
 		&i.DeletedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.TimeZone,
+	)
+	return i, err
+}
+
+const getEventByTripAndID = `-- name: GetEventByTripAndID :one
+SELECT id, trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, deleted_at, created_at, updated_at, time_zone FROM events WHERE id = $1 AND trip_id = $2 AND deleted_at IS NULL
+`
+
+type GetEventByTripAndIDParams struct {
+	ID     int32
+	TripID int32
+}
+
+func (q *Queries) GetEventByTripAndID(ctx context.Context, arg GetEventByTripAndIDParams) (Event, error) {
+	row := q.db.QueryRow(ctx, getEventByTripAndID, arg.ID, arg.TripID)
+	var i Event
+	err := row.Scan(
+		&i.ID,
+		&i.TripID,
+		&i.EventDate,
+		&i.Title,
+		&i.Category,
+		&i.Location,
+		&i.Latitude,
+		&i.Longitude,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Pinned,
+		&i.Position,
+		&i.Notes,
+		&i.DeletedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.TimeZone,
 	)
 	return i, err
 }
 
 const getLastEventByTrip = `-- name: GetLastEventByTrip :one
-SELECT id, trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, deleted_at, created_at, updated_at FROM events
+SELECT id, trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, deleted_at, created_at, updated_at, time_zone FROM events
 WHERE trip_id = $1 AND deleted_at IS NULL
 ORDER BY event_date DESC, end_time DESC
 LIMIT 1
@@ -176,6 +214,7 @@ func (q *Queries) GetLastEventByTrip(ctx context.Context, tripID int32) (Event,
 		&i.DeletedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.TimeZone,
 	)
 	return i, err
 }
@@ -198,8 +237,45 @@ func (q *Queries) GetMaxPositionByTripAndDate(ctx context.Context, arg GetMaxPos
 	return max_position, err
 }
 
+const listEventPositionsForUpdate = `-- name: ListEventPositionsForUpdate :many
+SELECT id, position FROM events
+WHERE trip_id = $1 AND event_date = $2 AND deleted_at IS NULL
+ORDER BY position ASC
+FOR UPDATE
+`
+
+type ListEventPositionsForUpdateParams struct {
+	TripID    int32
+	EventDate pgtype.Date
+}
+
+type ListEventPositionsForUpdateRow struct {
+	ID       int32
+	Position int32
+}
+
+func (q *Queries) ListEventPositionsForUpdate(ctx context.Context, arg ListEventPositionsForUpdateParams) ([]ListEventPositionsForUpdateRow, error) {
+	rows, err := q.db.Query(ctx, listEventPositionsForUpdate, arg.TripID, arg.EventDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEventPositionsForUpdateRow{}
+	for rows.Next() {
+		var i ListEventPositionsForUpdateRow
+		if err := rows.Scan(&i.ID, &i.Position); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEventsByTrip = `-- name: ListEventsByTrip :many
-SELECT id, trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, deleted_at, created_at, updated_at FROM events
+SELECT id, trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, deleted_at, created_at, updated_at, time_zone FROM events
 WHERE trip_id = $1 AND deleted_at IS NULL
 ORDER BY event_date ASC, position ASC
 `
@@ -230,6 +306,7 @@ func (q *Queries) ListEventsByTrip(ctx context.Context, tripID int32) ([]Event,
 			&i.DeletedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.TimeZone,
 		); err != nil {
 			return nil, err
 		}
@@ -242,7 +319,7 @@ func (q *Queries) ListEventsByTrip(ctx context.Context, tripID int32) ([]Event,
 }
 
 const listEventsByTripAndDate = `-- name: ListEventsByTripAndDate :many
-SELECT id, trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, deleted_at, created_at, updated_at FROM events
+SELECT id, trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, deleted_at, created_at, updated_at, time_zone FROM events
 WHERE trip_id = $1 AND event_date = $2 AND deleted_at IS NULL
 ORDER BY position ASC
 `
@@ -278,6 +355,98 @@ func (q *Queries) ListEventsByTripAndDate(ctx context.Context, arg ListEventsByT
 			&i.DeletedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.TimeZone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEventsOutsideTripRange = `-- name: ListEventsOutsideTripRange :many
+SELECT e.id, e.trip_id, e.event_date, e.title, t.name AS trip_name, t.start_date AS trip_start_date, t.end_date AS trip_end_date
+FROM events e
+JOIN trips t ON t.id = e.trip_id
+WHERE e.deleted_at IS NULL
+  AND (e.event_date < t.start_date OR e.event_date > t.end_date)
+ORDER BY e.trip_id, e.event_date, e.position
+`
+
+type ListEventsOutsideTripRangeRow struct {
+	ID            int32
+	TripID        int32
+	EventDate     pgtype.Date
+	Title         string
+	TripName      string
+	TripStartDate pgtype.Date
+	TripEndDate   pgtype.Date
+}
+
+func (q *Queries) ListEventsOutsideTripRange(ctx context.Context) ([]ListEventsOutsideTripRangeRow, error) {
+	rows, err := q.db.Query(ctx, listEventsOutsideTripRange)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEventsOutsideTripRangeRow{}
+	for rows.Next() {
+		var i ListEventsOutsideTripRangeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TripID,
+			&i.EventDate,
+			&i.Title,
+			&i.TripName,
+			&i.TripStartDate,
+			&i.TripEndDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLodgingEventsByTrip = `-- name: ListLodgingEventsByTrip :many
+SELECT id, trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, deleted_at, created_at, updated_at, time_zone FROM events
+WHERE trip_id = $1 AND category = 'lodging' AND deleted_at IS NULL
+ORDER BY start_time ASC
+`
+
+func (q *Queries) ListLodgingEventsByTrip(ctx context.Context, tripID int32) ([]Event, error) {
+	rows, err := q.db.Query(ctx, listLodgingEventsByTrip, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Event{}
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.TripID,
+			&i.EventDate,
+			&i.Title,
+			&i.Category,
+			&i.Location,
+			&i.Latitude,
+			&i.Longitude,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Pinned,
+			&i.Position,
+			&i.Notes,
+			&i.DeletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.TimeZone,
 		); err != nil {
 			return nil, err
 		}
@@ -289,13 +458,64 @@ func (q *Queries) ListEventsByTripAndDate(ctx context.Context, arg ListEventsByT
 	return items, nil
 }
 
+const moveEventToDay = `-- name: MoveEventToDay :one
+UPDATE events
+SET event_date = $2, start_time = $3, end_time = $4, position = $5, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, deleted_at, created_at, updated_at, time_zone
+`
+
+type MoveEventToDayParams struct {
+	ID        int32
+	EventDate pgtype.Date
+	StartTime pgtype.Timestamptz
+	EndTime   pgtype.Timestamptz
+	Position  int32
+}
+
+func (q *Queries) MoveEventToDay(ctx context.Context, arg MoveEventToDayParams) (Event, error) {
+	row := q.db.QueryRow(ctx, moveEventToDay,
+		arg.ID,
+		arg.EventDate,
+		arg.StartTime,
+		arg.EndTime,
+		arg.Position,
+	)
+	var i Event
+	err := row.Scan(
+		&i.ID,
+		&i.TripID,
+		&i.EventDate,
+		&i.Title,
+		&i.Category,
+		&i.Location,
+		&i.Latitude,
+		&i.Longitude,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Pinned,
+		&i.Position,
+		&i.Notes,
+		&i.DeletedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.TimeZone,
+	)
+	return i, err
+}
+
 const restoreEvent = `-- name: RestoreEvent :one
-UPDATE events SET deleted_at = NULL WHERE id = $1
-RETURNING id, trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, deleted_at, created_at, updated_at
+UPDATE events SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND trip_id = $2
+RETURNING id, trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, deleted_at, created_at, updated_at, time_zone
 `
 
-func (q *Queries) RestoreEvent(ctx context.Context, id int32) (Event, error) {
-	row := q.db.QueryRow(ctx, restoreEvent, id)
+type RestoreEventParams struct {
+	ID     int32
+	TripID int32
+}
+
+func (q *Queries) RestoreEvent(ctx context.Context, arg RestoreEventParams) (Event, error) {
+	row := q.db.QueryRow(ctx, restoreEvent, arg.ID, arg.TripID)
 	var i Event
 	err := row.Scan(
 		&i.ID,
@@ -314,12 +534,13 @@ func (q *Queries) RestoreEvent(ctx context.Context, id int32) (Event, error) {
 		&i.DeletedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.TimeZone,
 	)
 	return i, err
 }
 
 const softDeleteEvent = `-- name: SoftDeleteEvent :exec
-UPDATE events SET deleted_at = NOW() WHERE id = $1
+UPDATE events SET deleted_at = NOW(), updated_at = NOW() WHERE id = $1
 `
 
 func (q *Queries) SoftDeleteEvent(ctx context.Context, id int32) error {
@@ -331,9 +552,9 @@ const updateEvent = `-- name: UpdateEvent :one
 UPDATE events
 SET title = $2, category = $3, location = $4, latitude = $5, longitude = $6,
     start_time = $7, end_time = $8, pinned = $9, position = $10,
-    event_date = $11, notes = $12, updated_at = NOW()
+    event_date = $11, notes = $12, time_zone = $13, updated_at = NOW()
 WHERE id = $1
-RETURNING id, trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, deleted_at, created_at, updated_at
+RETURNING id, trip_id, event_date, title, category, location, latitude, longitude, start_time, end_time, pinned, position, notes, deleted_at, created_at, updated_at, time_zone
 `
 
 type UpdateEventParams struct {
@@ -349,6 +570,7 @@ type UpdateEventParams struct {
 	Position  int32
 	EventDate pgtype.Date
 	Notes     pgtype.Text
+	TimeZone  string
 }
 
 func (q *Queries) UpdateEvent(ctx context.Context, arg UpdateEventParams) (Event, error) {
@@ -365,6 +587,7 @@ func (q *Queries) UpdateEvent(ctx context.Context, arg UpdateEventParams) (Event
 		arg.Position,
 		arg.EventDate,
 		arg.Notes,
+		arg.TimeZone,
 	)
 	var i Event
 	err := row.Scan(
@@ -384,6 +607,21 @@ func (q *Queries) UpdateEvent(ctx context.Context, arg UpdateEventParams) (Event
 		&i.DeletedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.TimeZone,
 	)
 	return i, err
 }
+
+const updateEventPosition = `-- name: UpdateEventPosition :exec
+UPDATE events SET position = $2, updated_at = NOW() WHERE id = $1
+`
+
+type UpdateEventPositionParams struct {
+	ID       int32
+	Position int32
+}
+
+func (q *Queries) UpdateEventPosition(ctx context.Context, arg UpdateEventPositionParams) error {
+	_, err := q.db.Exec(ctx, updateEventPosition, arg.ID, arg.Position)
+	return err
+}
@@ -12,9 +12,9 @@ import (
 )
 
 const createFlightDetails = `-- name: CreateFlightDetails :one
-INSERT INTO flight_details (event_id, airline, flight_number, departure_airport, arrival_airport, departure_terminal, arrival_terminal, departure_gate, arrival_gate, booking_reference)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-RETURNING id, event_id, airline, flight_number, departure_airport, arrival_airport, departure_terminal, arrival_terminal, departure_gate, arrival_gate, booking_reference
+INSERT INTO flight_details (event_id, airline, flight_number, departure_airport, arrival_airport, departure_terminal, arrival_terminal, departure_gate, arrival_gate, booking_reference, departure_time_zone, arrival_time_zone)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING id, event_id, airline, flight_number, departure_airport, arrival_airport, departure_terminal, arrival_terminal, departure_gate, arrival_gate, booking_reference, departure_time_zone, arrival_time_zone
 `
 
 type CreateFlightDetailsParams struct {
@@ -28,6 +28,8 @@ type CreateFlightDetailsParams struct {
 	DepartureGate     pgtype.Text
 	ArrivalGate       pgtype.Text
 	BookingReference  pgtype.Text
+	DepartureTimeZone pgtype.Text
+	ArrivalTimeZone   pgtype.Text
 }
 
 func (q *Queries) CreateFlightDetails(ctx context.Context, arg CreateFlightDetailsParams) (FlightDetail, error) {
@@ -42,6 +44,8 @@ func (q *Queries) CreateFlightDetails(ctx context.Context, arg CreateFlightDetai
 		arg.DepartureGate,
 		arg.ArrivalGate,
 		arg.BookingReference,
+		arg.DepartureTimeZone,
+		arg.ArrivalTimeZone,
 	)
 	var i FlightDetail
 	err := row.Scan(
@@ -56,12 +60,14 @@ func (q *Queries) CreateFlightDetails(ctx context.Context, arg CreateFlightDetai
 		&i.DepartureGate,
 		&i.ArrivalGate,
 		&i.BookingReference,
+		&i.DepartureTimeZone,
+		&i.ArrivalTimeZone,
 	)
 	return i, err
 }
 
 const getFlightDetailsByEventID = `-- name: GetFlightDetailsByEventID :one
-SELECT id, event_id, airline, flight_number, departure_airport, arrival_airport, departure_terminal, arrival_terminal, departure_gate, arrival_gate, booking_reference FROM flight_details WHERE event_id = $1
+SELECT id, event_id, airline, flight_number, departure_airport, arrival_airport, departure_terminal, arrival_terminal, departure_gate, arrival_gate, booking_reference, departure_time_zone, arrival_time_zone FROM flight_details WHERE event_id = $1
 `
 
 func (q *Queries) GetFlightDetailsByEventID(ctx context.Context, eventID int32) (FlightDetail, error) {
@@ -79,12 +85,14 @@ func (q *Queries) GetFlightDetailsByEventID(ctx context.Context, eventID int32)
 		&i.DepartureGate,
 		&i.ArrivalGate,
 		&i.BookingReference,
+		&i.DepartureTimeZone,
+		&i.ArrivalTimeZone,
 	)
 	return i, err
 }
 
 const getFlightDetailsByEventIDs = `-- name: GetFlightDetailsByEventIDs :many
-SELECT id, event_id, airline, flight_number, departure_airport, arrival_airport, departure_terminal, arrival_terminal, departure_gate, arrival_gate, booking_reference FROM flight_details WHERE event_id = ANY($1::int[])
+SELECT id, event_id, airline, flight_number, departure_airport, arrival_airport, departure_terminal, arrival_terminal, departure_gate, arrival_gate, booking_reference, departure_time_zone, arrival_time_zone FROM flight_details WHERE event_id = ANY($1::int[])
 `
 
 func (q *Queries) GetFlightDetailsByEventIDs(ctx context.Context, eventIds []int32) ([]FlightDetail, error) {
@@ -108,6 +116,8 @@ func (q *Queries) GetFlightDetailsByEventIDs(ctx context.Context, eventIds []int
 			&i.DepartureGate,
 			&i.ArrivalGate,
 			&i.BookingReference,
+			&i.DepartureTimeZone,
+			&i.ArrivalTimeZone,
 		); err != nil {
 			return nil, err
 		}
@@ -123,9 +133,9 @@ const updateFlightDetails = `-- name: UpdateFlightDetails :one
 UPDATE flight_details
 SET airline = $2, flight_number = $3, departure_airport = $4, arrival_airport = $5,
     departure_terminal = $6, arrival_terminal = $7, departure_gate = $8, arrival_gate = $9,
-    booking_reference = $10
+    booking_reference = $10, departure_time_zone = $11, arrival_time_zone = $12
 WHERE event_id = $1
-RETURNING id, event_id, airline, flight_number, departure_airport, arrival_airport, departure_terminal, arrival_terminal, departure_gate, arrival_gate, booking_reference
+RETURNING id, event_id, airline, flight_number, departure_airport, arrival_airport, departure_terminal, arrival_terminal, departure_gate, arrival_gate, booking_reference, departure_time_zone, arrival_time_zone
 `
 
 type UpdateFlightDetailsParams struct {
@@ -139,6 +149,8 @@ type UpdateFlightDetailsParams struct {
 	DepartureGate     pgtype.Text
 	ArrivalGate       pgtype.Text
 	BookingReference  pgtype.Text
+	DepartureTimeZone pgtype.Text
+	ArrivalTimeZone   pgtype.Text
 }
 
 func (q *Queries) UpdateFlightDetails(ctx context.Context, arg UpdateFlightDetailsParams) (FlightDetail, error) {
@@ -153,6 +165,8 @@ func (q *Queries) UpdateFlightDetails(ctx context.Context, arg UpdateFlightDetai
 		arg.DepartureGate,
 		arg.ArrivalGate,
 		arg.BookingReference,
+		arg.DepartureTimeZone,
+		arg.ArrivalTimeZone,
 	)
 	var i FlightDetail
 	err := row.Scan(
@@ -167,6 +181,8 @@ func (q *Queries) UpdateFlightDetails(ctx context.Context, arg UpdateFlightDetai
 		&i.DepartureGate,
 		&i.ArrivalGate,
 		&i.BookingReference,
+		&i.DepartureTimeZone,
+		&i.ArrivalTimeZone,
 	)
 	return i, err
 }
@@ -8,6 +8,13 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type CalendarFeed struct {
+	ID        int32
+	TripID    int32
+	Token     string
+	CreatedAt pgtype.Timestamptz
+}
+
 type Event struct {
 	ID        int32
 	TripID    int32
@@ -25,6 +32,7 @@ type Event struct {
 	DeletedAt pgtype.Timestamptz
 	CreatedAt pgtype.Timestamptz
 	UpdatedAt pgtype.Timestamptz
+	TimeZone  string
 }
 
 type FlightDetail struct {
@@ -39,6 +47,26 @@ type FlightDetail struct {
 	DepartureGate     pgtype.Text
 	ArrivalGate       pgtype.Text
 	BookingReference  pgtype.Text
+	DepartureTimeZone pgtype.Text
+	ArrivalTimeZone   pgtype.Text
+}
+
+type FlightSegment struct {
+	ID                int32
+	EventID           int32
+	Position          int32
+	Airline           pgtype.Text
+	FlightNumber      pgtype.Text
+	DepartureAirport  pgtype.Text
+	ArrivalAirport    pgtype.Text
+	DepartureTerminal pgtype.Text
+	ArrivalTerminal   pgtype.Text
+	DepartureGate     pgtype.Text
+	ArrivalGate       pgtype.Text
+	DepartureTime     pgtype.Timestamptz
+	ArrivalTime       pgtype.Timestamptz
+	DepartureTimeZone pgtype.Text
+	ArrivalTimeZone   pgtype.Text
 }
 
 type LodgingDetail struct {
@@ -49,6 +77,13 @@ type LodgingDetail struct {
 	BookingReference pgtype.Text
 }
 
+type Session struct {
+	TokenHash string
+	UserID    pgtype.UUID
+	ExpiresAt pgtype.Timestamptz
+	CreatedAt pgtype.Timestamptz
+}
+
 type TransitDetail struct {
 	ID            int32
 	EventID       int32
@@ -66,4 +101,38 @@ type Trip struct {
 	EndDate     pgtype.Date
 	CreatedAt   pgtype.Timestamptz
 	UpdatedAt   pgtype.Timestamptz
+	TimeZone    string
+}
+
+type TripInvitation struct {
+	ID        int32
+	TripID    int32
+	Invitee   string
+	Role      string
+	InvitedBy string
+	CreatedAt pgtype.Timestamptz
+}
+
+type TripMember struct {
+	TripID    int32
+	UserID    pgtype.UUID
+	Username  string
+	Role      string
+	CreatedAt pgtype.Timestamptz
+}
+
+type TripShare struct {
+	ID        int32
+	TripID    int32
+	Token     string
+	ExpiresAt pgtype.Timestamptz
+	CreatedAt pgtype.Timestamptz
+}
+
+type User struct {
+	ID           pgtype.UUID
+	Username     string
+	Email        pgtype.Text
+	PasswordHash string
+	CreatedAt    pgtype.Timestamptz
 }
@@ -11,6 +11,30 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const claimOwnerlessTrips = `-- name: ClaimOwnerlessTrips :execrows
+WITH claimed AS (
+    UPDATE trips SET user_id = $1::uuid, updated_at = NOW()
+    WHERE user_id IS NULL
+    RETURNING id
+)
+INSERT INTO trip_members (trip_id, user_id, username, role)
+SELECT claimed.id, $1::uuid, $2::text, 'owner'
+FROM claimed
+`
+
+type ClaimOwnerlessTripsParams struct {
+	UserID   pgtype.UUID
+	Username string
+}
+
+func (q *Queries) ClaimOwnerlessTrips(ctx context.Context, arg ClaimOwnerlessTripsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, claimOwnerlessTrips, arg.UserID, arg.Username)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const countEventsByTripAndDateRange = `-- name: CountEventsByTripAndDateRange :one
 SELECT COUNT(*)::int AS event_count
 FROM events
@@ -31,10 +55,21 @@ func (q *Queries) CountEventsByTripAndDateRange(ctx context.Context, arg CountEv
 	return event_count, err
 }
 
+const countOwnerlessTrips = `-- name: CountOwnerlessTrips :one
+SELECT COUNT(*)::int AS trip_count FROM trips WHERE user_id IS NULL
+`
+
+func (q *Queries) CountOwnerlessTrips(ctx context.Context) (int32, error) {
+	row := q.db.QueryRow(ctx, countOwnerlessTrips)
+	var trip_count int32
+	err := row.Scan(&trip_count)
+	return trip_count, err
+}
+
 const createTrip = `-- name: CreateTrip :one
-INSERT INTO trips (name, destination, start_date, end_date, user_id)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, user_id, name, destination, start_date, end_date, created_at, updated_at
+INSERT INTO trips (name, destination, start_date, end_date, user_id, time_zone)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, user_id, name, destination, start_date, end_date, created_at, updated_at, time_zone
 `
 
 type CreateTripParams struct {
@@ -43,6 +78,7 @@ type CreateTripParams struct {
 	StartDate   pgtype.Date
 	EndDate     pgtype.Date
 	UserID      pgtype.UUID
+	TimeZone    string
 }
 
 func (q *Queries) CreateTrip(ctx context.Context, arg CreateTripParams) (Trip, error) {
@@ -52,6 +88,7 @@ func (q *Queries) CreateTrip(ctx context.Context, arg CreateTripParams) (Trip, e
 		arg.StartDate,
 		arg.EndDate,
 		arg.UserID,
+		arg.TimeZone,
 	)
 	var i Trip
 	err := row.Scan(
@@ -63,6 +100,7 @@ func (q *Queries) CreateTrip(ctx context.Context, arg CreateTripParams) (Trip, e
 		&i.EndDate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.TimeZone,
 	)
 	return i, err
 }
@@ -80,7 +118,7 @@ func (q *Queries) DeleteTrip(ctx context.Context, id int32) (int64, error) {
 }
 
 const getTripByID = `-- name: GetTripByID :one
-SELECT id, user_id, name, destination, start_date, end_date, created_at, updated_at FROM trips WHERE id = $1
+SELECT id, user_id, name, destination, start_date, end_date, created_at, updated_at, time_zone FROM trips WHERE id = $1
 `
 
 func (q *Queries) GetTripByID(ctx context.Context, id int32) (Trip, error) {
@@ -95,13 +133,15 @@ func (q *Queries) GetTripByID(ctx context.Context, id int32) (Trip, error) {
 		&i.EndDate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.TimeZone,
 	)
 	return i, err
 }
 
 const listTrips = `-- name: ListTrips :many
-SELECT id, user_id, name, destination, start_date, end_date, created_at, updated_at FROM trips
-WHERE (user_id = $1 OR $1 IS NULL)
+SELECT id, user_id, name, destination, start_date, end_date, created_at, updated_at, time_zone FROM trips
+WHERE (user_id IS NOT DISTINCT FROM $1::uuid
+    OR id IN (SELECT trip_id FROM trip_members WHERE trip_members.user_id = $1))
 ORDER BY start_date DESC, created_at DESC
 `
 
@@ -123,6 +163,7 @@ func (q *Queries) ListTrips(ctx context.Context, userID pgtype.UUID) ([]Trip, er
 			&i.EndDate,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.TimeZone,
 		); err != nil {
 			return nil, err
 		}
@@ -136,9 +177,9 @@ func (q *Queries) ListTrips(ctx context.Context, userID pgtype.UUID) ([]Trip, er
 
 const updateTrip = `-- name: UpdateTrip :one
 UPDATE trips
-SET name = $2, destination = $3, start_date = $4, end_date = $5, updated_at = NOW()
+SET name = $2, destination = $3, start_date = $4, end_date = $5, time_zone = $6, updated_at = NOW()
 WHERE id = $1
-RETURNING id, user_id, name, destination, start_date, end_date, created_at, updated_at
+RETURNING id, user_id, name, destination, start_date, end_date, created_at, updated_at, time_zone
 `
 
 type UpdateTripParams struct {
@@ -147,6 +188,7 @@ type UpdateTripParams struct {
 	Destination pgtype.Text
 	StartDate   pgtype.Date
 	EndDate     pgtype.Date
+	TimeZone    string
 }
 
 func (q *Queries) UpdateTrip(ctx context.Context, arg UpdateTripParams) (Trip, error) {
@@ -156,6 +198,7 @@ func (q *Queries) UpdateTrip(ctx context.Context, arg UpdateTripParams) (Trip, e
 		arg.Destination,
 		arg.StartDate,
 		arg.EndDate,
+		arg.TimeZone,
 	)
 	var i Trip
 	err := row.Scan(
@@ -167,6 +210,7 @@ func (q *Queries) UpdateTrip(ctx context.Context, arg UpdateTripParams) (Trip, e
 		&i.EndDate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.TimeZone,
 	)
 	return i, err
 }
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/repository/sqlcgen"
+)
+
+var _ domain.TripImporter = (*TripImportStore)(nil)
+
+// TripImportStore writes a whole trip at once, for restoring exported trips.
+type TripImportStore struct {
+	db      *pgxpool.Pool
+	flight  *FlightDetailsStore
+	lodging *LodgingDetailsStore
+	transit *TransitDetailsStore
+}
+
+func NewTripImportStore(db *pgxpool.Pool, flightStore *FlightDetailsStore, lodgingStore *LodgingDetailsStore, transitStore *TransitDetailsStore) *TripImportStore {
+	return &TripImportStore{
+		db:      db,
+		flight:  flightStore,
+		lodging: lodgingStore,
+		transit: transitStore,
+	}
+}
+
+// Import inserts the trip and its events in a single transaction, so a failed
+// import leaves no half-restored trip behind. Events are stored with the
+// positions they carry rather than appended, keeping their order within a day.
+func (s *TripImportStore) Import(ctx context.Context, trip *domain.Trip, events []domain.Event) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	txq := sqlcgen.New(tx)
+	if err = insertTrip(ctx, txq, trip); err != nil {
+		return err
+	}
+
+	for i := range events {
+		event := &events[i]
+		event.TripID = trip.ID
+		flight, lodging, transit := event.Flight, event.Lodging, event.Transit
+
+		eventRow, err := txq.CreateEvent(ctx, toCreateEventParams(event, int32(event.Position)))
+		if err != nil {
+			return fmt.Errorf("inserting event %q: %w", event.Title, err)
+		}
+		*event = eventRowToDomain(&eventRow)
+
+		switch {
+		case event.Category == domain.CategoryFlight && flight != nil:
+			if event.Flight, err = s.flight.Create(ctx, txq, event.ID, flight); err != nil {
+				return err
+			}
+		case event.Category == domain.CategoryLodging && lodging != nil:
+			if event.Lodging, err = s.lodging.Create(ctx, txq, event.ID, lodging); err != nil {
+				return err
+			}
+		case event.Category == domain.CategoryTransit && transit != nil:
+			if event.Transit, err = s.transit.Create(ctx, txq, event.ID, transit); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit(ctx)
+}
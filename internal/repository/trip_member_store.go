@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/repository/sqlcgen"
+)
+
+var _ domain.TripMemberRepository = (*TripMemberStore)(nil)
+
+type TripMemberStore struct {
+	queries *sqlcgen.Queries
+}
+
+func NewTripMemberStore(db *pgxpool.Pool) *TripMemberStore {
+	return &TripMemberStore{
+		queries: sqlcgen.New(db),
+	}
+}
+
+// SetRole changes a member's role. The owner's role never changes here.
+func (s *TripMemberStore) SetRole(ctx context.Context, tripID int, userID string, role domain.TripRole) (*domain.TripMember, error) {
+	uid := toPgUUID(userID)
+	if !uid.Valid {
+		return nil, domain.ErrNotFound
+	}
+	row, err := s.queries.UpdateTripMemberRole(ctx, sqlcgen.UpdateTripMemberRoleParams{
+		TripID: int32(tripID),
+		UserID: uid,
+		Role:   string(role),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	member := tripMemberRowToDomain(&row)
+	return &member, nil
+}
+
+// Remove takes a member off the trip. The owner cannot be removed.
+func (s *TripMemberStore) Remove(ctx context.Context, tripID int, userID string) error {
+	uid := toPgUUID(userID)
+	if !uid.Valid {
+		return domain.ErrNotFound
+	}
+	rows, err := s.queries.DeleteTripMember(ctx, sqlcgen.DeleteTripMemberParams{
+		TripID: int32(tripID),
+		UserID: uid,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (s *TripMemberStore) CreateInvitation(ctx context.Context, invitation *domain.TripInvitation) error {
+	row, err := s.queries.CreateTripInvitation(ctx, sqlcgen.CreateTripInvitationParams{
+		TripID:    int32(invitation.TripID),
+		Invitee:   invitation.Invitee,
+		Role:      string(invitation.Role),
+		InvitedBy: invitation.InvitedBy,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return domain.ErrConflict
+		}
+		return err
+	}
+	*invitation = tripInvitationRowToDomain(&row)
+	return nil
+}
+
+func (s *TripMemberStore) GetInvitation(ctx context.Context, id int) (*domain.TripInvitation, error) {
+	row, err := s.queries.GetTripInvitation(ctx, int32(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	// Both queries select the same columns
+	invitation := addressedInvitationRowToDomain((*sqlcgen.ListTripInvitationsForRow)(&row))
+	return &invitation, nil
+}
+
+func (s *TripMemberStore) ListInvitationsByTrip(ctx context.Context, tripID int) ([]domain.TripInvitation, error) {
+	rows, err := s.queries.ListTripInvitationsByTrip(ctx, int32(tripID))
+	if err != nil {
+		return nil, err
+	}
+
+	invitations := make([]domain.TripInvitation, len(rows))
+	for i := range rows {
+		invitations[i] = tripInvitationRowToDomain(&rows[i])
+	}
+	return invitations, nil
+}
+
+func (s *TripMemberStore) ListInvitationsFor(ctx context.Context, invitees []string) ([]domain.TripInvitation, error) {
+	rows, err := s.queries.ListTripInvitationsFor(ctx, invitees)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations := make([]domain.TripInvitation, len(rows))
+	for i := range rows {
+		invitations[i] = addressedInvitationRowToDomain(&rows[i])
+	}
+	return invitations, nil
+}
+
+func (s *TripMemberStore) DeleteInvitation(ctx context.Context, tripID, id int) error {
+	rows, err := s.queries.DeleteTripInvitation(ctx, sqlcgen.DeleteTripInvitationParams{
+		ID:     int32(id),
+		TripID: int32(tripID),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// AcceptInvitation deletes the invitation and adds member in one statement.
+// Someone already on the trip is reported as ErrConflict and keeps the
+// invitation.
+func (s *TripMemberStore) AcceptInvitation(ctx context.Context, id int, member *domain.TripMember) error {
+	row, err := s.queries.AcceptTripInvitation(ctx, sqlcgen.AcceptTripInvitationParams{
+		ID:       int32(id),
+		UserID:   toPgUUID(member.UserID),
+		Username: member.Username,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return domain.ErrNotFound
+		case errors.As(err, &pgErr) && pgErr.Code == uniqueViolation:
+			return domain.ErrConflict
+		}
+		return err
+	}
+	*member = tripMemberRowToDomain(&row)
+	return nil
+}
+
+func tripMemberRowToDomain(row *sqlcgen.TripMember) domain.TripMember {
+	return domain.TripMember{
+		TripID:    int(row.TripID),
+		UserID:    row.UserID.String(),
+		Username:  row.Username,
+		Role:      domain.TripRole(row.Role),
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
+
+func tripInvitationRowToDomain(row *sqlcgen.TripInvitation) domain.TripInvitation {
+	return domain.TripInvitation{
+		ID:        int(row.ID),
+		TripID:    int(row.TripID),
+		Invitee:   row.Invitee,
+		Role:      domain.TripRole(row.Role),
+		InvitedBy: row.InvitedBy,
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
+
+// addressedInvitationRowToDomain maps an invitation joined with its trip's
+// name, as its invitee sees it.
+func addressedInvitationRowToDomain(row *sqlcgen.ListTripInvitationsForRow) domain.TripInvitation {
+	invitation := tripInvitationRowToDomain(&sqlcgen.TripInvitation{
+		ID:        row.ID,
+		TripID:    row.TripID,
+		Invitee:   row.Invitee,
+		Role:      row.Role,
+		InvitedBy: row.InvitedBy,
+		CreatedAt: row.CreatedAt,
+	})
+	invitation.TripName = row.TripName
+	return invitation
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/repository/sqlcgen"
+)
+
+var _ domain.TripShareRepository = (*TripShareStore)(nil)
+
+type TripShareStore struct {
+	queries *sqlcgen.Queries
+}
+
+func NewTripShareStore(db *pgxpool.Pool) *TripShareStore {
+	return &TripShareStore{
+		queries: sqlcgen.New(db),
+	}
+}
+
+func (s *TripShareStore) Create(ctx context.Context, share *domain.TripShare) error {
+	row, err := s.queries.CreateTripShare(ctx, sqlcgen.CreateTripShareParams{
+		TripID:    int32(share.TripID),
+		Token:     share.Token,
+		ExpiresAt: toOptionalPgTimestamptz(share.ExpiresAt),
+	})
+	if err != nil {
+		return err
+	}
+	*share = tripShareRowToDomain(&row)
+	return nil
+}
+
+func (s *TripShareStore) GetByToken(ctx context.Context, token string) (*domain.TripShare, error) {
+	row, err := s.queries.GetTripShareByToken(ctx, token)
+	return tripShareResult(&row, err)
+}
+
+func (s *TripShareStore) ListByTrip(ctx context.Context, tripID int) ([]domain.TripShare, error) {
+	rows, err := s.queries.ListTripSharesByTrip(ctx, int32(tripID))
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]domain.TripShare, len(rows))
+	for i := range rows {
+		shares[i] = tripShareRowToDomain(&rows[i])
+	}
+	return shares, nil
+}
+
+func (s *TripShareStore) Delete(ctx context.Context, tripID, id int) error {
+	rows, err := s.queries.DeleteTripShare(ctx, sqlcgen.DeleteTripShareParams{
+		ID:     int32(id),
+		TripID: int32(tripID),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (s *TripShareStore) Rotate(ctx context.Context, tripID, id int, token string) (*domain.TripShare, error) {
+	row, err := s.queries.RotateTripShareToken(ctx, sqlcgen.RotateTripShareTokenParams{
+		ID:     int32(id),
+		TripID: int32(tripID),
+		Token:  token,
+	})
+	return tripShareResult(&row, err)
+}
+
+func (s *TripShareStore) SetExpiry(ctx context.Context, tripID, id int, expiresAt *time.Time) (*domain.TripShare, error) {
+	row, err := s.queries.SetTripShareExpiry(ctx, sqlcgen.SetTripShareExpiryParams{
+		ID:        int32(id),
+		TripID:    int32(tripID),
+		ExpiresAt: toOptionalPgTimestamptz(expiresAt),
+	})
+	return tripShareResult(&row, err)
+}
+
+// tripShareResult maps the outcome of a single-row share query.
+func tripShareResult(row *sqlcgen.TripShare, err error) (*domain.TripShare, error) {
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	share := tripShareRowToDomain(row)
+	return &share, nil
+}
+
+func tripShareRowToDomain(row *sqlcgen.TripShare) domain.TripShare {
+	return domain.TripShare{
+		ID:        int(row.ID),
+		TripID:    int(row.TripID),
+		Token:     row.Token,
+		ExpiresAt: fromPgTimestamptz(row.ExpiresAt),
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -13,31 +14,35 @@ import (
 	"github.com/simopzz/traccia/internal/repository/sqlcgen"
 )
 
-var _ domain.TripRepository = (*TripStore)(nil)
+var (
+	_ domain.TripRepository = (*TripStore)(nil)
+	_ domain.TripClaimer    = (*TripStore)(nil)
+)
 
 type TripStore struct {
+	db      *pgxpool.Pool
 	queries *sqlcgen.Queries
 }
 
 func NewTripStore(db *pgxpool.Pool) *TripStore {
 	return &TripStore{
+		db:      db,
 		queries: sqlcgen.New(db),
 	}
 }
 
+// Create inserts the trip and its owner's membership in one transaction.
 func (s *TripStore) Create(ctx context.Context, trip *domain.Trip) error {
-	row, err := s.queries.CreateTrip(ctx, sqlcgen.CreateTripParams{
-		Name:        trip.Name,
-		Destination: toPgText(trip.Destination),
-		StartDate:   toPgDate(trip.StartDate),
-		EndDate:     toPgDate(trip.EndDate),
-		UserID:      pgtype.UUID{},
-	})
+	tx, err := s.db.Begin(ctx)
 	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err = insertTrip(ctx, sqlcgen.New(tx), trip); err != nil {
 		return err
 	}
-	*trip = tripRowToDomain(&row)
-	return nil
+	return tx.Commit(ctx)
 }
 
 func (s *TripStore) GetByID(ctx context.Context, id int) (*domain.Trip, error) {
@@ -49,14 +54,23 @@ func (s *TripStore) GetByID(ctx context.Context, id int) (*domain.Trip, error) {
 		return nil, err
 	}
 	trip := tripRowToDomain(&row)
+
+	members, err := s.queries.ListTripMembers(ctx, row.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range members {
+		trip.Members = append(trip.Members, tripMemberRowToDomain(&members[i]))
+	}
 	return &trip, nil
 }
 
 func (s *TripStore) List(ctx context.Context, userID *string) ([]domain.Trip, error) {
 	var uid pgtype.UUID
 	if userID != nil {
-		if err := uid.Scan(*userID); err == nil {
-			uid.Valid = true
+		// An ID that does not parse must not fall back to ownerless trips
+		if uid = toPgUUID(*userID); !uid.Valid {
+			return []domain.Trip{}, nil
 		}
 	}
 
@@ -66,8 +80,23 @@ func (s *TripStore) List(ctx context.Context, userID *string) ([]domain.Trip, er
 	}
 
 	trips := make([]domain.Trip, len(rows))
+	ids := make([]int32, len(rows))
+	byID := make(map[int]*domain.Trip, len(rows))
 	for i := range rows {
 		trips[i] = tripRowToDomain(&rows[i])
+		ids[i] = rows[i].ID
+		byID[trips[i].ID] = &trips[i]
+	}
+
+	members, err := s.queries.ListTripMembersByTrips(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range members {
+		member := tripMemberRowToDomain(&members[i])
+		if trip, ok := byID[member.TripID]; ok {
+			trip.Members = append(trip.Members, member)
+		}
 	}
 	return trips, nil
 }
@@ -86,12 +115,14 @@ func (s *TripStore) Update(ctx context.Context, id int, updater func(*domain.Tri
 		Destination: toPgText(updated.Destination),
 		StartDate:   toPgDate(updated.StartDate),
 		EndDate:     toPgDate(updated.EndDate),
+		TimeZone:    updated.TimeZone,
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	result := tripRowToDomain(&row)
+	result.Members = trip.Members
 	return &result, nil
 }
 
@@ -138,6 +169,72 @@ func (s *TripStore) CountEventsByTripGroupedByDate(ctx context.Context, tripID i
 	return result, nil
 }
 
+func (s *TripStore) CountOwnerless(ctx context.Context) (int, error) {
+	count, err := s.queries.CountOwnerlessTrips(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// ClaimOwnerless sets the owner of every ownerless trip and records their
+// membership, in one statement.
+func (s *TripStore) ClaimOwnerless(ctx context.Context, owner domain.TripMember) (int, error) {
+	uid := toPgUUID(owner.UserID)
+	if !uid.Valid {
+		return 0, fmt.Errorf("%w: owner is not a user ID", domain.ErrInvalidInput)
+	}
+	rows, err := s.queries.ClaimOwnerlessTrips(ctx, sqlcgen.ClaimOwnerlessTripsParams{
+		UserID:   uid,
+		Username: owner.Username,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(rows), nil
+}
+
+// insertTrip inserts the trip and, if trip.Members names an owner, their
+// membership. Callers run it inside their transaction.
+func insertTrip(ctx context.Context, q *sqlcgen.Queries, trip *domain.Trip) error {
+	var owner *domain.TripMember
+	for i := range trip.Members {
+		if trip.Members[i].Role == domain.RoleOwner {
+			owner = &trip.Members[i]
+		}
+	}
+
+	row, err := q.CreateTrip(ctx, sqlcgen.CreateTripParams{
+		Name:        trip.Name,
+		Destination: toPgText(trip.Destination),
+		StartDate:   toPgDate(trip.StartDate),
+		EndDate:     toPgDate(trip.EndDate),
+		UserID:      toPgUUID(trip.UserID),
+		TimeZone:    trip.TimeZone,
+	})
+	if err != nil {
+		return fmt.Errorf("inserting trip: %w", err)
+	}
+
+	var members []domain.TripMember
+	if owner != nil && row.UserID.Valid {
+		memberRow, err := q.AddTripMember(ctx, sqlcgen.AddTripMemberParams{
+			TripID:   row.ID,
+			UserID:   row.UserID,
+			Username: owner.Username,
+			Role:     string(domain.RoleOwner),
+		})
+		if err != nil {
+			return fmt.Errorf("inserting trip owner: %w", err)
+		}
+		members = append(members, tripMemberRowToDomain(&memberRow))
+	}
+
+	*trip = tripRowToDomain(&row)
+	trip.Members = members
+	return nil
+}
+
 func tripRowToDomain(row *sqlcgen.Trip) domain.Trip {
 	return domain.Trip{
 		ID:          int(row.ID),
@@ -145,6 +242,8 @@ func tripRowToDomain(row *sqlcgen.Trip) domain.Trip {
 		Destination: row.Destination.String,
 		StartDate:   row.StartDate.Time,
 		EndDate:     row.EndDate.Time,
+		TimeZone:    row.TimeZone,
+		UserID:      row.UserID.String(),
 		CreatedAt:   row.CreatedAt.Time,
 		UpdatedAt:   row.UpdatedAt.Time,
 	}
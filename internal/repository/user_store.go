@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/repository/sqlcgen"
+)
+
+var _ domain.UserRepository = (*UserStore)(nil)
+
+// uniqueViolation is the Postgres error code for a duplicate key.
+const uniqueViolation = "23505"
+
+type UserStore struct {
+	queries *sqlcgen.Queries
+}
+
+func NewUserStore(db *pgxpool.Pool) *UserStore {
+	return &UserStore{
+		queries: sqlcgen.New(db),
+	}
+}
+
+func (s *UserStore) Create(ctx context.Context, user *domain.User) error {
+	row, err := s.queries.CreateUser(ctx, sqlcgen.CreateUserParams{
+		Username:     user.Username,
+		Email:        pgtype.Text{String: user.Email, Valid: user.Email != ""},
+		PasswordHash: user.PasswordHash,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return domain.ErrConflict
+		}
+		return err
+	}
+	*user = userRowToDomain(&row)
+	return nil
+}
+
+func (s *UserStore) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	uid := toPgUUID(id)
+	if !uid.Valid {
+		return nil, domain.ErrNotFound
+	}
+	row, err := s.queries.GetUserByID(ctx, uid)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	user := userRowToDomain(&row)
+	return &user, nil
+}
+
+func (s *UserStore) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	row, err := s.queries.GetUserByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	user := userRowToDomain(&row)
+	return &user, nil
+}
+
+func userRowToDomain(row *sqlcgen.User) domain.User {
+	return domain.User{
+		ID:           row.ID.String(),
+		Username:     row.Username,
+		Email:        row.Email.String,
+		PasswordHash: row.PasswordHash,
+		CreatedAt:    row.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+// tripAccess is what a caller needs to do with a trip.
+type tripAccess int
+
+const (
+	// accessRead views the trip and its events.
+	accessRead tripAccess = iota + 1
+	// accessEdit changes the trip or its events.
+	accessEdit
+	// accessManage deletes the trip and decides who else can reach it: its
+	// members, share links and calendar feeds.
+	accessManage
+)
+
+// shareGrantKey marks a context opened through a share link; its value is the
+// ID of the shared trip.
+type shareGrantKey struct{}
+
+// withShareGrant returns a context that may read tripID, and nothing else,
+// without a principal.
+func withShareGrant(ctx context.Context, tripID int) context.Context {
+	return context.WithValue(ctx, shareGrantKey{}, tripID)
+}
+
+// RoleOf returns the role the caller in ctx has on trip, or "" if they may
+// not reach it. Signed-in users have the role they were given as members. A
+// share link views only the trip it was issued for. Other callers without a
+// principal, such as the seed command, own the trips that have no owner.
+func RoleOf(ctx context.Context, trip *domain.Trip) domain.TripRole {
+	if sharedID, ok := ctx.Value(shareGrantKey{}).(int); ok {
+		if sharedID != trip.ID {
+			return ""
+		}
+		return domain.RoleViewer
+	}
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		if trip.UserID == "" {
+			return domain.RoleOwner
+		}
+		return ""
+	}
+	return trip.RoleOf(p.UserID)
+}
+
+// canAccessTrip reports whether the caller in ctx may use trip as need says:
+// viewers read, editors also edit, and only the owner manages.
+func canAccessTrip(ctx context.Context, trip *domain.Trip, need tripAccess) bool {
+	switch RoleOf(ctx, trip) {
+	case domain.RoleOwner:
+		return true
+	case domain.RoleEditor:
+		return need <= accessEdit
+	case domain.RoleViewer:
+		return need == accessRead
+	}
+	return false
+}
+
+// authorizeTrip loads trip id for the caller in ctx. A trip they may not use
+// is reported as ErrNotFound, exactly like one that does not exist, so that
+// other users' trip IDs cannot be probed.
+func authorizeTrip(ctx context.Context, trips domain.TripRepository, id int, need tripAccess) (*domain.Trip, error) {
+	trip, err := trips.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccessTrip(ctx, trip, need) {
+		return nil, domain.ErrNotFound
+	}
+	return trip, nil
+}
@@ -0,0 +1,200 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+const (
+	userA = "00000000-0000-0000-0000-00000000000a"
+	userB = "00000000-0000-0000-0000-00000000000b"
+)
+
+func actingAs(userID string) context.Context {
+	return service.WithPrincipal(context.Background(), service.Principal{UserID: userID})
+}
+
+// twoUsers holds services over a trip of user A and a trip of user B, each
+// with one event.
+type twoUsers struct {
+	trips    *service.TripService
+	events   *service.EventService
+	feeds    *service.CalendarFeedService
+	archives *service.ArchiveService
+	tripRepo *mockTripRepo
+	tripA    int
+	tripB    int
+	eventB   int
+}
+
+func newTwoUsers(t *testing.T) *twoUsers {
+	t.Helper()
+	tripRepo := newMockTripRepo()
+	eventRepo := newMockEventRepo()
+	f := &twoUsers{
+		trips:    service.NewTripService(tripRepo),
+		events:   service.NewEventService(eventRepo, tripRepo),
+		feeds:    service.NewCalendarFeedService(newMockFeedRepo(), tripRepo, eventRepo),
+		archives: service.NewArchiveService(tripRepo, eventRepo, nil),
+		tripRepo: tripRepo,
+	}
+
+	for _, userID := range []string{userA, userB} {
+		ctx := actingAs(userID)
+		trip, err := f.trips.Create(ctx, &service.CreateTripInput{
+			Name:      "Trip of " + userID,
+			StartDate: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC),
+		})
+		if err != nil {
+			t.Fatalf("creating trip: %v", err)
+		}
+		event, err := f.events.Create(ctx, &service.CreateEventInput{
+			TripID:    trip.ID,
+			Title:     "Museum",
+			StartTime: at(10, 0),
+			EndTime:   at(12, 0),
+		})
+		if err != nil {
+			t.Fatalf("creating event: %v", err)
+		}
+		if userID == userA {
+			f.tripA = trip.ID
+		}
+		f.tripB, f.eventB = trip.ID, event.ID
+	}
+	return f
+}
+
+func TestAccess_OtherUsersTripIsNotFound(t *testing.T) {
+	f := newTwoUsers(t)
+	trips, events, feeds, archives, tripB, eventB := f.trips, f.events, f.feeds, f.archives, f.tripB, f.eventB
+	ctx := actingAs(userA)
+	day := at(0, 0).Truncate(24 * time.Hour)
+	name := "Mine now"
+
+	tests := []struct {
+		op   func() error
+		name string
+	}{
+		{name: "TripService.GetByID", op: func() error { _, err := trips.GetByID(ctx, tripB); return err }},
+		{name: "TripService.List", op: func() error {
+			list, err := trips.List(ctx)
+			if err != nil {
+				return err
+			}
+			for _, trip := range list {
+				if trip.ID == tripB {
+					return nil
+				}
+			}
+			return domain.ErrNotFound
+		}},
+		{name: "TripService.Update", op: func() error {
+			_, err := trips.Update(ctx, tripB, service.UpdateTripInput{Name: &name})
+			return err
+		}},
+		{name: "TripService.Delete", op: func() error { return trips.Delete(ctx, tripB) }},
+		{name: "EventService.Create", op: func() error {
+			_, err := events.Create(ctx, &service.CreateEventInput{TripID: tripB, Title: "Planted", StartTime: at(13, 0), EndTime: at(14, 0)})
+			return err
+		}},
+		{name: "EventService.GetByID", op: func() error { _, err := events.GetByID(ctx, eventB); return err }},
+		{name: "EventService.GetByTripAndID", op: func() error { _, err := events.GetByTripAndID(ctx, tripB, eventB); return err }},
+		{name: "EventService.ListByTrip", op: func() error { _, err := events.ListByTrip(ctx, tripB); return err }},
+		{name: "EventService.ListByTripAndDate", op: func() error { _, err := events.ListByTripAndDate(ctx, tripB, day); return err }},
+		{name: "EventService.CountByTrip", op: func() error { _, err := events.CountByTrip(ctx, tripB); return err }},
+		{name: "EventService.DayConflicts", op: func() error { _, err := events.DayConflicts(ctx, tripB, day); return err }},
+		{name: "EventService.ListStays", op: func() error { _, err := events.ListStays(ctx, tripB); return err }},
+		{name: "EventService.NightsWithoutLodging", op: func() error { _, err := events.NightsWithoutLodging(ctx, tripB); return err }},
+		{name: "EventService.Update", op: func() error {
+			_, err := events.Update(ctx, eventB, &service.UpdateEventInput{Title: &name})
+			return err
+		}},
+		{name: "EventService.Delete", op: func() error { return events.Delete(ctx, eventB) }},
+		{name: "EventService.Restore", op: func() error { _, err := events.Restore(ctx, tripB, eventB); return err }},
+		{name: "EventService.ReorderEvents", op: func() error {
+			_, err := events.ReorderEvents(ctx, tripB, day, []int{eventB})
+			return err
+		}},
+		{name: "EventService.MoveEventToDay", op: func() error {
+			_, err := events.MoveEventToDay(ctx, eventB, day.AddDate(0, 0, 1))
+			return err
+		}},
+		{name: "CalendarFeedService.Create", op: func() error { _, err := feeds.Create(ctx, tripB); return err }},
+		{name: "CalendarFeedService.ListByTrip", op: func() error { _, err := feeds.ListByTrip(ctx, tripB); return err }},
+		{name: "CalendarFeedService.Revoke", op: func() error { return feeds.Revoke(ctx, tripB, 1) }},
+		{name: "ArchiveService.Export", op: func() error { _, err := archives.Export(ctx, tripB); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.op(); !errors.Is(err, domain.ErrNotFound) {
+				t.Errorf("error = %v, want ErrNotFound", err)
+			}
+		})
+	}
+
+	// Nothing of B's changed
+	ctxB := actingAs(userB)
+	trip, err := trips.GetByID(ctxB, tripB)
+	if err != nil || trip.Name != "Trip of "+userB {
+		t.Errorf("B's trip = %+v, %v, want it untouched", trip, err)
+	}
+	tripEvents, err := events.ListByTrip(ctxB, tripB)
+	if err != nil || len(tripEvents) != 1 {
+		t.Fatalf("B's events = %+v, %v, want the one event", tripEvents, err)
+	}
+	if e := tripEvents[0]; e.ID != eventB || e.Title != "Museum" || !e.EventDate.Equal(day) {
+		t.Errorf("B's event = %+v, want it untouched", e)
+	}
+	if defaults := events.SuggestDefaults(ctx, tripB, day, domain.CategoryActivity); defaults.StartTime.Hour() != 9 {
+		t.Errorf("SuggestDefaults() start = %v, want the 9:00 fallback rather than B's schedule", defaults.StartTime)
+	}
+}
+
+func TestAccess_OwnTrip(t *testing.T) {
+	f := newTwoUsers(t)
+	trips, events, tripB, eventB := f.trips, f.events, f.tripB, f.eventB
+	ctx := actingAs(userB)
+
+	if _, err := trips.GetByID(ctx, tripB); err != nil {
+		t.Errorf("GetByID() error = %v", err)
+	}
+	if list, err := trips.List(ctx); err != nil || len(list) != 1 || list[0].ID != tripB {
+		t.Errorf("List() = %+v, %v, want only B's trip", list, err)
+	}
+	title := "Gallery"
+	if _, err := events.Update(ctx, eventB, &service.UpdateEventInput{Title: &title}); err != nil {
+		t.Errorf("Update() error = %v", err)
+	}
+	if err := events.Delete(ctx, eventB); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if err := trips.Delete(ctx, tripB); err != nil {
+		t.Errorf("Delete() trip error = %v", err)
+	}
+}
+
+func TestAccess_Anonymous(t *testing.T) {
+	f := newTwoUsers(t)
+	if _, err := f.trips.GetByID(context.Background(), f.tripB); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetByID() without principal error = %v, want ErrNotFound", err)
+	}
+
+	ownerless, err := f.trips.Create(context.Background(), &service.CreateTripInput{
+		Name:      "Before accounts",
+		StartDate: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if list, err := f.trips.List(context.Background()); err != nil || len(list) != 1 || list[0].ID != ownerless.ID {
+		t.Errorf("List() without principal = %+v, %v, want only the ownerless trip", list, err)
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+// airportSearchLimit caps the suggestions offered while typing an airport.
+const airportSearchLimit = 8
+
+// AirportService looks up airports for flight details.
+type AirportService struct {
+	directory domain.AirportDirectory
+}
+
+func NewAirportService(directory domain.AirportDirectory) *AirportService {
+	return &AirportService{directory: directory}
+}
+
+// Lookup finds an airport by IATA or ICAO code. Unknown codes are reported
+// as ErrNotFound.
+func (s *AirportService) Lookup(code string) (*domain.Airport, error) {
+	airport, ok := s.directory.Lookup(code)
+	if !ok {
+		return nil, fmt.Errorf("airport %q: %w", code, domain.ErrNotFound)
+	}
+	return airport, nil
+}
+
+// Search suggests airports for a partly typed code, city or name.
+func (s *AirportService) Search(query string) []domain.Airport {
+	return s.directory.Search(query, airportSearchLimit)
+}
+
+// CompleteFlight rewrites ICAO codes in fd to the IATA codes travellers know
+// and fills in time zones left empty, for the journey and each of its
+// segments. Airport names, such as those taken from a booking, are kept as
+// free text, but a code the directory does not know is reported as
+// ErrInvalidInput: it is far more likely a typo than a real airport. It
+// returns the departure airport, or nil when fd names none the directory
+// knows.
+func (s *AirportService) CompleteFlight(fd *domain.FlightDetails) (*domain.Airport, error) {
+	departure, err := s.completeAirport(&fd.DepartureAirport, &fd.DepartureTimeZone)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = s.completeAirport(&fd.ArrivalAirport, &fd.ArrivalTimeZone); err != nil {
+		return nil, err
+	}
+	for i := range fd.Segments {
+		seg := &fd.Segments[i]
+		if _, err = s.completeAirport(&seg.DepartureAirport, &seg.DepartureTimeZone); err != nil {
+			return nil, fmt.Errorf("segment %d: %w", i+1, err)
+		}
+		if _, err = s.completeAirport(&seg.ArrivalAirport, &seg.ArrivalTimeZone); err != nil {
+			return nil, fmt.Errorf("segment %d: %w", i+1, err)
+		}
+	}
+	return departure, nil
+}
+
+// airportCodePattern matches text that reads as an IATA or ICAO code rather
+// than an airport name.
+var airportCodePattern = regexp.MustCompile(`^[A-Za-z0-9]{3,4}$`)
+
+func (s *AirportService) completeAirport(code, zone *string) (*domain.Airport, error) {
+	*code = strings.TrimSpace(*code)
+	if *code == "" {
+		return nil, nil
+	}
+	isCode := airportCodePattern.MatchString(*code)
+	if isCode {
+		*code = strings.ToUpper(*code)
+	}
+	airport, ok := s.directory.Lookup(*code)
+	if !ok {
+		if isCode {
+			return nil, fmt.Errorf("%w: unknown airport %q; enter a known code or the airport's name", domain.ErrInvalidInput, *code)
+		}
+		return nil, nil
+	}
+	if airport.IATA != "" {
+		*code = airport.IATA
+	}
+	if *zone == "" {
+		*zone = airport.TimeZone
+	}
+	return airport, nil
+}
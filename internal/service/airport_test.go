@@ -0,0 +1,219 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+// stubAirports is an AirportDirectory over a fixed set of airports.
+type stubAirports []domain.Airport
+
+func (s stubAirports) Lookup(code string) (*domain.Airport, bool) {
+	code = strings.ToUpper(code)
+	for i := range s {
+		if s[i].IATA == code || s[i].ICAO == code {
+			a := s[i]
+			return &a, true
+		}
+	}
+	return nil, false
+}
+
+func (s stubAirports) Search(query string, limit int) []domain.Airport {
+	var found []domain.Airport
+	for i := range s {
+		if strings.Contains(strings.ToLower(s[i].City), strings.ToLower(query)) && len(found) < limit {
+			found = append(found, s[i])
+		}
+	}
+	return found
+}
+
+var testAirports = stubAirports{
+	{IATA: "LHR", ICAO: "EGLL", Name: "Heathrow Airport", City: "London", Country: "GB", TimeZone: "Europe/London", Latitude: 51.4706, Longitude: -0.461941},
+	{IATA: "NRT", ICAO: "RJAA", Name: "Narita International Airport", City: "Tokyo", Country: "JP", TimeZone: "Asia/Tokyo", Latitude: 35.7647, Longitude: 140.386},
+}
+
+func TestAirportService_CompleteFlight(t *testing.T) {
+	svc := service.NewAirportService(testAirports)
+
+	tests := []struct {
+		name    string
+		in      domain.FlightDetails
+		want    domain.FlightDetails
+		wantErr bool
+	}{
+		{
+			name: "fills zones",
+			in:   domain.FlightDetails{DepartureAirport: "lhr", ArrivalAirport: "RJAA"},
+			want: domain.FlightDetails{DepartureAirport: "LHR", ArrivalAirport: "NRT", DepartureTimeZone: "Europe/London", ArrivalTimeZone: "Asia/Tokyo"},
+		},
+		{
+			name: "keeps given zones",
+			in:   domain.FlightDetails{DepartureAirport: "LHR", ArrivalAirport: "NRT", ArrivalTimeZone: "UTC"},
+			want: domain.FlightDetails{DepartureAirport: "LHR", ArrivalAirport: "NRT", DepartureTimeZone: "Europe/London", ArrivalTimeZone: "UTC"},
+		},
+		{
+			name: "no airports",
+			in:   domain.FlightDetails{Airline: "BA"},
+			want: domain.FlightDetails{Airline: "BA"},
+		},
+		{
+			name: "completes segments",
+			in:   domain.FlightDetails{Segments: []domain.FlightSegment{{DepartureAirport: "egll", ArrivalAirport: "NRT"}}},
+			want: domain.FlightDetails{Segments: []domain.FlightSegment{
+				{DepartureAirport: "LHR", ArrivalAirport: "NRT", DepartureTimeZone: "Europe/London", ArrivalTimeZone: "Asia/Tokyo"},
+			}},
+		},
+		{
+			name: "keeps airport names",
+			in:   domain.FlightDetails{DepartureAirport: "LHR", ArrivalAirport: " Narita Intl "},
+			want: domain.FlightDetails{DepartureAirport: "LHR", ArrivalAirport: "Narita Intl", DepartureTimeZone: "Europe/London"},
+		},
+		{
+			name: "keeps segment airport names",
+			in:   domain.FlightDetails{Segments: []domain.FlightSegment{{DepartureAirport: "LHR", ArrivalAirport: "Aosta airfield"}}},
+			want: domain.FlightDetails{Segments: []domain.FlightSegment{
+				{DepartureAirport: "LHR", ArrivalAirport: "Aosta airfield", DepartureTimeZone: "Europe/London"},
+			}},
+		},
+		{name: "unknown departure", in: domain.FlightDetails{DepartureAirport: "XXX", ArrivalAirport: "NRT"}, wantErr: true},
+		{name: "unknown arrival", in: domain.FlightDetails{DepartureAirport: "LHR", ArrivalAirport: "qqq"}, wantErr: true},
+		{name: "unknown segment airport", in: domain.FlightDetails{Segments: []domain.FlightSegment{{DepartureAirport: "LHR", ArrivalAirport: "ZZZZ"}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fd := tt.in
+			_, err := svc.CompleteFlight(&fd)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidInput) {
+					t.Fatalf("CompleteFlight() error = %v, want ErrInvalidInput", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CompleteFlight() error = %v", err)
+			}
+			if !reflect.DeepEqual(fd, tt.want) {
+				t.Errorf("CompleteFlight() = %+v, want %+v", fd, tt.want)
+			}
+		})
+	}
+}
+
+func TestAirportService_Lookup(t *testing.T) {
+	svc := service.NewAirportService(testAirports)
+	if a, err := svc.Lookup("NRT"); err != nil || a.City != "Tokyo" {
+		t.Errorf("Lookup(NRT) = %v, %v", a, err)
+	}
+	if _, err := svc.Lookup("XXX"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Lookup(XXX) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestEventService_AirportNames(t *testing.T) {
+	events := []domain.Event{
+		{Title: "Dinner"},
+		{Flight: &domain.FlightDetails{DepartureAirport: "LHR", ArrivalAirport: "NRT"}},
+		{Flight: &domain.FlightDetails{DepartureAirport: "XXX", ArrivalAirport: "Treviso Canova"}},
+	}
+
+	svc := service.NewEventService(newMockEventRepo(), newMockTripRepo()).
+		WithAirports(service.NewAirportService(testAirports))
+	want := map[string]string{"LHR": "Heathrow Airport", "NRT": "Narita International Airport"}
+	if got := svc.AirportNames(events); !reflect.DeepEqual(got, want) {
+		t.Errorf("AirportNames() = %v, want %v", got, want)
+	}
+
+	// Without a directory there is nothing to name
+	if got := service.NewEventService(newMockEventRepo(), newMockTripRepo()).AirportNames(events); got != nil {
+		t.Errorf("AirportNames() without directory = %v, want nil", got)
+	}
+}
+
+func TestEventService_Create_FlightAirports(t *testing.T) {
+	newService := func() *service.EventService {
+		return service.NewEventService(newMockEventRepo(), newTripRepoWith2026Trip()).
+			WithAirports(service.NewAirportService(testAirports))
+	}
+	start := time.Date(2026, 6, 1, 11, 0, 0, 0, time.UTC)
+
+	t.Run("fills zones and coordinates", func(t *testing.T) {
+		event, err := newService().Create(context.Background(), &service.CreateEventInput{
+			TripID:        1,
+			Title:         "London to Tokyo",
+			Category:      domain.CategoryFlight,
+			StartTime:     start,
+			EndTime:       start.Add(12 * time.Hour),
+			FlightDetails: &domain.FlightDetails{DepartureAirport: "LHR", ArrivalAirport: "NRT"},
+		})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if event.Flight.DepartureTimeZone != "Europe/London" || event.Flight.ArrivalTimeZone != "Asia/Tokyo" {
+			t.Errorf("zones = %q, %q", event.Flight.DepartureTimeZone, event.Flight.ArrivalTimeZone)
+		}
+		if event.Latitude == nil || *event.Latitude != 51.4706 || event.Location != "Heathrow Airport" {
+			t.Errorf("place = %v at %q, want Heathrow", event.Latitude, event.Location)
+		}
+	})
+
+	t.Run("keeps the given place", func(t *testing.T) {
+		lat, lon := 51.47, -0.45
+		event, err := newService().Create(context.Background(), &service.CreateEventInput{
+			TripID:        1,
+			Title:         "London to Tokyo",
+			Category:      domain.CategoryFlight,
+			Location:      "Terminal 5",
+			Latitude:      &lat,
+			Longitude:     &lon,
+			StartTime:     start,
+			EndTime:       start.Add(12 * time.Hour),
+			FlightDetails: &domain.FlightDetails{DepartureAirport: "LHR", ArrivalAirport: "NRT"},
+		})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if *event.Latitude != lat || event.Location != "Terminal 5" {
+			t.Errorf("place = %v at %q, want the input's", *event.Latitude, event.Location)
+		}
+	})
+
+	t.Run("unknown airport", func(t *testing.T) {
+		_, err := newService().Create(context.Background(), &service.CreateEventInput{
+			TripID:        1,
+			Title:         "Nowhere",
+			Category:      domain.CategoryFlight,
+			StartTime:     start,
+			EndTime:       start.Add(time.Hour),
+			FlightDetails: &domain.FlightDetails{DepartureAirport: "LHR", ArrivalAirport: "ZZZ"},
+		})
+		if !errors.Is(err, domain.ErrInvalidInput) {
+			t.Errorf("Create() error = %v, want ErrInvalidInput", err)
+		}
+	})
+
+	t.Run("airport name", func(t *testing.T) {
+		event, err := newService().Create(context.Background(), &service.CreateEventInput{
+			TripID:        1,
+			Title:         "To Treviso",
+			Category:      domain.CategoryFlight,
+			StartTime:     start,
+			EndTime:       start.Add(time.Hour),
+			FlightDetails: &domain.FlightDetails{DepartureAirport: "Treviso Canova", ArrivalAirport: "NRT"},
+		})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if event.Flight.DepartureAirport != "Treviso Canova" || event.Latitude != nil || event.Location != "" {
+			t.Errorf("event = %+v at %v, want the name kept and no place", event.Flight, event.Latitude)
+		}
+	})
+}
@@ -0,0 +1,379 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+// ArchiveVersion is the version of the trip archive format Export writes.
+// Import reads this version and refuses newer ones.
+const ArchiveVersion = 1
+
+// archiveDateFormat is how calendar dates appear in an archive.
+const archiveDateFormat = "2006-01-02"
+
+// TripArchive is the portable JSON form of a trip: everything needed to
+// rebuild its timeline elsewhere, without database IDs.
+type TripArchive struct {
+	ExportedAt time.Time       `json:"exported_at"`
+	Trip       ArchivedTrip    `json:"trip"`
+	Events     []ArchivedEvent `json:"events"`
+	Version    int             `json:"version"`
+}
+
+type ArchivedTrip struct {
+	Name        string `json:"name"`
+	Destination string `json:"destination,omitempty"`
+	StartDate   string `json:"start_date"`
+	EndDate     string `json:"end_date"`
+	TimeZone    string `json:"time_zone"`
+}
+
+// ArchivedEvent is an event with its category details. Date is the timeline
+// day the event sits on and Position its order within that day.
+type ArchivedEvent struct {
+	StartTime time.Time        `json:"start_time"`
+	EndTime   time.Time        `json:"end_time"`
+	Latitude  *float64         `json:"latitude,omitempty"`
+	Longitude *float64         `json:"longitude,omitempty"`
+	Flight    *ArchivedFlight  `json:"flight,omitempty"`
+	Lodging   *ArchivedLodging `json:"lodging,omitempty"`
+	Transit   *ArchivedTransit `json:"transit,omitempty"`
+	Date      string           `json:"date"`
+	Title     string           `json:"title"`
+	Category  string           `json:"category"`
+	Location  string           `json:"location,omitempty"`
+	Notes     string           `json:"notes,omitempty"`
+	TimeZone  string           `json:"time_zone"`
+	Position  int              `json:"position"`
+	Pinned    bool             `json:"pinned"`
+}
+
+type ArchivedFlight struct {
+	Airline           string `json:"airline,omitempty"`
+	FlightNumber      string `json:"flight_number,omitempty"`
+	DepartureAirport  string `json:"departure_airport,omitempty"`
+	ArrivalAirport    string `json:"arrival_airport,omitempty"`
+	DepartureTerminal string `json:"departure_terminal,omitempty"`
+	ArrivalTerminal   string `json:"arrival_terminal,omitempty"`
+	DepartureGate     string `json:"departure_gate,omitempty"`
+	ArrivalGate       string `json:"arrival_gate,omitempty"`
+	BookingReference  string `json:"booking_reference,omitempty"`
+	DepartureTimeZone string `json:"departure_time_zone,omitempty"`
+	ArrivalTimeZone   string `json:"arrival_time_zone,omitempty"`
+	// Segments are the legs of a connecting flight, in flying order.
+	Segments []ArchivedFlightSegment `json:"segments,omitempty"`
+}
+
+type ArchivedFlightSegment struct {
+	DepartureTime     time.Time `json:"departure_time"`
+	ArrivalTime       time.Time `json:"arrival_time"`
+	Airline           string    `json:"airline,omitempty"`
+	FlightNumber      string    `json:"flight_number,omitempty"`
+	DepartureAirport  string    `json:"departure_airport"`
+	ArrivalAirport    string    `json:"arrival_airport"`
+	DepartureTerminal string    `json:"departure_terminal,omitempty"`
+	ArrivalTerminal   string    `json:"arrival_terminal,omitempty"`
+	DepartureGate     string    `json:"departure_gate,omitempty"`
+	ArrivalGate       string    `json:"arrival_gate,omitempty"`
+	DepartureTimeZone string    `json:"departure_time_zone,omitempty"`
+	ArrivalTimeZone   string    `json:"arrival_time_zone,omitempty"`
+}
+
+type ArchivedLodging struct {
+	CheckInTime      *time.Time `json:"check_in_time,omitempty"`
+	CheckOutTime     *time.Time `json:"check_out_time,omitempty"`
+	BookingReference string     `json:"booking_reference,omitempty"`
+}
+
+type ArchivedTransit struct {
+	Origin        string `json:"origin,omitempty"`
+	Destination   string `json:"destination,omitempty"`
+	TransportMode string `json:"transport_mode,omitempty"`
+}
+
+// ArchiveService backs trips up to archives and restores them.
+type ArchiveService struct {
+	trips    domain.TripRepository
+	events   domain.EventRepository
+	importer domain.TripImporter
+}
+
+func NewArchiveService(trips domain.TripRepository, events domain.EventRepository, importer domain.TripImporter) *ArchiveService {
+	return &ArchiveService{trips: trips, events: events, importer: importer}
+}
+
+// Export captures a trip and all its events, ordered as on the timeline.
+func (s *ArchiveService) Export(ctx context.Context, tripID int) (*TripArchive, error) {
+	trip, err := authorizeTrip(ctx, s.trips, tripID, accessRead)
+	if err != nil {
+		return nil, fmt.Errorf("loading trip %d: %w", tripID, err)
+	}
+	events, err := s.events.ListByTrip(ctx, tripID)
+	if err != nil {
+		return nil, fmt.Errorf("listing events for trip %d: %w", tripID, err)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].EventDate.Equal(events[j].EventDate) {
+			return events[i].EventDate.Before(events[j].EventDate)
+		}
+		return events[i].Position < events[j].Position
+	})
+
+	archive := &TripArchive{
+		Version:    ArchiveVersion,
+		ExportedAt: time.Now().UTC(),
+		Trip: ArchivedTrip{
+			Name:        trip.Name,
+			Destination: trip.Destination,
+			StartDate:   trip.StartDate.Format(archiveDateFormat),
+			EndDate:     trip.EndDate.Format(archiveDateFormat),
+			TimeZone:    trip.TimeZone,
+		},
+		Events: make([]ArchivedEvent, len(events)),
+	}
+	for i := range events {
+		archive.Events[i] = archiveEvent(&events[i])
+	}
+	return archive, nil
+}
+
+func archiveEvent(e *domain.Event) ArchivedEvent {
+	a := ArchivedEvent{
+		Date:      e.EventDate.Format(archiveDateFormat),
+		StartTime: e.StartTime.UTC(),
+		EndTime:   e.EndTime.UTC(),
+		Latitude:  e.Latitude,
+		Longitude: e.Longitude,
+		Title:     e.Title,
+		Category:  string(e.Category),
+		Location:  e.Location,
+		Notes:     e.Notes,
+		TimeZone:  e.TimeZone,
+		Position:  e.Position,
+		Pinned:    e.Pinned,
+	}
+	if fd := e.Flight; fd != nil {
+		a.Flight = &ArchivedFlight{
+			Airline:           fd.Airline,
+			FlightNumber:      fd.FlightNumber,
+			DepartureAirport:  fd.DepartureAirport,
+			ArrivalAirport:    fd.ArrivalAirport,
+			DepartureTerminal: fd.DepartureTerminal,
+			ArrivalTerminal:   fd.ArrivalTerminal,
+			DepartureGate:     fd.DepartureGate,
+			ArrivalGate:       fd.ArrivalGate,
+			BookingReference:  fd.BookingReference,
+			DepartureTimeZone: fd.DepartureTimeZone,
+			ArrivalTimeZone:   fd.ArrivalTimeZone,
+		}
+		for i := range fd.Segments {
+			seg := &fd.Segments[i]
+			a.Flight.Segments = append(a.Flight.Segments, ArchivedFlightSegment{
+				DepartureTime:     seg.DepartureTime.UTC(),
+				ArrivalTime:       seg.ArrivalTime.UTC(),
+				Airline:           seg.Airline,
+				FlightNumber:      seg.FlightNumber,
+				DepartureAirport:  seg.DepartureAirport,
+				ArrivalAirport:    seg.ArrivalAirport,
+				DepartureTerminal: seg.DepartureTerminal,
+				ArrivalTerminal:   seg.ArrivalTerminal,
+				DepartureGate:     seg.DepartureGate,
+				ArrivalGate:       seg.ArrivalGate,
+				DepartureTimeZone: seg.DepartureTimeZone,
+				ArrivalTimeZone:   seg.ArrivalTimeZone,
+			})
+		}
+	}
+	if ld := e.Lodging; ld != nil {
+		a.Lodging = &ArchivedLodging{
+			CheckInTime:      utcPtr(ld.CheckInTime),
+			CheckOutTime:     utcPtr(ld.CheckOutTime),
+			BookingReference: ld.BookingReference,
+		}
+	}
+	if td := e.Transit; td != nil {
+		a.Transit = &ArchivedTransit{
+			Origin:        td.Origin,
+			Destination:   td.Destination,
+			TransportMode: td.TransportMode,
+		}
+	}
+	return a
+}
+
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// Import restores an archive as a new trip. The archive is validated in full
+// before anything is written, and the trip and its events are created in one
+// transaction. Errors in the archive wrap ErrInvalidInput.
+func (s *ArchiveService) Import(ctx context.Context, archive *TripArchive) (*domain.Trip, error) {
+	if archive.Version < 1 || archive.Version > ArchiveVersion {
+		return nil, fmt.Errorf("%w: unsupported archive version %d", domain.ErrInvalidInput, archive.Version)
+	}
+	trip, err := restoreTrip(&archive.Trip)
+	if err != nil {
+		return nil, err
+	}
+	// The restored trip belongs to whoever restores it
+	if p, ok := PrincipalFromContext(ctx); ok {
+		trip.UserID = p.UserID
+		trip.Members = []domain.TripMember{{UserID: p.UserID, Username: p.Username, Role: domain.RoleOwner}}
+	}
+	events := make([]domain.Event, len(archive.Events))
+	for i := range archive.Events {
+		event, err := restoreEvent(&archive.Events[i], trip)
+		if err != nil {
+			return nil, fmt.Errorf("event %d: %w", i+1, err)
+		}
+		events[i] = *event
+	}
+
+	if err := s.importer.Import(ctx, trip, events); err != nil {
+		return nil, fmt.Errorf("importing trip %q: %w", trip.Name, err)
+	}
+	return trip, nil
+}
+
+func restoreTrip(a *ArchivedTrip) (*domain.Trip, error) {
+	if a.Name == "" {
+		return nil, fmt.Errorf("%w: trip name is required", domain.ErrInvalidInput)
+	}
+	start, err := parseArchiveDate(a.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseArchiveDate(a.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("%w: end date must be on or after start date", domain.ErrInvalidInput)
+	}
+	timeZone := a.TimeZone
+	if timeZone == "" {
+		timeZone = domain.DefaultTimeZone
+	}
+	if _, err := domain.LoadTimeZone(timeZone); err != nil {
+		return nil, err
+	}
+	return &domain.Trip{
+		Name:        a.Name,
+		Destination: a.Destination,
+		StartDate:   start,
+		EndDate:     end,
+		TimeZone:    timeZone,
+	}, nil
+}
+
+func restoreEvent(a *ArchivedEvent, trip *domain.Trip) (*domain.Event, error) {
+	if a.Title == "" {
+		return nil, fmt.Errorf("%w: title is required", domain.ErrInvalidInput)
+	}
+	category := domain.EventCategory(a.Category)
+	if !domain.IsValidEventCategory(category) {
+		return nil, fmt.Errorf("%w: invalid category %q", domain.ErrInvalidInput, a.Category)
+	}
+	if a.StartTime.IsZero() || a.EndTime.IsZero() {
+		return nil, fmt.Errorf("%w: start and end times are required", domain.ErrInvalidInput)
+	}
+	if a.EndTime.Before(a.StartTime) {
+		return nil, fmt.Errorf("%w: end time must be on or after start time", domain.ErrInvalidInput)
+	}
+	date, err := parseArchiveDate(a.Date)
+	if err != nil {
+		return nil, err
+	}
+	if err := tripRangeError(trip, date); err != nil {
+		return nil, err
+	}
+
+	event := &domain.Event{
+		EventDate: date,
+		StartTime: a.StartTime,
+		EndTime:   a.EndTime,
+		Latitude:  a.Latitude,
+		Longitude: a.Longitude,
+		Title:     a.Title,
+		Category:  category,
+		Location:  a.Location,
+		Notes:     a.Notes,
+		TimeZone:  a.TimeZone,
+		Position:  a.Position,
+		Pinned:    a.Pinned,
+	}
+	if event.TimeZone == "" {
+		event.TimeZone = tripTimeZone(trip)
+	}
+	// Details only belong to their own category, as the event forms enforce
+	switch {
+	case category == domain.CategoryFlight && a.Flight != nil:
+		event.Flight = &domain.FlightDetails{
+			Airline:           a.Flight.Airline,
+			FlightNumber:      a.Flight.FlightNumber,
+			DepartureAirport:  a.Flight.DepartureAirport,
+			ArrivalAirport:    a.Flight.ArrivalAirport,
+			DepartureTerminal: a.Flight.DepartureTerminal,
+			ArrivalTerminal:   a.Flight.ArrivalTerminal,
+			DepartureGate:     a.Flight.DepartureGate,
+			ArrivalGate:       a.Flight.ArrivalGate,
+			BookingReference:  a.Flight.BookingReference,
+			DepartureTimeZone: a.Flight.DepartureTimeZone,
+			ArrivalTimeZone:   a.Flight.ArrivalTimeZone,
+		}
+		for i := range a.Flight.Segments {
+			seg := &a.Flight.Segments[i]
+			event.Flight.Segments = append(event.Flight.Segments, domain.FlightSegment{
+				DepartureTime:     seg.DepartureTime,
+				ArrivalTime:       seg.ArrivalTime,
+				Airline:           seg.Airline,
+				FlightNumber:      seg.FlightNumber,
+				DepartureAirport:  seg.DepartureAirport,
+				ArrivalAirport:    seg.ArrivalAirport,
+				DepartureTerminal: seg.DepartureTerminal,
+				ArrivalTerminal:   seg.ArrivalTerminal,
+				DepartureGate:     seg.DepartureGate,
+				ArrivalGate:       seg.ArrivalGate,
+				DepartureTimeZone: seg.DepartureTimeZone,
+				ArrivalTimeZone:   seg.ArrivalTimeZone,
+			})
+		}
+		if _, _, _, err = applySegments(event.Flight); err != nil {
+			return nil, err
+		}
+	case category == domain.CategoryLodging && a.Lodging != nil:
+		event.Lodging = &domain.LodgingDetails{
+			CheckInTime:      a.Lodging.CheckInTime,
+			CheckOutTime:     a.Lodging.CheckOutTime,
+			BookingReference: a.Lodging.BookingReference,
+		}
+	case category == domain.CategoryTransit && a.Transit != nil:
+		event.Transit = &domain.TransitDetails{
+			Origin:        a.Transit.Origin,
+			Destination:   a.Transit.Destination,
+			TransportMode: a.Transit.TransportMode,
+		}
+	}
+	if err := validateTimeZones(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func parseArchiveDate(s string) (time.Time, error) {
+	date, err := time.Parse(archiveDateFormat, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid date %q", domain.ErrInvalidInput, s)
+	}
+	return date, nil
+}
@@ -0,0 +1,221 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+// mockImporter stores imported trips in the mock repositories, so a restored
+// trip can be read back like any other.
+type mockImporter struct {
+	trips  *mockTripRepo
+	events *mockEventRepo
+	calls  int
+}
+
+func (m *mockImporter) Import(ctx context.Context, trip *domain.Trip, events []domain.Event) error {
+	m.calls++
+	if err := m.trips.Create(ctx, trip); err != nil {
+		return err
+	}
+	for i := range events {
+		events[i].TripID = trip.ID
+		events[i].ID = m.events.nextID
+		m.events.nextID++
+		e := events[i]
+		m.events.events[e.ID] = &e
+	}
+	return nil
+}
+
+func TestArchiveService_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	trips := newMockTripRepo()
+	events := newMockEventRepo()
+	importer := &mockImporter{trips: trips, events: events}
+	svc := service.NewArchiveService(trips, events, importer)
+
+	trip := &domain.Trip{
+		Name:        "Japan",
+		Destination: "Tokyo",
+		StartDate:   time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:     time.Date(2026, 4, 3, 0, 0, 0, 0, time.UTC),
+		TimeZone:    "Asia/Tokyo",
+	}
+	if err := trips.Create(ctx, trip); err != nil {
+		t.Fatal(err)
+	}
+	lat, lon := 35.6586, 139.7454
+	checkIn := time.Date(2026, 4, 1, 6, 0, 0, 0, time.UTC)
+	checkOut := time.Date(2026, 4, 3, 2, 0, 0, 0, time.UTC)
+	day1 := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC)
+	originals := []domain.Event{
+		{
+			Title: "NH 210", Category: domain.CategoryFlight, EventDate: day1, TimeZone: "Europe/London", Position: 1000,
+			StartTime: time.Date(2026, 3, 31, 19, 0, 0, 0, time.UTC), EndTime: time.Date(2026, 4, 1, 3, 0, 0, 0, time.UTC),
+			Pinned: true,
+			Flight: &domain.FlightDetails{
+				Airline: "ANA", FlightNumber: "NH210", DepartureAirport: "LHR", ArrivalAirport: "HND",
+				DepartureTimeZone: "Europe/London", ArrivalTimeZone: "Asia/Tokyo", BookingReference: "ABC123", DepartureGate: "A10",
+				Segments: []domain.FlightSegment{
+					{
+						Airline: "LH", FlightNumber: "LH901", DepartureAirport: "LHR", ArrivalAirport: "FRA",
+						DepartureTime: time.Date(2026, 3, 31, 19, 0, 0, 0, time.UTC), ArrivalTime: time.Date(2026, 3, 31, 20, 30, 0, 0, time.UTC),
+						DepartureTimeZone: "Europe/London", ArrivalTimeZone: "Europe/Berlin", DepartureGate: "A10",
+					},
+					{
+						Airline: "ANA", FlightNumber: "NH210", DepartureAirport: "FRA", ArrivalAirport: "HND",
+						DepartureTime: time.Date(2026, 3, 31, 21, 30, 0, 0, time.UTC), ArrivalTime: time.Date(2026, 4, 1, 3, 0, 0, 0, time.UTC),
+						DepartureTimeZone: "Europe/Berlin", ArrivalTimeZone: "Asia/Tokyo",
+					},
+				},
+			},
+		},
+		{
+			Title: "Hotel Gracery", Category: domain.CategoryLodging, EventDate: day1, TimeZone: "Asia/Tokyo", Position: 1500,
+			StartTime: checkIn, EndTime: checkIn.Add(time.Hour), Location: "Shinjuku",
+			Lodging: &domain.LodgingDetails{CheckInTime: &checkIn, CheckOutTime: &checkOut, BookingReference: "HG-1"},
+		},
+		{
+			Title: "Tokyo Tower", Category: domain.CategoryActivity, EventDate: day2, TimeZone: "Asia/Tokyo", Position: 2000,
+			StartTime: time.Date(2026, 4, 2, 1, 0, 0, 0, time.UTC), EndTime: time.Date(2026, 4, 2, 3, 0, 0, 0, time.UTC),
+			Latitude: &lat, Longitude: &lon, Notes: "Sunset view",
+		},
+		{
+			Title: "Train to Kyoto", Category: domain.CategoryTransit, EventDate: day2, TimeZone: "Asia/Tokyo", Position: 1000,
+			StartTime: time.Date(2026, 4, 1, 23, 0, 0, 0, time.UTC), EndTime: time.Date(2026, 4, 2, 0, 15, 0, 0, time.UTC),
+			Pinned:  true,
+			Transit: &domain.TransitDetails{Origin: "Tokyo", Destination: "Kyoto", TransportMode: "train"},
+		},
+	}
+	for i := range originals {
+		e := originals[i]
+		e.TripID = trip.ID
+		if err := events.Create(ctx, &e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	archive, err := svc.Export(ctx, trip.ID)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if archive.Version != service.ArchiveVersion {
+		t.Errorf("Version = %d, want %d", archive.Version, service.ArchiveVersion)
+	}
+	if archive.Events[2].Title != "Train to Kyoto" {
+		t.Errorf("events not exported in timeline order: third is %q", archive.Events[2].Title)
+	}
+
+	// Through JSON, as a real backup file would go
+	data, err := json.Marshal(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var restored service.TripArchive
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatal(err)
+	}
+
+	imported, err := svc.Import(ctx, &restored)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if imported.ID == trip.ID {
+		t.Fatal("Import() reused the original trip ID")
+	}
+	if imported.Name != trip.Name || imported.Destination != trip.Destination || imported.TimeZone != trip.TimeZone ||
+		!imported.StartDate.Equal(trip.StartDate) || !imported.EndDate.Equal(trip.EndDate) {
+		t.Errorf("imported trip = %+v, want a copy of %+v", imported, trip)
+	}
+
+	for _, day := range []time.Time{day1, day2} {
+		want, _ := events.ListByTripAndDate(ctx, trip.ID, day)
+		got, _ := events.ListByTripAndDate(ctx, imported.ID, day)
+		if len(got) != len(want) {
+			t.Fatalf("%s: got %d events, want %d", day.Format("Jan 2"), len(got), len(want))
+		}
+		for i := range want {
+			if !sameTimelineEvent(&got[i], &want[i]) {
+				t.Errorf("%s #%d:\n got %+v\nwant %+v", day.Format("Jan 2"), i, got[i], want[i])
+			}
+		}
+	}
+}
+
+// sameTimelineEvent compares everything the timeline shows, ignoring IDs.
+func sameTimelineEvent(a, b *domain.Event) bool {
+	x, y := *a, *b
+	x.ID, y.ID, x.TripID, y.TripID = 0, 0, 0, 0
+	x.CreatedAt, y.CreatedAt, x.UpdatedAt, y.UpdatedAt = time.Time{}, time.Time{}, time.Time{}, time.Time{}
+	x.StartTime, y.StartTime = x.StartTime.UTC(), y.StartTime.UTC()
+	x.EndTime, y.EndTime = x.EndTime.UTC(), y.EndTime.UTC()
+	if x.Lodging != nil && y.Lodging != nil {
+		lx, ly := *x.Lodging, *y.Lodging
+		if !lx.CheckInTime.Equal(*ly.CheckInTime) || !lx.CheckOutTime.Equal(*ly.CheckOutTime) {
+			return false
+		}
+		lx.CheckInTime, ly.CheckInTime, lx.CheckOutTime, ly.CheckOutTime = nil, nil, nil, nil
+		x.Lodging, y.Lodging = &lx, &ly
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func TestArchiveService_Import_Invalid(t *testing.T) {
+	valid := func() service.TripArchive {
+		return service.TripArchive{
+			Version: service.ArchiveVersion,
+			Trip:    service.ArchivedTrip{Name: "Rome", StartDate: "2026-05-01", EndDate: "2026-05-03", TimeZone: "Europe/Rome"},
+			Events: []service.ArchivedEvent{{
+				Title: "Colosseum", Category: "activity", Date: "2026-05-02", TimeZone: "Europe/Rome",
+				StartTime: time.Date(2026, 5, 2, 7, 0, 0, 0, time.UTC), EndTime: time.Date(2026, 5, 2, 9, 0, 0, 0, time.UTC),
+			}},
+		}
+	}
+	tests := []struct {
+		name   string
+		mutate func(a *service.TripArchive)
+	}{
+		{name: "newer version", mutate: func(a *service.TripArchive) { a.Version = service.ArchiveVersion + 1 }},
+		{name: "missing version", mutate: func(a *service.TripArchive) { a.Version = 0 }},
+		{name: "trip without name", mutate: func(a *service.TripArchive) { a.Trip.Name = "" }},
+		{name: "bad trip date", mutate: func(a *service.TripArchive) { a.Trip.EndDate = "May 3" }},
+		{name: "unknown zone", mutate: func(a *service.TripArchive) { a.Trip.TimeZone = "Mars/Olympus" }},
+		{name: "bad category", mutate: func(a *service.TripArchive) { a.Events[0].Category = "party" }},
+		{name: "ends before start", mutate: func(a *service.TripArchive) { a.Events[0].EndTime = a.Events[0].StartTime.Add(-time.Hour) }},
+		{name: "event outside trip", mutate: func(a *service.TripArchive) { a.Events[0].Date = "2026-06-01" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			importer := &mockImporter{trips: newMockTripRepo(), events: newMockEventRepo()}
+			svc := service.NewArchiveService(importer.trips, importer.events, importer)
+
+			archive := valid()
+			tt.mutate(&archive)
+			_, err := svc.Import(context.Background(), &archive)
+			if !errors.Is(err, domain.ErrInvalidInput) && !errors.Is(err, domain.ErrDateRangeConflict) {
+				t.Errorf("Import() error = %v, want a validation error", err)
+			}
+			if importer.calls != 0 {
+				t.Error("invalid archive reached the importer")
+			}
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		importer := &mockImporter{trips: newMockTripRepo(), events: newMockEventRepo()}
+		svc := service.NewArchiveService(importer.trips, importer.events, importer)
+		archive := valid()
+		if _, err := svc.Import(context.Background(), &archive); err != nil {
+			t.Errorf("Import() error = %v", err)
+		}
+	})
+}
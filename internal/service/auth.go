@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+const (
+	// sessionTokenBytes is the entropy of a session token.
+	sessionTokenBytes = 32
+	// DefaultSessionTTL is how long a sign-in lasts, unless the service is
+	// given another lifetime.
+	DefaultSessionTTL = 30 * 24 * time.Hour
+	// minPasswordLength is the shortest accepted password. bcrypt reads at
+	// most 72 bytes, so longer passwords are refused rather than truncated.
+	minPasswordLength = 8
+	maxPasswordBytes  = 72
+)
+
+var usernamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{2,31}$`)
+
+// uuidPattern matches the canonical text form of a UUID, which is what
+// bearer tokens must carry as their subject to own trips.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// errWrongCredentials is deliberately the same for unknown users and wrong
+// passwords, so the login form does not reveal which usernames exist.
+var errWrongCredentials = fmt.Errorf("%w: wrong username or password", domain.ErrInvalidInput)
+
+// dummyHash is compared against when a username is unknown, so that failing
+// logins take as long whether or not the user exists.
+var dummyHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("not a password"), bcrypt.DefaultCost)
+	return hash
+})
+
+// AuthService manages local accounts and their sign-in sessions.
+type AuthService struct {
+	users    domain.UserRepository
+	sessions domain.SessionRepository
+	tokens   domain.TokenVerifier
+	now      func() time.Time
+	ttl      time.Duration
+}
+
+func NewAuthService(users domain.UserRepository, sessions domain.SessionRepository) *AuthService {
+	return &AuthService{users: users, sessions: sessions, now: time.Now, ttl: DefaultSessionTTL}
+}
+
+// WithSessionTTL sets how long a sign-in lasts.
+func (s *AuthService) WithSessionTTL(ttl time.Duration) *AuthService {
+	s.ttl = ttl
+	return s
+}
+
+// WithTokenVerifier accepts bearer tokens from an external identity provider
+// alongside local sessions.
+func (s *AuthService) WithTokenVerifier(tokens domain.TokenVerifier) *AuthService {
+	s.tokens = tokens
+	return s
+}
+
+// WithClock replaces the service's clock, for tests of session expiry.
+func (s *AuthService) WithClock(now func() time.Time) *AuthService {
+	s.now = now
+	return s
+}
+
+type SignupInput struct {
+	Username string
+	Email    string // optional
+	Password string
+}
+
+// SignedIn is a new session. Token goes into the browser's cookie and is not
+// stored anywhere else.
+type SignedIn struct {
+	ExpiresAt time.Time
+	User      *domain.User
+	Token     string
+}
+
+// Signup creates an account. Usernames are case-insensitive and stored in
+// lower case; a taken username or email is reported as ErrInvalidInput.
+func (s *AuthService) Signup(ctx context.Context, input *SignupInput) (*domain.User, error) {
+	username := normalizeUsername(input.Username)
+	if !usernamePattern.MatchString(username) {
+		return nil, fmt.Errorf("%w: usernames are 3 to 32 letters, digits, dots, dashes or underscores", domain.ErrInvalidInput)
+	}
+	email := strings.TrimSpace(input.Email)
+	if email != "" && !strings.Contains(email, "@") {
+		return nil, fmt.Errorf("%w: invalid email address", domain.ErrInvalidInput)
+	}
+	if len(input.Password) < minPasswordLength {
+		return nil, fmt.Errorf("%w: passwords need at least %d characters", domain.ErrInvalidInput, minPasswordLength)
+	}
+	if len(input.Password) > maxPasswordBytes {
+		return nil, fmt.Errorf("%w: passwords can be at most %d bytes", domain.ErrInvalidInput, maxPasswordBytes)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("hashing password: %w", err)
+	}
+	user := &domain.User{Username: username, Email: email, PasswordHash: string(hash)}
+	if err := s.users.Create(ctx, user); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return nil, fmt.Errorf("%w: that username or email is already taken", domain.ErrInvalidInput)
+		}
+		return nil, fmt.Errorf("creating user %q: %w", username, err)
+	}
+	return user, nil
+}
+
+// Login checks a username and password and starts a session.
+func (s *AuthService) Login(ctx context.Context, username, password string) (*SignedIn, error) {
+	user, err := s.users.GetByUsername(ctx, normalizeUsername(username))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			_ = bcrypt.CompareHashAndPassword(dummyHash(), []byte(password))
+			return nil, errWrongCredentials
+		}
+		return nil, fmt.Errorf("loading user %q: %w", username, err)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, errWrongCredentials
+	}
+	return s.StartSession(ctx, user)
+}
+
+// StartSession signs user in, as after a signup.
+func (s *AuthService) StartSession(ctx context.Context, user *domain.User) (*SignedIn, error) {
+	// Sign-ins are rare enough to sweep out sessions that ran out on the way
+	if err := s.sessions.DeleteExpired(ctx); err != nil {
+		slog.WarnContext(ctx, "deleting expired sessions", "error", err)
+	}
+
+	b := make([]byte, sessionTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("generating session token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	session := &domain.Session{
+		TokenHash: hashSessionToken(token),
+		UserID:    user.ID,
+		ExpiresAt: s.now().Add(s.ttl),
+	}
+	if err := s.sessions.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("creating session for user %s: %w", user.ID, err)
+	}
+	return &SignedIn{User: user, Token: token, ExpiresAt: session.ExpiresAt}, nil
+}
+
+// Authenticate resolves a session token to its user. Unknown and expired
+// tokens are reported as ErrNotFound.
+func (s *AuthService) Authenticate(ctx context.Context, token string) (*domain.User, error) {
+	if token == "" {
+		return nil, domain.ErrNotFound
+	}
+	session, err := s.sessions.GetByTokenHash(ctx, hashSessionToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if !s.now().Before(session.ExpiresAt) {
+		return nil, domain.ErrNotFound
+	}
+	return s.users.GetByID(ctx, session.UserID)
+}
+
+// AuthenticateBearer resolves a bearer token to the principal it was issued
+// to. Its subject is the user's UUID; the user need not have a local account.
+// Invalid tokens, and any token when no verifier is configured, are reported
+// as ErrInvalidInput.
+func (s *AuthService) AuthenticateBearer(ctx context.Context, token string) (Principal, error) {
+	if s.tokens == nil {
+		return Principal{}, fmt.Errorf("%w: bearer tokens are not accepted", domain.ErrInvalidInput)
+	}
+	identity, err := s.tokens.Verify(ctx, token)
+	if err != nil {
+		return Principal{}, err
+	}
+	if !uuidPattern.MatchString(identity.Subject) {
+		return Principal{}, fmt.Errorf("%w: token subject is not a user ID", domain.ErrInvalidInput)
+	}
+	p := Principal{UserID: strings.ToLower(identity.Subject), Username: identity.Email}
+	if p.Username == "" {
+		p.Username = p.UserID
+	}
+	if identity.EmailVerified {
+		p.Email = identity.Email
+	}
+	return p, nil
+}
+
+// Logout ends the session of token. Unknown tokens are not an error.
+func (s *AuthService) Logout(ctx context.Context, token string) error {
+	if token == "" {
+		return nil
+	}
+	return s.sessions.Delete(ctx, hashSessionToken(token))
+}
+
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+func hashSessionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,254 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+// mockUserRepo is a test double implementing domain.UserRepository.
+type mockUserRepo struct {
+	users []*domain.User
+}
+
+func (m *mockUserRepo) Create(_ context.Context, user *domain.User) error {
+	for _, u := range m.users {
+		if u.Username == user.Username || (user.Email != "" && u.Email == user.Email) {
+			return domain.ErrConflict
+		}
+	}
+	user.ID = "00000000-0000-0000-0000-00000000000" + strconv.Itoa(len(m.users)+1)
+	user.CreatedAt = time.Now()
+	m.users = append(m.users, user)
+	return nil
+}
+
+func (m *mockUserRepo) GetByID(_ context.Context, id string) (*domain.User, error) {
+	for _, u := range m.users {
+		if u.ID == id {
+			return u, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockUserRepo) GetByUsername(_ context.Context, username string) (*domain.User, error) {
+	for _, u := range m.users {
+		if u.Username == username {
+			return u, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+// mockSessionRepo is a test double implementing domain.SessionRepository.
+type mockSessionRepo struct {
+	sessions map[string]*domain.Session
+}
+
+func newMockSessionRepo() *mockSessionRepo {
+	return &mockSessionRepo{sessions: make(map[string]*domain.Session)}
+}
+
+func (m *mockSessionRepo) Create(_ context.Context, session *domain.Session) error {
+	m.sessions[session.TokenHash] = session
+	return nil
+}
+
+func (m *mockSessionRepo) GetByTokenHash(_ context.Context, tokenHash string) (*domain.Session, error) {
+	s, ok := m.sessions[tokenHash]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return s, nil
+}
+
+func (m *mockSessionRepo) Delete(_ context.Context, tokenHash string) error {
+	delete(m.sessions, tokenHash)
+	return nil
+}
+
+func (m *mockSessionRepo) DeleteExpired(_ context.Context) error {
+	return nil
+}
+
+func TestAuthService_Signup(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   service.SignupInput
+		wantErr bool
+	}{
+		{name: "valid", input: service.SignupInput{Username: " Ada.L ", Email: "ada@example.com", Password: "correct horse"}},
+		{name: "no email", input: service.SignupInput{Username: "grace", Password: "correct horse"}},
+		{name: "username too short", input: service.SignupInput{Username: "al", Password: "correct horse"}, wantErr: true},
+		{name: "username with spaces", input: service.SignupInput{Username: "ada l", Password: "correct horse"}, wantErr: true},
+		{name: "invalid email", input: service.SignupInput{Username: "ada", Email: "ada", Password: "correct horse"}, wantErr: true},
+		{name: "password too short", input: service.SignupInput{Username: "ada", Password: "short"}, wantErr: true},
+		{name: "password too long", input: service.SignupInput{Username: "ada", Password: string(make([]byte, 73))}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := service.NewAuthService(&mockUserRepo{}, newMockSessionRepo())
+			user, err := svc.Signup(context.Background(), &tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidInput) {
+					t.Errorf("Signup() error = %v, want ErrInvalidInput", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Signup() error = %v", err)
+			}
+			if user.ID == "" || user.PasswordHash == tt.input.Password {
+				t.Errorf("Signup() = %+v, want an ID and a hashed password", user)
+			}
+		})
+	}
+}
+
+func TestAuthService_Signup_Taken(t *testing.T) {
+	svc := service.NewAuthService(&mockUserRepo{}, newMockSessionRepo())
+	ctx := context.Background()
+	if _, err := svc.Signup(ctx, &service.SignupInput{Username: "ada", Password: "correct horse"}); err != nil {
+		t.Fatalf("Signup() error = %v", err)
+	}
+	_, err := svc.Signup(ctx, &service.SignupInput{Username: "ADA", Password: "another one"})
+	if !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("Signup() error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestAuthService_LoginAndLogout(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	sessions := newMockSessionRepo()
+	svc := service.NewAuthService(&mockUserRepo{}, sessions).
+		WithSessionTTL(time.Hour).
+		WithClock(func() time.Time { return now })
+	ctx := context.Background()
+
+	user, err := svc.Signup(ctx, &service.SignupInput{Username: "ada", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("Signup() error = %v", err)
+	}
+
+	for _, creds := range [][2]string{{"ada", "wrong horse"}, {"bob", "correct horse"}} {
+		if _, err = svc.Login(ctx, creds[0], creds[1]); !errors.Is(err, domain.ErrInvalidInput) {
+			t.Errorf("Login(%q, %q) error = %v, want ErrInvalidInput", creds[0], creds[1], err)
+		}
+	}
+
+	signedIn, err := svc.Login(ctx, "Ada", "correct horse")
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if !signedIn.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("ExpiresAt = %v, want %v", signedIn.ExpiresAt, now.Add(time.Hour))
+	}
+	if _, ok := sessions.sessions[signedIn.Token]; ok {
+		t.Error("session stored under the raw token")
+	}
+
+	got, err := svc.Authenticate(ctx, signedIn.Token)
+	if err != nil || got.ID != user.ID {
+		t.Fatalf("Authenticate() = %v, %v, want user %s", got, err, user.ID)
+	}
+	if _, err = svc.Authenticate(ctx, "made-up"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Authenticate(unknown) error = %v, want ErrNotFound", err)
+	}
+
+	if err = svc.Logout(ctx, signedIn.Token); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	if _, err = svc.Authenticate(ctx, signedIn.Token); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Authenticate() after logout error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestAuthService_Authenticate_Expired(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	svc := service.NewAuthService(&mockUserRepo{}, newMockSessionRepo()).
+		WithSessionTTL(time.Hour).
+		WithClock(func() time.Time { return now })
+	ctx := context.Background()
+
+	user, err := svc.Signup(ctx, &service.SignupInput{Username: "ada", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("Signup() error = %v", err)
+	}
+	signedIn, err := svc.StartSession(ctx, user)
+	if err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+
+	now = now.Add(time.Hour)
+	if _, err = svc.Authenticate(ctx, signedIn.Token); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Authenticate() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestTripService_Create_SetsOwner(t *testing.T) {
+	repo := newMockTripRepo()
+	svc := service.NewTripService(repo)
+	ctx := service.WithPrincipal(context.Background(), service.Principal{UserID: "00000000-0000-0000-0000-000000000001", Username: "ada"})
+
+	trip, err := svc.Create(ctx, &service.CreateTripInput{
+		Name:      "Lisbon",
+		StartDate: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if trip.UserID != "00000000-0000-0000-0000-000000000001" {
+		t.Errorf("UserID = %q, want the principal's", trip.UserID)
+	}
+}
+
+// stubTokenVerifier accepts every token as issued to subject.
+type stubTokenVerifier struct {
+	subject       string
+	email         string
+	emailVerified bool
+}
+
+func (s stubTokenVerifier) Verify(_ context.Context, _ string) (*domain.TokenIdentity, error) {
+	return &domain.TokenIdentity{Subject: s.subject, Email: s.email, EmailVerified: s.emailVerified}, nil
+}
+
+func TestAuthService_AuthenticateBearer(t *testing.T) {
+	ctx := context.Background()
+
+	svc := service.NewAuthService(&mockUserRepo{}, newMockSessionRepo())
+	if _, err := svc.AuthenticateBearer(ctx, "token"); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("without verifier: error = %v, want ErrInvalidInput", err)
+	}
+
+	svc.WithTokenVerifier(stubTokenVerifier{subject: "anon"})
+	if _, err := svc.AuthenticateBearer(ctx, "token"); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("non-UUID subject: error = %v, want ErrInvalidInput", err)
+	}
+
+	svc.WithTokenVerifier(stubTokenVerifier{subject: "7B6F3C1E-2F4A-4C2B-9D1E-0A5B6C7D8E9F"})
+	p, err := svc.AuthenticateBearer(ctx, "token")
+	if err != nil {
+		t.Fatalf("AuthenticateBearer() error = %v", err)
+	}
+	if p.UserID != "7b6f3c1e-2f4a-4c2b-9d1e-0a5b6c7d8e9f" || p.Username != p.UserID {
+		t.Errorf("AuthenticateBearer() = %+v", p)
+	}
+
+	subject := "7b6f3c1e-2f4a-4c2b-9d1e-0a5b6c7d8e9f"
+	svc.WithTokenVerifier(stubTokenVerifier{subject: subject, email: "ada@example.com"})
+	if p, _ = svc.AuthenticateBearer(ctx, "token"); p.Username != "ada@example.com" || p.Email != "" {
+		t.Errorf("unverified email: AuthenticateBearer() = %+v, want no Email", p)
+	}
+	svc.WithTokenVerifier(stubTokenVerifier{subject: subject, email: "ada@example.com", emailVerified: true})
+	if p, _ = svc.AuthenticateBearer(ctx, "token"); p.Email != "ada@example.com" {
+		t.Errorf("verified email: AuthenticateBearer() = %+v, want Email", p)
+	}
+}
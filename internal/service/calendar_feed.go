@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+// feedTokenBytes is the entropy of a feed token. The token is the only secret
+// guarding the feed, so it must not be guessable.
+const feedTokenBytes = 32
+
+type CalendarFeedService struct {
+	feeds  domain.CalendarFeedRepository
+	trips  domain.TripRepository
+	events domain.EventRepository
+}
+
+func NewCalendarFeedService(feeds domain.CalendarFeedRepository, trips domain.TripRepository, events domain.EventRepository) *CalendarFeedService {
+	return &CalendarFeedService{feeds: feeds, trips: trips, events: events}
+}
+
+// FeedSnapshot is what a calendar feed serves: the trip, its events and when
+// any of them last changed.
+type FeedSnapshot struct {
+	LastModified time.Time
+	Trip         *domain.Trip
+	Events       []domain.Event
+}
+
+// Create issues a new feed token for the trip.
+func (s *CalendarFeedService) Create(ctx context.Context, tripID int) (*domain.CalendarFeed, error) {
+	if _, err := authorizeTrip(ctx, s.trips, tripID, accessManage); err != nil {
+		return nil, fmt.Errorf("loading trip %d: %w", tripID, err)
+	}
+
+	token, err := newFeedToken()
+	if err != nil {
+		return nil, err
+	}
+	feed := &domain.CalendarFeed{TripID: tripID, Token: token}
+	if err := s.feeds.Create(ctx, feed); err != nil {
+		return nil, fmt.Errorf("creating feed for trip %d: %w", tripID, err)
+	}
+	return feed, nil
+}
+
+func (s *CalendarFeedService) ListByTrip(ctx context.Context, tripID int) ([]domain.CalendarFeed, error) {
+	if _, err := authorizeTrip(ctx, s.trips, tripID, accessManage); err != nil {
+		return nil, err
+	}
+	return s.feeds.ListByTrip(ctx, tripID)
+}
+
+// Revoke deletes a feed; calendar apps polling its URL get 404 from then on.
+func (s *CalendarFeedService) Revoke(ctx context.Context, tripID, id int) error {
+	if _, err := authorizeTrip(ctx, s.trips, tripID, accessManage); err != nil {
+		return err
+	}
+	return s.feeds.Delete(ctx, tripID, id)
+}
+
+// Snapshot resolves a feed token to the trip it publishes. Unknown and revoked
+// tokens return ErrNotFound. The token stands in for the owner, so the trip is
+// served without a principal.
+func (s *CalendarFeedService) Snapshot(ctx context.Context, token string) (*FeedSnapshot, error) {
+	if token == "" {
+		return nil, domain.ErrNotFound
+	}
+	feed, err := s.feeds.GetByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	trip, err := s.trips.GetByID(ctx, feed.TripID)
+	if err != nil {
+		return nil, fmt.Errorf("loading trip %d: %w", feed.TripID, err)
+	}
+	lastModified, err := s.feeds.TripLastModified(ctx, feed.TripID)
+	if err != nil {
+		return nil, fmt.Errorf("loading last change of trip %d: %w", feed.TripID, err)
+	}
+	events, err := s.events.ListByTrip(ctx, feed.TripID)
+	if err != nil {
+		return nil, fmt.Errorf("listing events for trip %d: %w", feed.TripID, err)
+	}
+	return &FeedSnapshot{Trip: trip, Events: events, LastModified: lastModified}, nil
+}
+
+func newFeedToken() (string, error) {
+	b := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating feed token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
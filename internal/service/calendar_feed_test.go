@@ -0,0 +1,134 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+type mockFeedRepo struct {
+	feeds        map[int]*domain.CalendarFeed
+	lastModified time.Time
+	nextID       int
+}
+
+func newMockFeedRepo() *mockFeedRepo {
+	return &mockFeedRepo{feeds: make(map[int]*domain.CalendarFeed), nextID: 1}
+}
+
+func (m *mockFeedRepo) Create(_ context.Context, feed *domain.CalendarFeed) error {
+	feed.ID = m.nextID
+	feed.CreatedAt = time.Now()
+	m.feeds[feed.ID] = feed
+	m.nextID++
+	return nil
+}
+
+func (m *mockFeedRepo) GetByToken(_ context.Context, token string) (*domain.CalendarFeed, error) {
+	for _, feed := range m.feeds {
+		if feed.Token == token {
+			return feed, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockFeedRepo) ListByTrip(_ context.Context, tripID int) ([]domain.CalendarFeed, error) {
+	var result []domain.CalendarFeed
+	for _, feed := range m.feeds {
+		if feed.TripID == tripID {
+			result = append(result, *feed)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockFeedRepo) Delete(_ context.Context, tripID, id int) error {
+	feed, ok := m.feeds[id]
+	if !ok || feed.TripID != tripID {
+		return domain.ErrNotFound
+	}
+	delete(m.feeds, id)
+	return nil
+}
+
+func (m *mockFeedRepo) TripLastModified(_ context.Context, _ int) (time.Time, error) {
+	return m.lastModified, nil
+}
+
+func TestCalendarFeedService(t *testing.T) {
+	ctx := context.Background()
+	feeds := newMockFeedRepo()
+	feeds.lastModified = time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+	events := newMockEventRepo()
+	events.events[1] = &domain.Event{ID: 1, TripID: 1, Title: "Colosseum"}
+	events.events[2] = &domain.Event{ID: 2, TripID: 2, Title: "Other trip"}
+	svc := service.NewCalendarFeedService(feeds, newTripRepoWith2026Trip(), events)
+
+	t.Run("create requires an existing trip", func(t *testing.T) {
+		if _, err := svc.Create(ctx, 99); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Create() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	first, err := svc.Create(ctx, 1)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	second, err := svc.Create(ctx, 1)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	t.Run("tokens are long and unique", func(t *testing.T) {
+		if len(first.Token) < 40 {
+			t.Errorf("token %q is too short", first.Token)
+		}
+		if first.Token == second.Token {
+			t.Errorf("two feeds share token %q", first.Token)
+		}
+	})
+
+	t.Run("snapshot serves the feed's trip", func(t *testing.T) {
+		snapshot, err := svc.Snapshot(ctx, first.Token)
+		if err != nil {
+			t.Fatalf("Snapshot() error = %v", err)
+		}
+		if snapshot.Trip.ID != 1 || len(snapshot.Events) != 1 || snapshot.Events[0].ID != 1 {
+			t.Errorf("Snapshot() = trip %d with %d events, want trip 1 with event 1", snapshot.Trip.ID, len(snapshot.Events))
+		}
+		if !snapshot.LastModified.Equal(feeds.lastModified) {
+			t.Errorf("Snapshot() LastModified = %v, want %v", snapshot.LastModified, feeds.lastModified)
+		}
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		for _, token := range []string{"", "not-a-token"} {
+			if _, err := svc.Snapshot(ctx, token); !errors.Is(err, domain.ErrNotFound) {
+				t.Errorf("Snapshot(%q) error = %v, want ErrNotFound", token, err)
+			}
+		}
+	})
+
+	t.Run("revoke scoped to the trip", func(t *testing.T) {
+		if err := svc.Revoke(ctx, 2, first.ID); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Revoke() from another trip error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("revoked token stops resolving", func(t *testing.T) {
+		if err := svc.Revoke(ctx, 1, first.ID); err != nil {
+			t.Fatalf("Revoke() error = %v", err)
+		}
+		if _, err := svc.Snapshot(ctx, first.Token); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Snapshot() after revoke error = %v, want ErrNotFound", err)
+		}
+		if _, err := svc.Snapshot(ctx, second.Token); err != nil {
+			t.Errorf("Snapshot() of the other feed error = %v", err)
+		}
+	})
+}
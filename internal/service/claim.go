@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+// ClaimService hands trips created before there were accounts to a user.
+// Such trips have no owner, so no signed-in user can reach them until they
+// are claimed.
+type ClaimService struct {
+	trips domain.TripClaimer
+	users domain.UserRepository
+}
+
+func NewClaimService(trips domain.TripClaimer, users domain.UserRepository) *ClaimService {
+	return &ClaimService{trips: trips, users: users}
+}
+
+// CountOwnerless returns how many trips still wait to be claimed.
+func (s *ClaimService) CountOwnerless(ctx context.Context) (int, error) {
+	return s.trips.CountOwnerless(ctx)
+}
+
+// ClaimOwnerless makes the local account username the owner of every
+// ownerless trip and returns how many it claimed.
+func (s *ClaimService) ClaimOwnerless(ctx context.Context, username string) (int, error) {
+	username = normalizeUsername(username)
+	if username == "" {
+		return 0, fmt.Errorf("%w: username is required", domain.ErrInvalidInput)
+	}
+	user, err := s.users.GetByUsername(ctx, username)
+	if errors.Is(err, domain.ErrNotFound) {
+		return 0, fmt.Errorf("%w: no user is called %s", domain.ErrInvalidInput, username)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("looking up %s: %w", username, err)
+	}
+
+	return s.trips.ClaimOwnerless(ctx, domain.TripMember{UserID: user.ID, Username: user.Username, Role: domain.RoleOwner})
+}
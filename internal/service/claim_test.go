@@ -0,0 +1,73 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+// mockTripRepo also implements domain.TripClaimer.
+func (m *mockTripRepo) CountOwnerless(_ context.Context) (int, error) {
+	count := 0
+	for _, t := range m.trips {
+		if t.UserID == "" {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockTripRepo) ClaimOwnerless(_ context.Context, owner domain.TripMember) (int, error) {
+	claimed := 0
+	for _, t := range m.trips {
+		if t.UserID == "" {
+			owner.TripID = t.ID
+			t.UserID = owner.UserID
+			t.Members = append(t.Members, owner)
+			claimed++
+		}
+	}
+	return claimed, nil
+}
+
+func TestClaimService_ClaimOwnerless(t *testing.T) {
+	f := newTwoUsers(t)
+	legacy, err := f.trips.Create(context.Background(), &service.CreateTripInput{
+		Name:      "Before accounts",
+		StartDate: at(0, 0),
+		EndDate:   at(0, 0),
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	users := &mockUserRepo{users: []*domain.User{{ID: userA, Username: "ada"}}}
+	svc := service.NewClaimService(f.tripRepo, users)
+
+	if _, err = svc.ClaimOwnerless(context.Background(), "nobody"); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("ClaimOwnerless() of an unknown user error = %v, want ErrInvalidInput", err)
+	}
+	if count, _ := svc.CountOwnerless(context.Background()); count != 1 {
+		t.Errorf("CountOwnerless() = %d, want 1", count)
+	}
+
+	claimed, err := svc.ClaimOwnerless(context.Background(), "Ada")
+	if err != nil || claimed != 1 {
+		t.Fatalf("ClaimOwnerless() = %d, %v, want 1", claimed, err)
+	}
+	trip, err := f.trips.GetByID(actingAs(userA), legacy.ID)
+	if err != nil {
+		t.Fatalf("GetByID() as the new owner error = %v", err)
+	}
+	if trip.RoleOf(userA) != domain.RoleOwner {
+		t.Errorf("role = %q, want owner", trip.RoleOf(userA))
+	}
+	if _, err = f.trips.GetByID(context.Background(), legacy.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetByID() without principal error = %v, want ErrNotFound", err)
+	}
+	if count, _ := svc.CountOwnerless(context.Background()); count != 0 {
+		t.Errorf("CountOwnerless() after claiming = %d, want 0", count)
+	}
+}
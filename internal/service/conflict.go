@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+// DetectConflicts returns every pair of events whose time ranges overlap.
+// Lodging events are ignored: a stay runs in the background of a day rather than
+// competing with other plans for the same time. Events that merely touch (one ends
+// when the next starts) do not conflict.
+func DetectConflicts(events []domain.Event) []domain.Conflict {
+	sorted := make([]domain.Event, 0, len(events))
+	for i := range events {
+		if events[i].Category != domain.CategoryLodging {
+			sorted = append(sorted, events[i])
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].StartTime.Before(sorted[j].StartTime) })
+
+	conflicts := []domain.Conflict{}
+	for i := range sorted {
+		a := &sorted[i]
+		for j := i + 1; j < len(sorted); j++ {
+			b := &sorted[j]
+			if !b.StartTime.Before(a.EndTime) {
+				break
+			}
+			end := a.EndTime
+			if b.EndTime.Before(end) {
+				end = b.EndTime
+			}
+			overlap := end.Sub(b.StartTime)
+			if overlap <= 0 {
+				continue
+			}
+			conflicts = append(conflicts, domain.Conflict{
+				EventID:  a.ID,
+				OtherID:  b.ID,
+				Overlap:  overlap,
+				Severity: conflictSeverity(a, b),
+			})
+		}
+	}
+	return conflicts
+}
+
+func conflictSeverity(a, b *domain.Event) domain.ConflictSeverity {
+	if (a.Pinned && b.Pinned) || a.Category == domain.CategoryFlight || b.Category == domain.CategoryFlight {
+		return domain.ConflictCritical
+	}
+	return domain.ConflictWarning
+}
+
+// DayConflicts analyses one day of a trip for overlapping events.
+func (s *EventService) DayConflicts(ctx context.Context, tripID int, date time.Time) ([]domain.Conflict, error) {
+	events, err := s.ListByTripAndDate(ctx, tripID, date)
+	if err != nil {
+		return nil, fmt.Errorf("listing events for trip %d on %s: %w", tripID, date.Format("2006-01-02"), err)
+	}
+	return DetectConflicts(events), nil
+}
+
+// checkConflicts returns ErrScheduleConflict when candidate would overlap another
+// event of its day. candidate.ID is zero for events that are not stored yet.
+func (s *EventService) checkConflicts(ctx context.Context, candidate *domain.Event) error {
+	events, err := s.repo.ListByTripAndDate(ctx, candidate.TripID, candidate.EventDate)
+	if err != nil {
+		return fmt.Errorf("listing events for trip %d: %w", candidate.TripID, err)
+	}
+
+	day := make([]domain.Event, 0, len(events)+1)
+	titles := make(map[int]string, len(events))
+	for i := range events {
+		if events[i].ID == candidate.ID {
+			continue
+		}
+		day = append(day, events[i])
+		titles[events[i].ID] = events[i].Title
+	}
+	// A new event has no ID yet; use -1 so it cannot collide with stored events.
+	probe := *candidate
+	if probe.ID == 0 {
+		probe.ID = -1
+	}
+	day = append(day, probe)
+
+	var overlapping []string
+	for _, c := range DetectConflicts(day) {
+		switch probe.ID {
+		case c.EventID:
+			overlapping = append(overlapping, fmt.Sprintf("%q", titles[c.OtherID]))
+		case c.OtherID:
+			overlapping = append(overlapping, fmt.Sprintf("%q", titles[c.EventID]))
+		}
+	}
+	if len(overlapping) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: overlaps with %s", domain.ErrScheduleConflict, strings.Join(overlapping, ", "))
+}
@@ -0,0 +1,180 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+func at(hour, minute int) time.Time {
+	return time.Date(2026, 5, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func TestDetectConflicts(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []domain.Event
+		want   []domain.Conflict
+	}{
+		{
+			name: "back-to-back events do not conflict",
+			events: []domain.Event{
+				{ID: 1, StartTime: at(9, 0), EndTime: at(10, 0)},
+				{ID: 2, StartTime: at(10, 0), EndTime: at(11, 0)},
+			},
+			want: []domain.Conflict{},
+		},
+		{
+			name: "flexible overlap is a warning",
+			events: []domain.Event{
+				{ID: 1, StartTime: at(9, 0), EndTime: at(10, 30)},
+				{ID: 2, StartTime: at(10, 0), EndTime: at(11, 0)},
+			},
+			want: []domain.Conflict{
+				{EventID: 1, OtherID: 2, Overlap: 30 * time.Minute, Severity: domain.ConflictWarning},
+			},
+		},
+		{
+			name: "two pinned events are critical",
+			events: []domain.Event{
+				{ID: 1, StartTime: at(9, 0), EndTime: at(12, 0), Pinned: true},
+				{ID: 2, StartTime: at(10, 0), EndTime: at(11, 0), Pinned: true},
+			},
+			want: []domain.Conflict{
+				{EventID: 1, OtherID: 2, Overlap: time.Hour, Severity: domain.ConflictCritical},
+			},
+		},
+		{
+			name: "one pinned event is a warning",
+			events: []domain.Event{
+				{ID: 1, StartTime: at(9, 0), EndTime: at(12, 0), Pinned: true},
+				{ID: 2, StartTime: at(10, 0), EndTime: at(11, 0)},
+			},
+			want: []domain.Conflict{
+				{EventID: 1, OtherID: 2, Overlap: time.Hour, Severity: domain.ConflictWarning},
+			},
+		},
+		{
+			name: "overlap with a flight is critical",
+			events: []domain.Event{
+				{ID: 1, StartTime: at(14, 0), EndTime: at(16, 0), Category: domain.CategoryFlight},
+				{ID: 2, StartTime: at(13, 0), EndTime: at(14, 15)},
+			},
+			want: []domain.Conflict{
+				{EventID: 2, OtherID: 1, Overlap: 15 * time.Minute, Severity: domain.ConflictCritical},
+			},
+		},
+		{
+			name: "lodging is ignored",
+			events: []domain.Event{
+				{ID: 1, StartTime: at(9, 0), EndTime: at(23, 0), Category: domain.CategoryLodging},
+				{ID: 2, StartTime: at(10, 0), EndTime: at(11, 0)},
+			},
+			want: []domain.Conflict{},
+		},
+		{
+			name: "long event overlaps every later one",
+			events: []domain.Event{
+				{ID: 1, StartTime: at(9, 0), EndTime: at(18, 0)},
+				{ID: 2, StartTime: at(10, 0), EndTime: at(11, 0)},
+				{ID: 3, StartTime: at(12, 0), EndTime: at(13, 0)},
+			},
+			want: []domain.Conflict{
+				{EventID: 1, OtherID: 2, Overlap: time.Hour, Severity: domain.ConflictWarning},
+				{EventID: 1, OtherID: 3, Overlap: time.Hour, Severity: domain.ConflictWarning},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := service.DetectConflicts(tt.events)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("DetectConflicts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventService_RejectConflicts(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		run     func(svc *service.EventService) error
+		wantErr error
+		name    string
+	}{
+		{
+			name: "create overlapping is rejected when asked",
+			run: func(svc *service.EventService) error {
+				_, err := svc.Create(ctx, &service.CreateEventInput{
+					TripID: 1, Title: "Lunch", StartTime: at(11, 0), EndTime: at(12, 30), RejectConflicts: true,
+				})
+				return err
+			},
+			wantErr: domain.ErrScheduleConflict,
+		},
+		{
+			name: "create overlapping is saved by default",
+			run: func(svc *service.EventService) error {
+				_, err := svc.Create(ctx, &service.CreateEventInput{
+					TripID: 1, Title: "Lunch", StartTime: at(11, 0), EndTime: at(12, 30),
+				})
+				return err
+			},
+		},
+		{
+			name: "create in a free slot passes the check",
+			run: func(svc *service.EventService) error {
+				_, err := svc.Create(ctx, &service.CreateEventInput{
+					TripID: 1, Title: "Lunch", StartTime: at(12, 0), EndTime: at(13, 0), RejectConflicts: true,
+				})
+				return err
+			},
+		},
+		{
+			name: "update into an overlap is rejected when asked",
+			run: func(svc *service.EventService) error {
+				start, end := at(11, 30), at(13, 0)
+				_, err := svc.Update(ctx, 2, &service.UpdateEventInput{StartTime: &start, EndTime: &end, RejectConflicts: true})
+				return err
+			},
+			wantErr: domain.ErrScheduleConflict,
+		},
+		{
+			name: "update does not conflict with itself",
+			run: func(svc *service.EventService) error {
+				title := "Evening walk"
+				_, err := svc.Update(ctx, 2, &service.UpdateEventInput{Title: &title, RejectConflicts: true})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockEventRepo()
+			repo.events[1] = &domain.Event{ID: 1, TripID: 1, EventDate: day, Title: "Museum", StartTime: at(10, 0), EndTime: at(12, 0), Position: 1000}
+			repo.events[2] = &domain.Event{ID: 2, TripID: 1, EventDate: day, Title: "Walk", StartTime: at(17, 0), EndTime: at(18, 0), Position: 2000}
+			repo.nextID = 3
+			svc := service.NewEventService(repo, newTripRepoWith2026Trip())
+
+			err := tt.run(svc)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
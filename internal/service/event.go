@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/simopzz/traccia/internal/domain"
@@ -21,11 +22,73 @@ const (
 )
 
 type EventService struct {
-	repo EventStore
+	repo           EventStore
+	trips          domain.TripRepository
+	airports       *AirportService
+	boardingPasses domain.BoardingPassReader
 }
 
-func NewEventService(repo EventStore) *EventService {
-	return &EventService{repo: repo}
+func NewEventService(repo EventStore, trips domain.TripRepository) *EventService {
+	return &EventService{repo: repo, trips: trips}
+}
+
+// WithAirports makes the service look flight airports up in airports, reject
+// unknown codes and fill in the time zones and coordinates of known airports.
+// Airport names, and all airports without it, are free text.
+func (s *EventService) WithAirports(airports *AirportService) *EventService {
+	s.airports = airports
+	return s
+}
+
+// WithBoardingPasses lets forms be filled in from the barcode of a boarding
+// pass.
+func (s *EventService) WithBoardingPasses(reader domain.BoardingPassReader) *EventService {
+	s.boardingPasses = reader
+	return s
+}
+
+// ReadBoardingPass reads the barcode text of a boarding pass. Text that is not
+// a boarding pass is reported as ErrInvalidInput.
+func (s *EventService) ReadBoardingPass(data string) (*domain.BoardingPass, error) {
+	if s.boardingPasses == nil {
+		return nil, fmt.Errorf("%w: boarding passes cannot be read here", domain.ErrInvalidInput)
+	}
+	pass, err := s.boardingPasses.ReadBoardingPass(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+	return pass, nil
+}
+
+// CompleteFlight normalises and checks the airports of fd and fills in the
+// time zones of known ones, as Create and Update do. Forms call it before
+// reading times, so that times are taken in the airports' zones.
+func (s *EventService) CompleteFlight(fd *domain.FlightDetails) error {
+	if s.airports == nil || fd == nil {
+		return nil
+	}
+	_, err := s.airports.CompleteFlight(fd)
+	return err
+}
+
+// AirportNames returns the names of the airports flights among events leave
+// from and arrive at, by code, for those the directory knows. It is nil
+// without a directory.
+func (s *EventService) AirportNames(events []domain.Event) map[string]string {
+	if s.airports == nil {
+		return nil
+	}
+	names := make(map[string]string)
+	for i := range events {
+		if fd := events[i].Flight; fd != nil {
+			for _, code := range []string{fd.DepartureAirport, fd.ArrivalAirport} {
+				if airport, err := s.airports.Lookup(code); err == nil {
+					names[code] = airport.Name
+				}
+			}
+		}
+	}
+	return names
 }
 
 type CreateEventInput struct {
@@ -40,8 +103,14 @@ type CreateEventInput struct {
 	Category       domain.EventCategory
 	Location       string
 	Notes          string
-	TripID         int
-	Pinned         bool
+	// TimeZone is the IANA zone the event's times are expressed in. Empty uses
+	// the trip's zone.
+	TimeZone string
+	TripID   int
+	Pinned   bool
+	// RejectConflicts makes Create fail with ErrScheduleConflict when the event
+	// would overlap another event of its day, so the caller can warn first.
+	RejectConflicts bool
 }
 
 func (s *EventService) Create(ctx context.Context, input *CreateEventInput) (*domain.Event, error) {
@@ -68,9 +137,14 @@ func (s *EventService) Create(ctx context.Context, input *CreateEventInput) (*do
 		return nil, fmt.Errorf("%w: invalid category %q", domain.ErrInvalidInput, input.Category)
 	}
 
+	trip, err := authorizeTrip(ctx, s.trips, input.TripID, accessEdit)
+	if err != nil {
+		return nil, fmt.Errorf("fetching trip %d: %w", input.TripID, err)
+	}
+
 	event := &domain.Event{
 		TripID:    input.TripID,
-		EventDate: time.Date(input.StartTime.Year(), input.StartTime.Month(), input.StartTime.Day(), 0, 0, 0, 0, input.StartTime.Location()),
+		TimeZone:  input.TimeZone,
 		Title:     input.Title,
 		Category:  input.Category,
 		Location:  input.Location,
@@ -87,6 +161,17 @@ func (s *EventService) Create(ctx context.Context, input *CreateEventInput) (*do
 		if event.Flight == nil {
 			event.Flight = &domain.FlightDetails{}
 		}
+		// A connecting flight spans its segments, whatever times were given
+		start, end, connecting, segErr := applySegments(event.Flight)
+		if segErr != nil {
+			return nil, segErr
+		}
+		if connecting {
+			event.StartTime, event.EndTime = start, end
+		}
+		if err := s.completeFlightEvent(event); err != nil {
+			return nil, err
+		}
 	}
 
 	if input.Category == domain.CategoryLodging {
@@ -105,6 +190,24 @@ func (s *EventService) Create(ctx context.Context, input *CreateEventInput) (*do
 		}
 	}
 
+	if event.TimeZone == "" {
+		event.TimeZone = tripTimeZone(trip)
+	}
+	if err := validateTimeZones(event); err != nil {
+		return nil, err
+	}
+	// The event belongs to the day it starts on where it starts, not in UTC
+	event.EventDate = dateOnly(event.LocalStart())
+	if err := tripRangeError(trip, event.EventDate); err != nil {
+		return nil, err
+	}
+
+	if input.RejectConflicts {
+		if err := s.checkConflicts(ctx, event); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := s.repo.Create(ctx, event); err != nil {
 		return nil, err
 	}
@@ -112,22 +215,55 @@ func (s *EventService) Create(ctx context.Context, input *CreateEventInput) (*do
 	return event, nil
 }
 
+// GetByID returns an event of one of the caller's trips; any other event is
+// reported as ErrNotFound.
 func (s *EventService) GetByID(ctx context.Context, id int) (*domain.Event, error) {
-	return s.repo.GetByID(ctx, id)
+	return s.authorizeEvent(ctx, id, accessRead)
+}
+
+// GetByTripAndID returns an event only if it belongs to tripID; events of other
+// trips are reported as ErrNotFound.
+func (s *EventService) GetByTripAndID(ctx context.Context, tripID, id int) (*domain.Event, error) {
+	if _, err := authorizeTrip(ctx, s.trips, tripID, accessRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByTripAndID(ctx, tripID, id)
 }
 
 func (s *EventService) ListByTrip(ctx context.Context, tripID int) ([]domain.Event, error) {
+	if _, err := authorizeTrip(ctx, s.trips, tripID, accessRead); err != nil {
+		return nil, err
+	}
 	return s.repo.ListByTrip(ctx, tripID)
 }
 
 func (s *EventService) ListByTripAndDate(ctx context.Context, tripID int, date time.Time) ([]domain.Event, error) {
+	if _, err := authorizeTrip(ctx, s.trips, tripID, accessRead); err != nil {
+		return nil, err
+	}
 	return s.repo.ListByTripAndDate(ctx, tripID, date)
 }
 
 func (s *EventService) CountByTrip(ctx context.Context, tripID int) (int, error) {
+	if _, err := authorizeTrip(ctx, s.trips, tripID, accessRead); err != nil {
+		return 0, err
+	}
 	return s.repo.CountByTrip(ctx, tripID)
 }
 
+// authorizeEvent loads event id, checking that the caller may use its trip as
+// need says.
+func (s *EventService) authorizeEvent(ctx context.Context, id int, need tripAccess) (*domain.Event, error) {
+	event, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeTrip(ctx, s.trips, event.TripID, need); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
 type UpdateEventInput struct {
 	Title          *string
 	Category       *domain.EventCategory
@@ -137,11 +273,14 @@ type UpdateEventInput struct {
 	StartTime      *time.Time
 	EndTime        *time.Time
 	Pinned         *bool
-	Position       *int
 	Notes          *string
+	TimeZone       *string
 	FlightDetails  *domain.FlightDetails  // nil means "don't change flight details"
 	LodgingDetails *domain.LodgingDetails // nil means "don't change lodging details"
 	TransitDetails *domain.TransitDetails // nil means "don't change transit details"
+	// RejectConflicts makes Update fail with ErrScheduleConflict when the updated
+	// event would overlap another event of its day.
+	RejectConflicts bool
 }
 
 func (s *EventService) Update(ctx context.Context, id int, input *UpdateEventInput) (*domain.Event, error) {
@@ -149,33 +288,18 @@ func (s *EventService) Update(ctx context.Context, id int, input *UpdateEventInp
 	if input.StartTime != nil && input.EndTime != nil && !input.EndTime.After(*input.StartTime) {
 		return nil, fmt.Errorf("%w: end time must be after start time", domain.ErrInvalidInput)
 	}
-	// When only one time is provided, fetch the event to get the other existing
-	// time and validate the combined result before writing.
-	if (input.StartTime != nil) != (input.EndTime != nil) {
-		existing, err := s.repo.GetByID(ctx, id)
-		if err != nil {
-			return nil, err
-		}
-		effectiveStart := existing.StartTime
-		if input.StartTime != nil {
-			effectiveStart = *input.StartTime
-		}
-		effectiveEnd := existing.EndTime
-		if input.EndTime != nil {
-			effectiveEnd = *input.EndTime
-		}
-		if !effectiveEnd.After(effectiveStart) {
-			return nil, fmt.Errorf("%w: end time must be after start time", domain.ErrInvalidInput)
-		}
-	}
-
 	// When both lodging times are in the input, validate before starting the update.
 	if input.LodgingDetails != nil && input.LodgingDetails.CheckInTime != nil && input.LodgingDetails.CheckOutTime != nil &&
 		!input.LodgingDetails.CheckOutTime.After(*input.LodgingDetails.CheckInTime) {
 		return nil, fmt.Errorf("%w: lodging check-out time must be after check-in time", domain.ErrInvalidInput)
 	}
 
-	return s.repo.Update(ctx, id, func(event *domain.Event) *domain.Event {
+	existing, err := s.authorizeEvent(ctx, id, accessEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	apply := func(event *domain.Event) *domain.Event {
 		if input.Title != nil {
 			event.Title = *input.Title
 		}
@@ -193,7 +317,6 @@ func (s *EventService) Update(ctx context.Context, id int, input *UpdateEventInp
 		}
 		if input.StartTime != nil {
 			event.StartTime = *input.StartTime
-			event.EventDate = time.Date(input.StartTime.Year(), input.StartTime.Month(), input.StartTime.Day(), 0, 0, 0, 0, input.StartTime.Location())
 		}
 		if input.EndTime != nil {
 			event.EndTime = *input.EndTime
@@ -201,12 +324,12 @@ func (s *EventService) Update(ctx context.Context, id int, input *UpdateEventInp
 		if input.Pinned != nil {
 			event.Pinned = *input.Pinned
 		}
-		if input.Position != nil {
-			event.Position = *input.Position
-		}
 		if input.Notes != nil {
 			event.Notes = *input.Notes
 		}
+		if input.TimeZone != nil {
+			event.TimeZone = *input.TimeZone
+		}
 		if input.FlightDetails != nil {
 			event.Flight = input.FlightDetails
 		}
@@ -216,43 +339,342 @@ func (s *EventService) Update(ctx context.Context, id int, input *UpdateEventInp
 		if input.TransitDetails != nil {
 			event.Transit = input.TransitDetails
 		}
+		// A new start time or zone can move the event to another local day
+		if input.StartTime != nil || input.TimeZone != nil || input.FlightDetails != nil {
+			event.EventDate = dateOnly(event.LocalStart())
+		}
 		return event
-	})
+	}
+
+	if input.FlightDetails != nil {
+		start, end, connecting, segErr := applySegments(input.FlightDetails)
+		if segErr != nil {
+			return nil, segErr
+		}
+		if connecting {
+			input.StartTime, input.EndTime = &start, &end
+		}
+	}
+	if err := s.CompleteFlight(input.FlightDetails); err != nil {
+		return nil, err
+	}
+
+	// When times or zones change, apply the input to a copy of the event to
+	// validate the combined result and its new date before writing.
+	dateMayChange := input.StartTime != nil || input.TimeZone != nil || input.FlightDetails != nil
+	if dateMayChange || input.EndTime != nil || input.RejectConflicts {
+		current := *existing
+		candidate := apply(&current)
+		if !candidate.EndTime.After(candidate.StartTime) {
+			return nil, fmt.Errorf("%w: end time must be after start time", domain.ErrInvalidInput)
+		}
+		if err := validateTimeZones(candidate); err != nil {
+			return nil, err
+		}
+		if dateMayChange {
+			if err := s.checkWithinTrip(ctx, candidate.TripID, candidate.EventDate); err != nil {
+				return nil, err
+			}
+		}
+		if input.RejectConflicts {
+			if err := s.checkConflicts(ctx, candidate); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return s.repo.Update(ctx, id, apply)
+}
+
+// applySegments checks the segments of a connecting flight and derives the
+// journey from them: where it departs from, where it arrives and the times it
+// spans. It reports false for a direct flight, which has no segments.
+func applySegments(fd *domain.FlightDetails) (start, end time.Time, ok bool, err error) {
+	if len(fd.Segments) == 0 {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	if len(fd.Segments) == 1 {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("%w: a connecting flight needs at least two segments", domain.ErrInvalidInput)
+	}
+	for i := range fd.Segments {
+		seg := &fd.Segments[i]
+		switch {
+		case strings.TrimSpace(seg.DepartureAirport) == "" || strings.TrimSpace(seg.ArrivalAirport) == "":
+			err = fmt.Errorf("%w: segment %d needs departure and arrival airports", domain.ErrInvalidInput, i+1)
+		case seg.DepartureTime.IsZero() || seg.ArrivalTime.IsZero():
+			err = fmt.Errorf("%w: segment %d needs departure and arrival times", domain.ErrInvalidInput, i+1)
+		case !seg.ArrivalTime.After(seg.DepartureTime):
+			err = fmt.Errorf("%w: segment %d must arrive after it departs", domain.ErrInvalidInput, i+1)
+		case i > 0 && seg.DepartureTime.Before(fd.Segments[i-1].ArrivalTime):
+			err = fmt.Errorf("%w: segment %d departs before segment %d arrives", domain.ErrInvalidInput, i+1, i)
+		}
+		if err != nil {
+			return time.Time{}, time.Time{}, false, err
+		}
+	}
+
+	first, last := &fd.Segments[0], &fd.Segments[len(fd.Segments)-1]
+	fd.DepartureAirport, fd.DepartureTerminal, fd.DepartureGate = first.DepartureAirport, first.DepartureTerminal, first.DepartureGate
+	fd.ArrivalAirport, fd.ArrivalTerminal, fd.ArrivalGate = last.ArrivalAirport, last.ArrivalTerminal, last.ArrivalGate
+	if first.DepartureTimeZone != "" {
+		fd.DepartureTimeZone = first.DepartureTimeZone
+	}
+	if last.ArrivalTimeZone != "" {
+		fd.ArrivalTimeZone = last.ArrivalTimeZone
+	}
+	if fd.Airline == "" && fd.FlightNumber == "" {
+		fd.Airline, fd.FlightNumber = first.Airline, first.FlightNumber
+	}
+	return first.DepartureTime, last.ArrivalTime, true, nil
+}
+
+// completeFlightEvent completes a new flight's airports and, when the event
+// has no place of its own, puts it at the departure airport.
+func (s *EventService) completeFlightEvent(event *domain.Event) error {
+	if s.airports == nil {
+		return nil
+	}
+	departure, err := s.airports.CompleteFlight(event.Flight)
+	if err != nil {
+		return err
+	}
+	if departure != nil && event.Latitude == nil && event.Longitude == nil {
+		lat, lon := departure.Latitude, departure.Longitude
+		event.Latitude, event.Longitude = &lat, &lon
+		if event.Location == "" {
+			event.Location = departure.Name
+		}
+	}
+	return nil
 }
 
 func (s *EventService) Delete(ctx context.Context, id int) error {
+	if _, err := s.authorizeEvent(ctx, id, accessEdit); err != nil {
+		return err
+	}
 	return s.repo.Delete(ctx, id)
 }
 
-func (s *EventService) Restore(ctx context.Context, id int) (*domain.Event, error) {
-	event, err := s.repo.Restore(ctx, id)
+func (s *EventService) Restore(ctx context.Context, tripID, id int) (*domain.Event, error) {
+	if _, err := authorizeTrip(ctx, s.trips, tripID, accessEdit); err != nil {
+		return nil, err
+	}
+	event, err := s.repo.Restore(ctx, tripID, id)
 	if err != nil {
 		return nil, fmt.Errorf("restoring event %d: %w", id, err)
 	}
 	return event, nil
 }
 
-// EventDefaults holds suggested start and end times for a new event.
+// ReorderEvents applies a new order to the events of one day and returns the day's
+// events in their new order. orderedIDs must list every event of the day exactly once.
+// Pinned events keep their slot; flexible events fill the remaining slots in the order given.
+func (s *EventService) ReorderEvents(ctx context.Context, tripID int, date time.Time, orderedIDs []int) ([]domain.Event, error) {
+	if _, err := authorizeTrip(ctx, s.trips, tripID, accessEdit); err != nil {
+		return nil, err
+	}
+	events, err := s.repo.ListByTripAndDate(ctx, tripID, date)
+	if err != nil {
+		return nil, fmt.Errorf("listing events for trip %d on %s: %w", tripID, date.Format("2006-01-02"), err)
+	}
+
+	finalOrder, err := applyPinnedSlots(events, orderedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Reorder(ctx, tripID, date, finalOrder); err != nil {
+		return nil, fmt.Errorf("reordering events for trip %d: %w", tripID, err)
+	}
+
+	return s.repo.ListByTripAndDate(ctx, tripID, date)
+}
+
+// applyPinnedSlots merges a requested order with the current one: pinned events stay
+// at their current index and flexible events are laid into the free slots in the
+// requested order. events must be in current position order.
+func applyPinnedSlots(events []domain.Event, orderedIDs []int) ([]int, error) {
+	if len(orderedIDs) != len(events) {
+		return nil, fmt.Errorf("%w: order must list all %d events of the day", domain.ErrInvalidInput, len(events))
+	}
+
+	pinnedByID := make(map[int]bool, len(events))
+	for i := range events {
+		pinnedByID[events[i].ID] = events[i].Pinned
+	}
+
+	seen := make(map[int]bool, len(orderedIDs))
+	flexible := make([]int, 0, len(orderedIDs))
+	for _, id := range orderedIDs {
+		pinned, ok := pinnedByID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: event %d is not on this day", domain.ErrInvalidInput, id)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("%w: event %d is listed more than once", domain.ErrInvalidInput, id)
+		}
+		seen[id] = true
+		if !pinned {
+			flexible = append(flexible, id)
+		}
+	}
+
+	finalOrder := make([]int, len(events))
+	next := 0
+	for i := range events {
+		if events[i].Pinned {
+			finalOrder[i] = events[i].ID
+			continue
+		}
+		finalOrder[i] = flexible[next]
+		next++
+	}
+	return finalOrder, nil
+}
+
+// MoveEventToDay moves an event to another day of its trip. Start and end times are
+// shifted by whole days so the time of day is kept, and the event is appended after
+// the last event of the target day.
+func (s *EventService) MoveEventToDay(ctx context.Context, id int, targetDate time.Time) (*domain.Event, error) {
+	if targetDate.IsZero() {
+		return nil, fmt.Errorf("%w: target date is required", domain.ErrInvalidInput)
+	}
+
+	event, err := s.authorizeEvent(ctx, id, accessEdit)
+	if err != nil {
+		return nil, fmt.Errorf("fetching event %d: %w", id, err)
+	}
+
+	days := daysBetween(event.EventDate, targetDate)
+	if days == 0 {
+		return event, nil
+	}
+	if err := s.checkWithinTrip(ctx, event.TripID, targetDate); err != nil {
+		return nil, err
+	}
+
+	// Shift in local time so the wall-clock times survive DST changes
+	eventDate := event.EventDate.AddDate(0, 0, days)
+	startTime := event.LocalStart().AddDate(0, 0, days)
+	endTime := event.LocalEnd().AddDate(0, 0, days)
+
+	moved, err := s.repo.MoveToDay(ctx, id, eventDate, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("moving event %d: %w", id, err)
+	}
+	return moved, nil
+}
+
+// daysBetween returns the number of calendar days from a to b, ignoring time of day.
+func daysBetween(a, b time.Time) int {
+	return int(dateOnly(b).Sub(dateOnly(a)).Hours() / 24)
+}
+
+// dateOnly returns t's calendar date at midnight UTC, so dates from different
+// sources compare equal regardless of time of day.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// checkWithinTrip returns ErrDateRangeConflict when date falls outside the trip's
+// date range. Events outside the range would never be shown on the timeline.
+func (s *EventService) checkWithinTrip(ctx context.Context, tripID int, date time.Time) error {
+	trip, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		return fmt.Errorf("fetching trip %d: %w", tripID, err)
+	}
+	return tripRangeError(trip, date)
+}
+
+// tripRangeError is checkWithinTrip for callers that already loaded the trip.
+func tripRangeError(trip *domain.Trip, date time.Time) error {
+	day := dateOnly(date)
+	if day.Before(dateOnly(trip.StartDate)) || day.After(dateOnly(trip.EndDate)) {
+		return fmt.Errorf("%w: %s is outside the trip dates (%s to %s)", domain.ErrDateRangeConflict,
+			day.Format("Mon, Jan 2"), trip.StartDate.Format("Jan 2"), trip.EndDate.Format("Jan 2, 2006"))
+	}
+	return nil
+}
+
+// tripTimeZone returns the zone new events of trip default to.
+func tripTimeZone(trip *domain.Trip) string {
+	if trip.TimeZone == "" {
+		return domain.DefaultTimeZone
+	}
+	return trip.TimeZone
+}
+
+// validateTimeZones checks that the event's zone and, for flights, its
+// departure and arrival zones are known IANA zones.
+func validateTimeZones(event *domain.Event) error {
+	zones := []string{event.TimeZone}
+	if event.Flight != nil {
+		zones = append(zones, event.Flight.DepartureTimeZone, event.Flight.ArrivalTimeZone)
+		for i := range event.Flight.Segments {
+			zones = append(zones, event.Flight.Segments[i].DepartureTimeZone, event.Flight.Segments[i].ArrivalTimeZone)
+		}
+	}
+	for _, zone := range zones {
+		if _, err := domain.LoadTimeZone(zone); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListOutOfRangeEvents reports events whose date falls outside their trip's date
+// range. Such events predate range enforcement and are invisible on the timeline.
+// The report spans every user's trips; it is for maintenance commands only.
+func (s *EventService) ListOutOfRangeEvents(ctx context.Context) ([]domain.OutOfRangeEvent, error) {
+	events, err := s.repo.ListOutsideTripRange(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing events outside their trip: %w", err)
+	}
+	return events, nil
+}
+
+// DefaultTimeZone returns the zone new events of a trip default to: the
+// trip's zone, or UTC when the trip can't be loaded.
+func (s *EventService) DefaultTimeZone(ctx context.Context, tripID int) string {
+	trip, err := authorizeTrip(ctx, s.trips, tripID, accessRead)
+	if err != nil {
+		slog.WarnContext(ctx, "DefaultTimeZone: failed to load trip, using UTC",
+			"trip_id", tripID, "error", err)
+		return domain.DefaultTimeZone
+	}
+	return tripTimeZone(trip)
+}
+
+// EventDefaults holds suggested start and end times for a new event, expressed
+// in TimeZone, the zone the new event defaults to.
 type EventDefaults struct {
 	StartTime time.Time
 	EndTime   time.Time
+	TimeZone  string
 }
 
 // SuggestDefaults returns smart time defaults for a new event on a given day.
 // If the day has existing events, start time = latest end time among them.
 // If no events exist, start time = 9:00 AM on that date.
 // End time = start time + category-based duration.
+// Times are in the zone returned by DefaultTimeZone.
 func (s *EventService) SuggestDefaults(ctx context.Context, tripID int, eventDate time.Time, category domain.EventCategory) EventDefaults {
-	events, err := s.repo.ListByTripAndDate(ctx, tripID, eventDate)
+	zone := s.DefaultTimeZone(ctx, tripID)
+	loc, err := domain.LoadTimeZone(zone)
+	if err != nil {
+		zone, loc = domain.DefaultTimeZone, time.UTC
+	}
+
+	events, err := s.ListByTripAndDate(ctx, tripID, eventDate)
 
 	var startTime time.Time
 	switch {
 	case err != nil:
 		slog.WarnContext(ctx, "SuggestDefaults: failed to list events, using 9:00 AM default",
 			"trip_id", tripID, "error", err)
-		startTime = time.Date(eventDate.Year(), eventDate.Month(), eventDate.Day(), 9, 0, 0, 0, eventDate.Location())
+		startTime = time.Date(eventDate.Year(), eventDate.Month(), eventDate.Day(), 9, 0, 0, 0, loc)
 	case len(events) == 0:
-		startTime = time.Date(eventDate.Year(), eventDate.Month(), eventDate.Day(), 9, 0, 0, 0, eventDate.Location())
+		startTime = time.Date(eventDate.Year(), eventDate.Month(), eventDate.Day(), 9, 0, 0, 0, loc)
 	default:
 		// Find the event with the latest EndTime (not last-by-position)
 		latestEnd := events[0].EndTime
@@ -261,13 +683,14 @@ func (s *EventService) SuggestDefaults(ctx context.Context, tripID int, eventDat
 				latestEnd = events[i+1].EndTime
 			}
 		}
-		startTime = latestEnd
+		startTime = latestEnd.In(loc)
 	}
 
 	duration := durationForCategory(category)
 	return EventDefaults{
 		StartTime: startTime,
 		EndTime:   startTime.Add(duration),
+		TimeZone:  zone,
 	}
 }
 
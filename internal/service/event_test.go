@@ -3,6 +3,8 @@ package service_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -48,6 +50,15 @@ func (m *mockEventRepo) GetByID(_ context.Context, id int) (*domain.Event, error
 	return &cp, nil
 }
 
+func (m *mockEventRepo) GetByTripAndID(_ context.Context, tripID, id int) (*domain.Event, error) {
+	e, ok := m.events[id]
+	if !ok || m.deletedAt[id] || e.TripID != tripID {
+		return nil, domain.ErrNotFound
+	}
+	cp := *e
+	return &cp, nil
+}
+
 func (m *mockEventRepo) ListByTrip(_ context.Context, tripID int) ([]domain.Event, error) {
 	var result []domain.Event
 	for _, e := range m.events {
@@ -58,6 +69,16 @@ func (m *mockEventRepo) ListByTrip(_ context.Context, tripID int) ([]domain.Even
 	return result, nil
 }
 
+func (m *mockEventRepo) ListLodgingByTrip(_ context.Context, tripID int) ([]domain.Event, error) {
+	var result []domain.Event
+	for id, e := range m.events {
+		if e.TripID == tripID && e.Category == domain.CategoryLodging && !m.deletedAt[id] {
+			result = append(result, *e)
+		}
+	}
+	return result, nil
+}
+
 func (m *mockEventRepo) ListByTripAndDate(_ context.Context, tripID int, date time.Time) ([]domain.Event, error) {
 	var result []domain.Event
 	for id, e := range m.events {
@@ -65,6 +86,7 @@ func (m *mockEventRepo) ListByTripAndDate(_ context.Context, tripID int, date ti
 			result = append(result, *e)
 		}
 	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Position < result[j].Position })
 	return result, nil
 }
 
@@ -87,9 +109,9 @@ func (m *mockEventRepo) Delete(_ context.Context, id int) error {
 	return nil
 }
 
-func (m *mockEventRepo) Restore(_ context.Context, id int) (*domain.Event, error) {
+func (m *mockEventRepo) Restore(_ context.Context, tripID, id int) (*domain.Event, error) {
 	e, ok := m.events[id]
-	if !ok || !m.deletedAt[id] {
+	if !ok || !m.deletedAt[id] || e.TripID != tripID {
 		return nil, domain.ErrNotFound
 	}
 	delete(m.deletedAt, id)
@@ -97,6 +119,36 @@ func (m *mockEventRepo) Restore(_ context.Context, id int) (*domain.Event, error
 	return &cp, nil
 }
 
+func (m *mockEventRepo) Reorder(_ context.Context, _ int, _ time.Time, orderedIDs []int) error {
+	for i, id := range orderedIDs {
+		e, ok := m.events[id]
+		if !ok {
+			return domain.ErrNotFound
+		}
+		e.Position = (i + 1) * 1000
+	}
+	return nil
+}
+
+func (m *mockEventRepo) MoveToDay(_ context.Context, id int, eventDate, startTime, endTime time.Time) (*domain.Event, error) {
+	e, ok := m.events[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	maxPos := 0
+	for otherID, other := range m.events {
+		if other.TripID == e.TripID && other.EventDate.Equal(eventDate) && !m.deletedAt[otherID] && other.Position > maxPos {
+			maxPos = other.Position
+		}
+	}
+	e.EventDate = eventDate
+	e.StartTime = startTime
+	e.EndTime = endTime
+	e.Position = maxPos + 1000
+	cp := *e
+	return &cp, nil
+}
+
 func (m *mockEventRepo) CountByTrip(_ context.Context, tripID int) (int, error) {
 	count := 0
 	for _, e := range m.events {
@@ -107,6 +159,23 @@ func (m *mockEventRepo) CountByTrip(_ context.Context, tripID int) (int, error)
 	return count, nil
 }
 
+func (m *mockEventRepo) ListOutsideTripRange(_ context.Context) ([]domain.OutOfRangeEvent, error) {
+	return []domain.OutOfRangeEvent{}, nil
+}
+
+// newTripRepoWith2026Trip returns a trip repository holding trip 1, which spans
+// all of 2026 so that event tests are not constrained by the trip's date range.
+func newTripRepoWith2026Trip() *mockTripRepo {
+	trips := newMockTripRepo()
+	trips.trips[1] = &domain.Trip{
+		ID:        1,
+		Name:      "Test Trip",
+		StartDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+	}
+	return trips
+}
+
 func (m *mockEventRepo) GetLastEventByTrip(_ context.Context, _ int) (*domain.Event, error) {
 	if m.lastEvent != nil {
 		return m.lastEvent, nil
@@ -211,7 +280,7 @@ func TestEventService_Create(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := newMockEventRepo()
-			svc := service.NewEventService(repo)
+			svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 			event, err := svc.Create(context.Background(), tt.input)
 			if tt.wantErr != nil {
@@ -238,7 +307,7 @@ func TestEventService_Create(t *testing.T) {
 
 func TestEventService_Create_ActivityCategory(t *testing.T) {
 	repo := newMockEventRepo()
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	input := &service.CreateEventInput{
 		TripID:    1,
@@ -271,7 +340,7 @@ func TestEventService_Create_ActivityCategory(t *testing.T) {
 
 func TestEventService_Create_FoodCategory(t *testing.T) {
 	repo := newMockEventRepo()
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	input := &service.CreateEventInput{
 		TripID:    1,
@@ -302,7 +371,7 @@ func TestEventService_Create_FoodCategory(t *testing.T) {
 // Position assignment is a repository concern and is tested at the integration level.
 func TestEventService_Create_MultipleEvents(t *testing.T) {
 	repo := newMockEventRepo()
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	inputs := []*service.CreateEventInput{
 		{
@@ -374,7 +443,7 @@ func TestEventService_Update(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := newMockEventRepo()
 			tt.setup(repo)
-			svc := service.NewEventService(repo)
+			svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 			event, err := svc.Update(context.Background(), tt.id, tt.input)
 			if tt.wantErr != nil {
@@ -475,7 +544,7 @@ func TestEventService_SuggestDefaults(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := newMockEventRepo()
 			tt.setup(repo)
-			svc := service.NewEventService(repo)
+			svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 			defaults := svc.SuggestDefaults(context.Background(), 1, eventDate, tt.category)
 
@@ -518,7 +587,7 @@ func TestEventService_Delete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := newMockEventRepo()
 			tt.setup(repo)
-			svc := service.NewEventService(repo)
+			svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 			err := svc.Delete(context.Background(), tt.id)
 			if tt.wantErr != nil {
@@ -551,7 +620,7 @@ func TestEventService_Update_EventDateRecalculation(t *testing.T) {
 		Title:     "Test",
 		Category:  domain.CategoryActivity,
 	}
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	newStart := time.Date(2026, 3, 11, 10, 0, 0, 0, time.UTC)
 	newEnd := time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC)
@@ -583,7 +652,7 @@ func TestEventService_Update_EventDateUnchangedWhenOnlyTitleUpdated(t *testing.T
 		Title:     "Old Title",
 		Category:  domain.CategoryActivity,
 	}
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	updated, err := svc.Update(context.Background(), 1, &service.UpdateEventInput{
 		Title: strPtr("New Title"),
@@ -601,7 +670,7 @@ func TestEventService_Update_EventDateUnchangedWhenOnlyTitleUpdated(t *testing.T
 
 func TestEventService_Update_InvalidEndTimeBeforeStartTime(t *testing.T) {
 	repo := newMockEventRepo()
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	start := time.Date(2026, 3, 10, 11, 0, 0, 0, time.UTC)
 	end := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC) // before start
@@ -617,7 +686,7 @@ func TestEventService_Update_InvalidEndTimeBeforeStartTime(t *testing.T) {
 
 func TestEventService_Update_NotFound(t *testing.T) {
 	repo := newMockEventRepo()
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	title := "X"
 	_, err := svc.Update(context.Background(), 999, &service.UpdateEventInput{Title: &title})
@@ -637,7 +706,7 @@ func TestEventService_Update_OnlyStartTimeMovedPastEndTime(t *testing.T) {
 		Title:     "Test",
 		Category:  domain.CategoryActivity,
 	}
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	// Move StartTime to 12:00, past existing EndTime of 11:00 — no EndTime provided.
 	newStart := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
@@ -659,7 +728,7 @@ func TestEventService_DeleteAndRestoreRoundTrip(t *testing.T) {
 		Title:     "Test Event",
 		Category:  domain.CategoryActivity,
 	}
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	// Delete (soft)
 	if err := svc.Delete(context.Background(), 1); err != nil {
@@ -676,7 +745,7 @@ func TestEventService_DeleteAndRestoreRoundTrip(t *testing.T) {
 	}
 
 	// Restore
-	restored, err := svc.Restore(context.Background(), 1)
+	restored, err := svc.Restore(context.Background(), 1, 1)
 	if err != nil {
 		t.Fatalf("Restore() unexpected error: %v", err)
 	}
@@ -696,9 +765,9 @@ func TestEventService_DeleteAndRestoreRoundTrip(t *testing.T) {
 
 func TestEventService_Restore_NotFound(t *testing.T) {
 	repo := newMockEventRepo()
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
-	_, err := svc.Restore(context.Background(), 999)
+	_, err := svc.Restore(context.Background(), 1, 999)
 	if !errors.Is(err, domain.ErrNotFound) {
 		t.Errorf("Restore() error = %v, want ErrNotFound", err)
 	}
@@ -710,7 +779,7 @@ func TestEventService_ListByTripAndDate_ExcludesSoftDeleted(t *testing.T) {
 	repo.events[1] = &domain.Event{ID: 1, TripID: 1, EventDate: eventDate, Title: "Keep"}
 	repo.events[2] = &domain.Event{ID: 2, TripID: 1, EventDate: eventDate, Title: "Deleted"}
 	repo.deletedAt[2] = true
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	events, err := svc.ListByTripAndDate(context.Background(), 1, eventDate)
 	if err != nil {
@@ -728,7 +797,7 @@ func TestEventService_ListByTripAndDate_ExcludesSoftDeleted(t *testing.T) {
 
 func TestEventService_Create_FlightEvent_PopulatesFlightDetails(t *testing.T) {
 	repo := newMockEventRepo()
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	input := &service.CreateEventInput{
 		TripID:    1,
@@ -765,7 +834,7 @@ func TestEventService_Create_FlightEvent_PopulatesFlightDetails(t *testing.T) {
 
 func TestEventService_Create_FlightEvent_NilDetailsDefaultsToEmpty(t *testing.T) {
 	repo := newMockEventRepo()
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	input := &service.CreateEventInput{
 		TripID:        1,
@@ -803,7 +872,7 @@ func TestEventService_Update_FlightEvent_UpdatesFlightDetails(t *testing.T) {
 			FlightNumber: "234",
 		},
 	}
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	updatedDetails := &domain.FlightDetails{
 		Airline:      "LH",
@@ -837,7 +906,7 @@ func TestEventService_Update_NonFlightEvent_NilDetailsUnchanged(t *testing.T) {
 		EndTime:   time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC),
 		Flight:    nil,
 	}
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	// Provide nil FlightDetails — should not change anything
 	event, err := svc.Update(context.Background(), 1, &service.UpdateEventInput{
@@ -859,7 +928,7 @@ func TestEventService_Update_NonFlightEvent_NilDetailsUnchanged(t *testing.T) {
 
 func TestEventService_Create_LodgingEvent_PopulatesLodgingDetails(t *testing.T) {
 	repo := newMockEventRepo()
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	checkIn := time.Date(2026, 6, 1, 15, 0, 0, 0, time.UTC)
 	checkOut := time.Date(2026, 6, 5, 11, 0, 0, 0, time.UTC)
@@ -891,7 +960,7 @@ func TestEventService_Create_LodgingEvent_PopulatesLodgingDetails(t *testing.T)
 
 func TestEventService_Create_LodgingEvent_NilDetailsDefaultsToEmpty(t *testing.T) {
 	repo := newMockEventRepo()
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	checkIn := time.Date(2026, 6, 1, 15, 0, 0, 0, time.UTC)
 	checkOut := time.Date(2026, 6, 5, 11, 0, 0, 0, time.UTC)
@@ -935,7 +1004,7 @@ func TestEventService_Update_LodgingEvent_UpdatesLodgingDetails(t *testing.T) {
 			CheckOutTime:     &oldCheckOut,
 		},
 	}
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	updatedDetails := &domain.LodgingDetails{
 		BookingReference: "XYZ789",
@@ -965,7 +1034,7 @@ func TestEventService_Update_NonLodgingEvent_NilLodgingDetailsUnchanged(t *testi
 		EndTime:   time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC),
 		Lodging:   nil,
 	}
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	event, err := svc.Update(context.Background(), 1, &service.UpdateEventInput{
 		Title:          strPtr("Updated Walk"),
@@ -984,7 +1053,7 @@ func TestEventService_Update_NonLodgingEvent_NilLodgingDetailsUnchanged(t *testi
 
 func TestEventService_SuggestDefaults_FlightDuration(t *testing.T) {
 	repo := newMockEventRepo()
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	eventDate := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
 	defaults := svc.SuggestDefaults(context.Background(), 1, eventDate, domain.CategoryFlight)
@@ -998,7 +1067,7 @@ func TestEventService_SuggestDefaults_FlightDuration(t *testing.T) {
 
 func TestEventService_Create_Lodging_Validation(t *testing.T) {
 	repo := newMockEventRepo()
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	checkIn := time.Date(2026, 6, 1, 15, 0, 0, 0, time.UTC)
 	checkOut := time.Date(2026, 6, 1, 11, 0, 0, 0, time.UTC) // Before check-in
@@ -1032,7 +1101,7 @@ func TestEventService_Update_Lodging_Validation(t *testing.T) {
 		Category: domain.CategoryLodging,
 		Title:    "Hotel",
 	}
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	checkIn := time.Date(2026, 6, 1, 15, 0, 0, 0, time.UTC)
 	checkOut := time.Date(2026, 6, 1, 11, 0, 0, 0, time.UTC) // Before check-in
@@ -1054,7 +1123,7 @@ func TestEventService_Update_Lodging_Validation(t *testing.T) {
 
 func TestEventService_Create_TransitEvent_PopulatesTransitDetails(t *testing.T) {
 	repo := newMockEventRepo()
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	input := &service.CreateEventInput{
 		TripID:    1,
@@ -1089,7 +1158,7 @@ func TestEventService_Create_TransitEvent_PopulatesTransitDetails(t *testing.T)
 
 func TestEventService_Create_TransitEvent_NilDetailsDefaultsToEmpty(t *testing.T) {
 	repo := newMockEventRepo()
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	input := &service.CreateEventInput{
 		TripID:         1,
@@ -1127,7 +1196,7 @@ func TestEventService_Update_TransitEvent_UpdatesTransitDetails(t *testing.T) {
 			TransportMode: "Metro",
 		},
 	}
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	event, err := svc.Update(context.Background(), 1, &service.UpdateEventInput{
 		TransitDetails: &domain.TransitDetails{
@@ -1161,7 +1230,7 @@ func TestEventService_Update_NonTransitEvent_NilTransitDetailsUnchanged(t *testi
 		EndTime:   time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC),
 		Transit:   nil,
 	}
-	svc := service.NewEventService(repo)
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
 
 	event, err := svc.Update(context.Background(), 1, &service.UpdateEventInput{
 		Title:          strPtr("Updated Walk"),
@@ -1177,3 +1246,527 @@ func TestEventService_Update_NonTransitEvent_NilTransitDetailsUnchanged(t *testi
 		t.Errorf("Title = %q, want %q", event.Title, "Updated Walk")
 	}
 }
+
+func TestEventService_ReorderEvents(t *testing.T) {
+	day := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		wantErr   error
+		name      string
+		pinned    map[int]bool
+		order     []int
+		wantOrder []int
+	}{
+		{
+			name:      "flexible events follow requested order",
+			order:     []int{3, 1, 2},
+			wantOrder: []int{3, 1, 2},
+		},
+		{
+			name:      "pinned event keeps its slot",
+			pinned:    map[int]bool{2: true},
+			order:     []int{2, 3, 1},
+			wantOrder: []int{3, 2, 1},
+		},
+		{
+			name:      "flexible events flow around pinned anchor",
+			pinned:    map[int]bool{1: true},
+			order:     []int{1, 3, 2},
+			wantOrder: []int{1, 3, 2},
+		},
+		{
+			name:    "missing event is rejected",
+			order:   []int{1, 2},
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name:    "duplicate event is rejected",
+			order:   []int{1, 1, 2},
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name:    "event from another day is rejected",
+			order:   []int{1, 2, 99},
+			wantErr: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockEventRepo()
+			for id := 1; id <= 3; id++ {
+				repo.events[id] = &domain.Event{
+					ID:        id,
+					TripID:    1,
+					EventDate: day,
+					Title:     fmt.Sprintf("Event %d", id),
+					Position:  id * 1000,
+					Pinned:    tt.pinned[id],
+				}
+			}
+			svc := service.NewEventService(repo, newTripRepoWith2026Trip())
+
+			events, err := svc.ReorderEvents(context.Background(), 1, day, tt.order)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ReorderEvents() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReorderEvents() unexpected error: %v", err)
+			}
+
+			got := make([]int, len(events))
+			for i := range events {
+				got[i] = events[i].ID
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.wantOrder) {
+				t.Errorf("ReorderEvents() order = %v, want %v", got, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestEventService_MoveEventToDay(t *testing.T) {
+	day1 := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	day3 := time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		target        time.Time
+		wantErr       error
+		name          string
+		eventID       int
+		wantStart     time.Time
+		wantEnd       time.Time
+		wantDate      time.Time
+		wantPosition  int
+		wantUnchanged bool
+	}{
+		{
+			name:         "shifts times and appends to target day",
+			eventID:      1,
+			target:       day3,
+			wantDate:     day3,
+			wantStart:    time.Date(2026, 5, 3, 10, 0, 0, 0, time.UTC),
+			wantEnd:      time.Date(2026, 5, 3, 12, 30, 0, 0, time.UTC),
+			wantPosition: 6000,
+		},
+		{
+			name:         "event ending after midnight keeps its span",
+			eventID:      2,
+			target:       day3,
+			wantDate:     day3,
+			wantStart:    time.Date(2026, 5, 3, 22, 0, 0, 0, time.UTC),
+			wantEnd:      time.Date(2026, 5, 4, 1, 0, 0, 0, time.UTC),
+			wantPosition: 6000,
+		},
+		{
+			name:          "same day is a no-op",
+			eventID:       1,
+			target:        day1,
+			wantUnchanged: true,
+		},
+		{
+			name:    "missing target date is rejected",
+			eventID: 1,
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name:    "unknown event",
+			eventID: 99,
+			target:  day3,
+			wantErr: domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockEventRepo()
+			repo.events[1] = &domain.Event{
+				ID: 1, TripID: 1, EventDate: day1, Title: "Museum", Position: 1000,
+				StartTime: time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2026, 5, 1, 12, 30, 0, 0, time.UTC),
+			}
+			repo.events[2] = &domain.Event{
+				ID: 2, TripID: 1, EventDate: day1, Title: "Night train", Position: 2000,
+				StartTime: time.Date(2026, 5, 1, 22, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2026, 5, 2, 1, 0, 0, 0, time.UTC),
+			}
+			repo.events[3] = &domain.Event{
+				ID: 3, TripID: 1, EventDate: day3, Title: "Dinner", Position: 5000,
+				StartTime: time.Date(2026, 5, 3, 19, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2026, 5, 3, 21, 0, 0, 0, time.UTC),
+			}
+			before, _ := repo.GetByID(context.Background(), tt.eventID)
+			svc := service.NewEventService(repo, newTripRepoWith2026Trip())
+
+			got, err := svc.MoveEventToDay(context.Background(), tt.eventID, tt.target)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("MoveEventToDay() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MoveEventToDay() unexpected error: %v", err)
+			}
+
+			if tt.wantUnchanged {
+				if got.Position != before.Position || !got.StartTime.Equal(before.StartTime) {
+					t.Errorf("MoveEventToDay() changed event on same-day move: %+v", got)
+				}
+				return
+			}
+			if !got.EventDate.Equal(tt.wantDate) {
+				t.Errorf("EventDate = %v, want %v", got.EventDate, tt.wantDate)
+			}
+			if !got.StartTime.Equal(tt.wantStart) {
+				t.Errorf("StartTime = %v, want %v", got.StartTime, tt.wantStart)
+			}
+			if !got.EndTime.Equal(tt.wantEnd) {
+				t.Errorf("EndTime = %v, want %v", got.EndTime, tt.wantEnd)
+			}
+			if got.Position != tt.wantPosition {
+				t.Errorf("Position = %d, want %d", got.Position, tt.wantPosition)
+			}
+		})
+	}
+}
+
+func TestEventService_TripDateRange(t *testing.T) {
+	ctx := context.Background()
+	newSvc := func() (*service.EventService, *mockEventRepo) {
+		repo := newMockEventRepo()
+		repo.events[1] = &domain.Event{
+			ID: 1, TripID: 1, Title: "Museum", Position: 1000,
+			EventDate: time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC),
+			StartTime: time.Date(2026, 5, 2, 10, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2026, 5, 2, 12, 0, 0, 0, time.UTC),
+		}
+		trips := newMockTripRepo()
+		trips.trips[1] = &domain.Trip{
+			ID:        1,
+			Name:      "Rome",
+			StartDate: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC),
+		}
+		return service.NewEventService(repo, trips), repo
+	}
+
+	tests := []struct {
+		run     func(svc *service.EventService) error
+		wantErr error
+		name    string
+	}{
+		{
+			name: "create on last day is allowed",
+			run: func(svc *service.EventService) error {
+				_, err := svc.Create(ctx, &service.CreateEventInput{
+					TripID:    1,
+					Title:     "Farewell dinner",
+					StartTime: time.Date(2026, 5, 3, 19, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2026, 5, 3, 21, 0, 0, 0, time.UTC),
+				})
+				return err
+			},
+		},
+		{
+			name: "create before trip start is rejected",
+			run: func(svc *service.EventService) error {
+				_, err := svc.Create(ctx, &service.CreateEventInput{
+					TripID:    1,
+					Title:     "Too early",
+					StartTime: time.Date(2026, 4, 30, 9, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2026, 4, 30, 10, 0, 0, 0, time.UTC),
+				})
+				return err
+			},
+			wantErr: domain.ErrDateRangeConflict,
+		},
+		{
+			name: "create for unknown trip fails",
+			run: func(svc *service.EventService) error {
+				_, err := svc.Create(ctx, &service.CreateEventInput{
+					TripID:    2,
+					Title:     "Orphan",
+					StartTime: time.Date(2026, 5, 2, 9, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2026, 5, 2, 10, 0, 0, 0, time.UTC),
+				})
+				return err
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name: "update start past trip end is rejected",
+			run: func(svc *service.EventService) error {
+				start := time.Date(2026, 5, 4, 10, 0, 0, 0, time.UTC)
+				end := time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)
+				_, err := svc.Update(ctx, 1, &service.UpdateEventInput{StartTime: &start, EndTime: &end})
+				return err
+			},
+			wantErr: domain.ErrDateRangeConflict,
+		},
+		{
+			name: "update within trip is allowed",
+			run: func(svc *service.EventService) error {
+				start := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+				end := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+				_, err := svc.Update(ctx, 1, &service.UpdateEventInput{StartTime: &start, EndTime: &end})
+				return err
+			},
+		},
+		{
+			name: "move outside trip is rejected",
+			run: func(svc *service.EventService) error {
+				_, err := svc.MoveEventToDay(ctx, 1, time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC))
+				return err
+			},
+			wantErr: domain.ErrDateRangeConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newSvc()
+			before := *repo.events[1]
+
+			err := tt.run(svc)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := repo.events[1]; !got.StartTime.Equal(before.StartTime) || !got.EventDate.Equal(before.EventDate) {
+				t.Errorf("event was modified despite error: %+v", got)
+			}
+			if len(repo.events) != 1 {
+				t.Errorf("event was created despite error")
+			}
+		})
+	}
+}
+
+func TestEventService_TimeZones(t *testing.T) {
+	ctx := context.Background()
+	newSvc := func() (*service.EventService, *mockEventRepo) {
+		repo := newMockEventRepo()
+		trips := newTripRepoWith2026Trip()
+		trips.trips[1].TimeZone = "Asia/Tokyo"
+		return service.NewEventService(repo, trips), repo
+	}
+
+	t.Run("event date is the local day in the trip zone", func(t *testing.T) {
+		svc, _ := newSvc()
+		// 16:00 UTC on May 1 is 01:00 on May 2 in Tokyo
+		event, err := svc.Create(ctx, &service.CreateEventInput{
+			TripID:    1,
+			Title:     "Tsukiji breakfast",
+			StartTime: time.Date(2026, 5, 1, 16, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2026, 5, 1, 17, 0, 0, 0, time.UTC),
+		})
+		if err != nil {
+			t.Fatalf("Create() unexpected error: %v", err)
+		}
+		if event.TimeZone != "Asia/Tokyo" {
+			t.Errorf("TimeZone = %q, want trip zone %q", event.TimeZone, "Asia/Tokyo")
+		}
+		if want := time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC); !event.EventDate.Equal(want) {
+			t.Errorf("EventDate = %v, want %v", event.EventDate, want)
+		}
+		if got := event.LocalStart().Format("15:04"); got != "01:00" {
+			t.Errorf("LocalStart() = %s, want 01:00", got)
+		}
+	})
+
+	t.Run("flight date follows the departure zone", func(t *testing.T) {
+		svc, _ := newSvc()
+		// 23:30 UTC on May 1 is 19:30 on May 1 in New York, already May 2 in Tokyo
+		event, err := svc.Create(ctx, &service.CreateEventInput{
+			TripID:    1,
+			Title:     "JFK to NRT",
+			Category:  domain.CategoryFlight,
+			StartTime: time.Date(2026, 5, 1, 23, 30, 0, 0, time.UTC),
+			EndTime:   time.Date(2026, 5, 2, 13, 30, 0, 0, time.UTC),
+			FlightDetails: &domain.FlightDetails{
+				DepartureAirport:  "JFK",
+				ArrivalAirport:    "NRT",
+				DepartureTimeZone: "America/New_York",
+			},
+		})
+		if err != nil {
+			t.Fatalf("Create() unexpected error: %v", err)
+		}
+		if want := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC); !event.EventDate.Equal(want) {
+			t.Errorf("EventDate = %v, want %v", event.EventDate, want)
+		}
+		if got := event.LocalEnd().Format("Jan 2 15:04"); got != "May 2 22:30" {
+			t.Errorf("LocalEnd() = %s, want May 2 22:30 in the event zone", got)
+		}
+	})
+
+	t.Run("unknown zones are rejected", func(t *testing.T) {
+		svc, _ := newSvc()
+		_, err := svc.Create(ctx, &service.CreateEventInput{
+			TripID:    1,
+			Title:     "Somewhere",
+			TimeZone:  "Nowhere/Special",
+			StartTime: time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2026, 5, 1, 11, 0, 0, 0, time.UTC),
+		})
+		if !errors.Is(err, domain.ErrInvalidInput) {
+			t.Errorf("Create() error = %v, want ErrInvalidInput", err)
+		}
+	})
+
+	t.Run("changing the zone recomputes the date", func(t *testing.T) {
+		svc, _ := newSvc()
+		event, err := svc.Create(ctx, &service.CreateEventInput{
+			TripID:    1,
+			Title:     "Late call",
+			StartTime: time.Date(2026, 5, 1, 16, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2026, 5, 1, 17, 0, 0, 0, time.UTC),
+		})
+		if err != nil {
+			t.Fatalf("Create() unexpected error: %v", err)
+		}
+		zone := "Europe/London"
+		updated, err := svc.Update(ctx, event.ID, &service.UpdateEventInput{TimeZone: &zone})
+		if err != nil {
+			t.Fatalf("Update() unexpected error: %v", err)
+		}
+		if want := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC); !updated.EventDate.Equal(want) {
+			t.Errorf("EventDate = %v, want %v", updated.EventDate, want)
+		}
+	})
+
+	t.Run("move keeps wall-clock time across a DST change", func(t *testing.T) {
+		repo := newMockEventRepo()
+		rome, _ := time.LoadLocation("Europe/Rome")
+		repo.events[1] = &domain.Event{
+			ID: 1, TripID: 1, Title: "Vatican", TimeZone: "Europe/Rome",
+			EventDate: time.Date(2026, 3, 28, 0, 0, 0, 0, time.UTC),
+			StartTime: time.Date(2026, 3, 28, 9, 0, 0, 0, rome),
+			EndTime:   time.Date(2026, 3, 28, 12, 0, 0, 0, rome),
+		}
+		svc := service.NewEventService(repo, newTripRepoWith2026Trip())
+
+		moved, err := svc.MoveEventToDay(ctx, 1, time.Date(2026, 3, 30, 0, 0, 0, 0, time.UTC))
+		if err != nil {
+			t.Fatalf("MoveEventToDay() unexpected error: %v", err)
+		}
+		if got := moved.LocalStart().Format("Jan 2 15:04"); got != "Mar 30 09:00" {
+			t.Errorf("LocalStart() = %s, want Mar 30 09:00", got)
+		}
+	})
+}
+
+func TestEventService_Create_ConnectingFlight(t *testing.T) {
+	segment := func(from, to string, dep, arr time.Time) domain.FlightSegment {
+		return domain.FlightSegment{DepartureAirport: from, ArrivalAirport: to, DepartureTime: dep, ArrivalTime: arr}
+	}
+
+	tests := []struct {
+		name     string
+		segments []domain.FlightSegment
+		wantErr  bool
+	}{
+		{
+			name: "two segments",
+			segments: []domain.FlightSegment{
+				segment("LHR", "FRA", at(7, 0), at(8, 40)),
+				segment("FRA", "NRT", at(10, 0), at(21, 30)),
+			},
+		},
+		{name: "a single segment", segments: []domain.FlightSegment{segment("LHR", "FRA", at(7, 0), at(8, 40))}, wantErr: true},
+		{
+			name: "arrives before departing",
+			segments: []domain.FlightSegment{
+				segment("LHR", "FRA", at(7, 0), at(6, 40)),
+				segment("FRA", "NRT", at(10, 0), at(21, 30)),
+			},
+			wantErr: true,
+		},
+		{
+			name: "overlapping segments",
+			segments: []domain.FlightSegment{
+				segment("LHR", "FRA", at(7, 0), at(8, 40)),
+				segment("FRA", "NRT", at(8, 0), at(21, 30)),
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing airport",
+			segments: []domain.FlightSegment{
+				segment("LHR", "", at(7, 0), at(8, 40)),
+				segment("FRA", "NRT", at(10, 0), at(21, 30)),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := service.NewEventService(newMockEventRepo(), newTripRepoWith2026Trip())
+			event, err := svc.Create(context.Background(), &service.CreateEventInput{
+				TripID:        1,
+				Title:         "London to Tokyo",
+				Category:      domain.CategoryFlight,
+				StartTime:     at(6, 0),
+				EndTime:       at(7, 0),
+				FlightDetails: &domain.FlightDetails{Segments: tt.segments},
+			})
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidInput) {
+					t.Fatalf("Create() error = %v, want ErrInvalidInput", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			// The journey and the event's times come from the segments
+			if event.Flight.DepartureAirport != "LHR" || event.Flight.ArrivalAirport != "NRT" {
+				t.Errorf("journey = %s → %s, want LHR → NRT", event.Flight.DepartureAirport, event.Flight.ArrivalAirport)
+			}
+			if !event.StartTime.Equal(at(7, 0)) || !event.EndTime.Equal(at(21, 30)) {
+				t.Errorf("times = %v – %v, want the segments' span", event.StartTime, event.EndTime)
+			}
+		})
+	}
+}
+
+func TestEventService_Update_ConnectingFlight(t *testing.T) {
+	repo := newMockEventRepo()
+	repo.events[1] = &domain.Event{
+		ID:        1,
+		TripID:    1,
+		Title:     "London to Tokyo",
+		Category:  domain.CategoryFlight,
+		StartTime: time.Date(2026, 6, 1, 7, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2026, 6, 1, 21, 0, 0, 0, time.UTC),
+		Flight:    &domain.FlightDetails{DepartureAirport: "LHR", ArrivalAirport: "NRT"},
+	}
+	svc := service.NewEventService(repo, newTripRepoWith2026Trip())
+
+	// The new segments run overnight into the next day
+	dep := time.Date(2026, 6, 1, 21, 0, 0, 0, time.UTC)
+	event, err := svc.Update(context.Background(), 1, &service.UpdateEventInput{
+		FlightDetails: &domain.FlightDetails{Segments: []domain.FlightSegment{
+			{DepartureAirport: "LHR", ArrivalAirport: "DXB", DepartureTime: dep, ArrivalTime: dep.Add(7 * time.Hour)},
+			{DepartureAirport: "DXB", ArrivalAirport: "NRT", DepartureTime: dep.Add(9 * time.Hour), ArrivalTime: dep.Add(19 * time.Hour)},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if !event.StartTime.Equal(dep) || !event.EndTime.Equal(dep.Add(19*time.Hour)) {
+		t.Errorf("times = %v – %v, want the segments' span", event.StartTime, event.EndTime)
+	}
+	if len(event.Flight.Segments) != 2 || event.Flight.ArrivalAirport != "NRT" {
+		t.Errorf("flight = %+v", event.Flight)
+	}
+}
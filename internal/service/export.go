@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+// ExportService renders trips into documents meant to leave the app.
+type ExportService struct {
+	pdf       domain.PDFExporter
+	calendar  domain.CalendarExporter
+	maps      domain.MapExporter
+	itinerary domain.ItineraryExporter
+}
+
+func NewExportService(pdf domain.PDFExporter, calendar domain.CalendarExporter, maps domain.MapExporter, itinerary domain.ItineraryExporter) *ExportService {
+	return &ExportService{pdf: pdf, calendar: calendar, maps: maps, itinerary: itinerary}
+}
+
+// PDF writes the trip's itinerary as a printable PDF.
+func (s *ExportService) PDF(ctx context.Context, w io.Writer, trip *domain.Trip, days []domain.ItineraryDay) error {
+	if err := s.pdf.ExportPDF(ctx, w, trip, days); err != nil {
+		return fmt.Errorf("exporting trip %d as PDF: %w", trip.ID, err)
+	}
+	return nil
+}
+
+// Calendar writes the trip's events as an iCalendar file.
+func (s *ExportService) Calendar(w io.Writer, trip *domain.Trip, events []domain.Event) error {
+	if err := s.calendar.ExportCalendar(w, trip, events); err != nil {
+		return fmt.Errorf("exporting trip %d as iCalendar: %w", trip.ID, err)
+	}
+	return nil
+}
+
+// GPX writes the places of the trip's days as GPX waypoints and routes.
+func (s *ExportService) GPX(w io.Writer, trip *domain.Trip, days []domain.ItineraryDay) error {
+	if err := s.maps.ExportGPX(w, trip, days); err != nil {
+		return fmt.Errorf("exporting trip %d as GPX: %w", trip.ID, err)
+	}
+	return nil
+}
+
+// KML writes the places of the trip's days as KML, one folder per day.
+func (s *ExportService) KML(w io.Writer, trip *domain.Trip, days []domain.ItineraryDay) error {
+	if err := s.maps.ExportKML(w, trip, days); err != nil {
+		return fmt.Errorf("exporting trip %d as KML: %w", trip.ID, err)
+	}
+	return nil
+}
+
+// GeoJSON writes the places of the trip's days as a GeoJSON FeatureCollection.
+func (s *ExportService) GeoJSON(w io.Writer, trip *domain.Trip, days []domain.ItineraryDay) error {
+	if err := s.maps.ExportGeoJSON(w, trip, days); err != nil {
+		return fmt.Errorf("exporting trip %d as GeoJSON: %w", trip.ID, err)
+	}
+	return nil
+}
+
+// Markdown writes the trip's itinerary as Markdown.
+func (s *ExportService) Markdown(w io.Writer, trip *domain.Trip, days []domain.ItineraryDay) error {
+	if err := s.itinerary.ExportMarkdown(w, trip, days); err != nil {
+		return fmt.Errorf("exporting trip %d as Markdown: %w", trip.ID, err)
+	}
+	return nil
+}
+
+// Text writes the trip's itinerary as plain text.
+func (s *ExportService) Text(w io.Writer, trip *domain.Trip, days []domain.ItineraryDay) error {
+	if err := s.itinerary.ExportText(w, trip, days); err != nil {
+		return fmt.Errorf("exporting trip %d as text: %w", trip.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,381 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+// Wall-clock times used for all-day entries, which carry no time of day.
+const (
+	allDayStartHour    = 9
+	allDayCheckInHour  = 15
+	allDayCheckOutHour = 11
+)
+
+// ImportService turns calendars and booking emails from other apps into events
+// of a trip. Imports are previewed as candidates first, and only the chosen
+// ones are created.
+type ImportService struct {
+	events       *EventService
+	calendar     domain.CalendarParser
+	reservations domain.ReservationReader
+}
+
+func NewImportService(events *EventService, calendar domain.CalendarParser, reservations domain.ReservationReader) *ImportService {
+	return &ImportService{events: events, calendar: calendar, reservations: reservations}
+}
+
+// ImportCandidate is one event found in an upload, as previewed before the
+// import is committed.
+type ImportCandidate struct {
+	Input *CreateEventInput
+	// Err is set when creating the event failed during the import.
+	Err   error
+	Index int
+	// OutOfRange marks events outside the trip dates, which cannot be imported.
+	OutOfRange bool
+	// Existing marks events already in the trip: exported from it, or the
+	// same flight or stay.
+	Existing bool
+	// Imported marks events created by an import that failed part way.
+	Imported bool
+}
+
+// Selectable reports whether the candidate can still be imported.
+func (c *ImportCandidate) Selectable() bool {
+	return !c.OutOfRange && !c.Imported
+}
+
+// CalendarCandidates reads the events of an iCalendar file and maps them onto
+// trip. categories overrides the guessed category by event index. Files that
+// cannot be read are reported as ErrInvalidInput.
+func (s *ImportService) CalendarCandidates(ctx context.Context, trip *domain.Trip, data string, categories map[int]domain.EventCategory) ([]ImportCandidate, error) {
+	entries, err := s.calendar.ParseCalendar(data, domain.ZoneOrUTC(trip.TimeZone))
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not read calendar: %v", domain.ErrInvalidInput, err)
+	}
+	found := make([]foundEvent, len(entries))
+	for i := range entries {
+		found[i] = foundEvent{
+			input:   calendarInput(trip, &entries[i], categories[i]),
+			eventID: exportedEventID(entries[i].UID),
+		}
+	}
+	return s.candidates(ctx, trip, found)
+}
+
+// ReservationCandidates reads the flight and hotel bookings of a confirmation
+// email and maps them onto trip, leaving out cancelled ones. Emails that cannot
+// be read or hold no bookings are reported as ErrInvalidInput.
+func (s *ImportService) ReservationCandidates(ctx context.Context, trip *domain.Trip, data string) ([]ImportCandidate, error) {
+	reservations, err := s.reservations.ReadReservations([]byte(data), domain.ZoneOrUTC(trip.TimeZone))
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not read email: %v", domain.ErrInvalidInput, err)
+	}
+	var found []foundEvent
+	for i := range reservations {
+		if !reservations[i].Cancelled {
+			found = append(found, foundEvent{input: reservationInput(trip, &reservations[i])})
+		}
+	}
+	candidates, err := s.candidates(ctx, trip, found)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w: the email has no flight or hotel reservations to import", domain.ErrInvalidInput)
+	}
+	return candidates, nil
+}
+
+// foundEvent is an event read from an upload, with the ID of the event it was
+// exported from, if any.
+type foundEvent struct {
+	input   *CreateEventInput
+	eventID int
+}
+
+// candidates checks found against trip and its events. Repeats of a flight or
+// stay, as when an email carries both JSON-LD and microdata, are left out;
+// candidates keep their index in found, so a submitted preview still matches.
+func (s *ImportService) candidates(ctx context.Context, trip *domain.Trip, found []foundEvent) ([]ImportCandidate, error) {
+	existing, err := s.events.ListByTrip(ctx, trip.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not load trip events: %w", err)
+	}
+	existingIDs := make(map[int]bool, len(existing))
+	existingKeys := make(map[string]bool)
+	for i := range existing {
+		existingIDs[existing[i].ID] = true
+		if key := eventBookingKey(&existing[i]); key != "" {
+			existingKeys[key] = true
+		}
+	}
+
+	candidates := []ImportCandidate{}
+	seen := make(map[string]bool)
+	for i, f := range found {
+		key := bookingKey(f.input)
+		if key != "" {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		candidates = append(candidates, ImportCandidate{
+			Index:      i,
+			Input:      f.input,
+			OutOfRange: outsideTrip(trip, f.input),
+			Existing:   existingIDs[f.eventID] || existingKeys[key],
+		})
+	}
+	return candidates, nil
+}
+
+// Create creates the candidates in selected that can still be imported,
+// recording each failure on its candidate. It reports whether all succeeded.
+func (s *ImportService) Create(ctx context.Context, candidates []ImportCandidate, selected map[int]bool) bool {
+	ok := true
+	for i := range candidates {
+		c := &candidates[i]
+		if !selected[c.Index] || !c.Selectable() {
+			continue
+		}
+		if _, err := s.events.Create(ctx, c.Input); err != nil {
+			c.Err = err
+			ok = false
+			continue
+		}
+		c.Imported = true
+	}
+	return ok
+}
+
+// outsideTrip reports whether input starts on a day outside the trip dates.
+func outsideTrip(trip *domain.Trip, input *CreateEventInput) bool {
+	zone := domain.ZoneOrUTC(trip.TimeZone)
+	if loc, err := domain.LoadTimeZone(input.TimeZone); err == nil {
+		zone = loc
+	}
+	day := input.StartTime.In(zone)
+	date := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	return date.Before(trip.StartDate) || date.After(trip.EndDate)
+}
+
+// categoryKeywords guess an imported event's category, checked in order: a
+// "flight to the hotel" is a flight.
+var categoryKeywords = []struct {
+	pattern  *regexp.Regexp
+	category domain.EventCategory
+}{
+	{regexp.MustCompile(`\b(flights?|airlines?|boarding)\b|✈`), domain.CategoryFlight},
+	{regexp.MustCompile(`\b(hotels?|hostels?|motel|airbnb|lodging|accommodation|guest ?house|resort|b&b|check-in|stay at)\b`), domain.CategoryLodging},
+	{regexp.MustCompile(`\b(trains?|rail|bus|coach|ferry|metro|subway|shuttle|taxi|transfer|car rental)\b`), domain.CategoryTransit},
+	{regexp.MustCompile(`\b(restaurant|dinner|lunch|breakfast|brunch|cafe|bistro|trattoria|osteria|tasting|food)\b|\bcafé`), domain.CategoryFood},
+}
+
+// guessCategory picks a category from an entry's summary and categories,
+// defaulting to activity. Calendars exported by traccia name the category.
+func guessCategory(e *domain.CalendarEntry) domain.EventCategory {
+	if category := domain.EventCategory(strings.ToLower(e.Categories)); domain.IsValidEventCategory(category) {
+		return category
+	}
+	text := strings.ToLower(e.Summary + " " + e.Categories)
+	for _, ck := range categoryKeywords {
+		if ck.pattern.MatchString(text) {
+			return ck.category
+		}
+	}
+	return domain.CategoryActivity
+}
+
+var (
+	flightNumberPattern = regexp.MustCompile(`\b([A-Z][A-Z0-9]|[A-Z0-9][A-Z])\s?(\d{1,4})\b`)
+	airportPairPattern  = regexp.MustCompile(`\b([A-Z]{3})\s*(?:-|–|→|>|to)\s*([A-Z]{3})\b`)
+)
+
+// calendarInput maps a calendar entry to an event of trip, in category unless
+// that is empty.
+func calendarInput(trip *domain.Trip, e *domain.CalendarEntry, category domain.EventCategory) *CreateEventInput {
+	input := &CreateEventInput{
+		TripID:    trip.ID,
+		Title:     e.Summary,
+		Category:  category,
+		Location:  e.Location,
+		Latitude:  e.Latitude,
+		Longitude: e.Longitude,
+		Notes:     e.Description,
+		TimeZone:  e.TimeZone,
+	}
+	if !domain.IsValidEventCategory(input.Category) {
+		input.Category = guessCategory(e)
+	}
+	if input.Title == "" {
+		input.Title = "Untitled event"
+	}
+	zone := domain.ZoneOrUTC(trip.TimeZone)
+	if input.TimeZone == "" {
+		input.TimeZone = trip.TimeZone
+	} else if eventLoc, err := domain.LoadTimeZone(input.TimeZone); err == nil {
+		zone = eventLoc
+	}
+	setCalendarTimes(input, e, zone)
+
+	switch input.Category {
+	case domain.CategoryFlight:
+		input.FlightDetails = &domain.FlightDetails{}
+		if m := flightNumberPattern.FindStringSubmatch(e.Summary); m != nil {
+			input.FlightDetails.FlightNumber = m[1] + m[2]
+		}
+		if m := airportPairPattern.FindStringSubmatch(e.Summary + " " + e.Location); m != nil {
+			input.FlightDetails.DepartureAirport, input.FlightDetails.ArrivalAirport = m[1], m[2]
+		}
+	case domain.CategoryTransit:
+		input.TransitDetails = &domain.TransitDetails{}
+	}
+	return input
+}
+
+// setCalendarTimes fills the start and end of input. Lodging spanning several
+// days keeps the span as its check-in and check-out, with a one hour event
+// for the arrival itself.
+func setCalendarTimes(input *CreateEventInput, e *domain.CalendarEntry, loc *time.Location) {
+	start, end := e.Start, e.End
+	if !e.HasEnd {
+		end = start.Add(e.Duration)
+	}
+
+	if e.AllDay {
+		startDay := start.In(loc)
+		endDay := end.In(loc)
+		if !endDay.After(startDay) {
+			endDay = startDay.AddDate(0, 0, 1)
+		}
+		if input.Category == domain.CategoryLodging {
+			checkIn := atHour(startDay, allDayCheckInHour)
+			checkOut := atHour(endDay, allDayCheckOutHour)
+			input.LodgingDetails = &domain.LodgingDetails{CheckInTime: &checkIn, CheckOutTime: &checkOut}
+			input.StartTime, input.EndTime = checkIn, checkIn.Add(time.Hour)
+			return
+		}
+		input.StartTime = atHour(startDay, allDayStartHour)
+		input.EndTime = input.StartTime.Add(DefaultActivityDuration)
+		return
+	}
+
+	if !end.After(start) {
+		end = start.Add(time.Hour)
+	}
+	input.StartTime, input.EndTime = start, end
+	if input.Category == domain.CategoryLodging {
+		input.LodgingDetails = &domain.LodgingDetails{}
+		localStart, localEnd := start.In(loc), end.In(loc)
+		if localStart.YearDay() != localEnd.YearDay() || localStart.Year() != localEnd.Year() {
+			checkIn, checkOut := start, end
+			input.LodgingDetails.CheckInTime, input.LodgingDetails.CheckOutTime = &checkIn, &checkOut
+			input.EndTime = start.Add(time.Hour)
+		}
+	}
+}
+
+func atHour(day time.Time, hour int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, day.Location())
+}
+
+// exportedEventID returns the event ID of a UID written by the calendar
+// export, such as "event-42@traccia", or 0.
+func exportedEventID(uid string) int {
+	idStr, ok := strings.CutPrefix(uid, "event-")
+	if !ok {
+		return 0
+	}
+	idStr, ok = strings.CutSuffix(idStr, "@traccia")
+	if !ok {
+		return 0
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// reservationInput maps a booking to an event of trip. Stays booked by date
+// use the same check-in and check-out hours as all-day calendar entries.
+func reservationInput(trip *domain.Trip, r *domain.Reservation) *CreateEventInput {
+	input := &CreateEventInput{
+		TripID:    trip.ID,
+		TimeZone:  trip.TimeZone,
+		Latitude:  r.Latitude,
+		Longitude: r.Longitude,
+		StartTime: r.Start,
+	}
+	if r.Flight != nil {
+		fd := *r.Flight
+		fd.BookingReference = r.BookingReference
+		input.Category, input.FlightDetails = domain.CategoryFlight, &fd
+		input.Title = "Flight"
+		if fd.FlightNumber != "" {
+			input.Title += " " + fd.FlightNumber
+		}
+		if fd.DepartureAirport != "" && fd.ArrivalAirport != "" {
+			input.Title += " " + fd.DepartureAirport + " → " + fd.ArrivalAirport
+		}
+		input.EndTime = r.End
+		if input.EndTime.Before(r.Start) {
+			input.EndTime = r.Start.Add(time.Hour)
+		}
+		return input
+	}
+
+	checkIn := r.Start
+	if r.StartDateOnly {
+		checkIn = atHour(checkIn, allDayCheckInHour)
+	}
+	ld := &domain.LodgingDetails{CheckInTime: &checkIn, BookingReference: r.BookingReference}
+	if !r.End.IsZero() {
+		checkOut := r.End
+		if r.EndDateOnly {
+			checkOut = atHour(checkOut, allDayCheckOutHour)
+		}
+		if checkOut.After(checkIn) {
+			ld.CheckOutTime = &checkOut
+		}
+	}
+	input.Category, input.LodgingDetails = domain.CategoryLodging, ld
+	input.Title, input.Location = r.Name, r.Address
+	if input.Title == "" {
+		input.Title = "Lodging"
+	}
+	input.StartTime, input.EndTime = checkIn, checkIn.Add(time.Hour)
+	return input
+}
+
+// bookingKey identifies a flight or stay across the copies of one upload and
+// the events already in a trip. It is empty for other events.
+func bookingKey(input *CreateEventInput) string {
+	switch {
+	case input.FlightDetails != nil && input.FlightDetails.FlightNumber != "":
+		return fmt.Sprintf("flight|%s|%d", input.FlightDetails.FlightNumber, input.StartTime.Unix())
+	case input.LodgingDetails != nil:
+		return fmt.Sprintf("lodging|%s|%s|%d", input.LodgingDetails.BookingReference, input.Title, input.StartTime.Unix())
+	}
+	return ""
+}
+
+// eventBookingKey is the bookingKey of an event in the trip. Stays are keyed
+// by their check-in, as imported ones start at it.
+func eventBookingKey(e *domain.Event) string {
+	input := &CreateEventInput{Title: e.Title, StartTime: e.StartTime, FlightDetails: e.Flight}
+	if e.Lodging != nil && e.Lodging.CheckInTime != nil {
+		input.LodgingDetails = e.Lodging
+		input.StartTime = *e.Lodging.CheckInTime
+	}
+	return bookingKey(input)
+}
@@ -0,0 +1,282 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+// stubCalendar returns fixed entries for any calendar, or err.
+type stubCalendar struct {
+	err     error
+	entries []domain.CalendarEntry
+}
+
+func (s stubCalendar) ParseCalendar(_ string, _ *time.Location) ([]domain.CalendarEntry, error) {
+	return s.entries, s.err
+}
+
+// stubReservations returns fixed reservations for any email, or err.
+type stubReservations struct {
+	err          error
+	reservations []domain.Reservation
+}
+
+func (s stubReservations) ReadReservations(_ []byte, _ *time.Location) ([]domain.Reservation, error) {
+	return s.reservations, s.err
+}
+
+func newImportFixture(t *testing.T, calendar domain.CalendarParser, reservations domain.ReservationReader) (*service.ImportService, *domain.Trip, *mockEventRepo) {
+	t.Helper()
+	trip := &domain.Trip{
+		ID:        1,
+		TimeZone:  "Europe/Rome",
+		StartDate: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 5, 5, 0, 0, 0, 0, time.UTC),
+	}
+	trips := newMockTripRepo()
+	trips.trips[trip.ID] = trip
+	events := newMockEventRepo()
+	events.events[42] = &domain.Event{ID: 42, TripID: trip.ID, Title: "Vatican Museums"}
+	return service.NewImportService(service.NewEventService(events, trips), calendar, reservations), trip, events
+}
+
+func TestImportService_CalendarCandidates(t *testing.T) {
+	rome, _ := time.LoadLocation("Europe/Rome")
+	newYork, _ := time.LoadLocation("America/New_York")
+	lat, lon := 41.9009, 12.4936
+	entries := []domain.CalendarEntry{
+		{
+			UID:      "abc-1",
+			Summary:  "Flight AZ 610 FCO-JFK",
+			Start:    time.Date(2026, 5, 3, 10, 15, 0, 0, rome),
+			End:      time.Date(2026, 5, 3, 13, 50, 0, 0, newYork),
+			HasEnd:   true,
+			TimeZone: "Europe/Rome",
+		},
+		{
+			UID:       "abc-2",
+			Summary:   "Hotel Artemide",
+			Location:  "Via Nazionale 22, Roma",
+			Latitude:  &lat,
+			Longitude: &lon,
+			Start:     time.Date(2026, 5, 1, 0, 0, 0, 0, rome),
+			End:       time.Date(2026, 5, 3, 0, 0, 0, 0, rome),
+			HasEnd:    true,
+			AllDay:    true,
+		},
+		{
+			UID:         "abc-3",
+			Summary:     "Dinner at Roscioli",
+			Description: "Ask for the carbonara",
+			Start:       time.Date(2026, 5, 1, 18, 0, 0, 0, time.UTC),
+			Duration:    90 * time.Minute,
+		},
+		{
+			UID:     "event-42@traccia",
+			Summary: "Vatican Museums",
+			Start:   time.Date(2026, 5, 2, 9, 0, 0, 0, rome),
+			End:     time.Date(2026, 5, 2, 12, 0, 0, 0, rome),
+			HasEnd:  true,
+		},
+		{
+			UID:     "abc-4",
+			Summary: "Train to Florence",
+			Start:   time.Date(2026, 6, 10, 8, 0, 0, 0, time.UTC),
+			End:     time.Date(2026, 6, 10, 9, 30, 0, 0, time.UTC),
+			HasEnd:  true,
+		},
+	}
+	svc, trip, _ := newImportFixture(t, stubCalendar{entries: entries}, stubReservations{})
+
+	candidates, err := svc.CalendarCandidates(context.Background(), trip, "", nil)
+	if err != nil {
+		t.Fatalf("CalendarCandidates() error = %v", err)
+	}
+	if len(candidates) != 5 {
+		t.Fatalf("got %d candidates, want 5", len(candidates))
+	}
+
+	flight := candidates[0].Input
+	if flight.Category != domain.CategoryFlight || flight.TimeZone != "Europe/Rome" {
+		t.Errorf("flight = %s in %q", flight.Category, flight.TimeZone)
+	}
+	if want := time.Date(2026, 5, 3, 17, 50, 0, 0, time.UTC); !flight.EndTime.Equal(want) {
+		t.Errorf("flight end = %v, want %v", flight.EndTime, want)
+	}
+	if fd := flight.FlightDetails; fd == nil || fd.FlightNumber != "AZ610" || fd.DepartureAirport != "FCO" || fd.ArrivalAirport != "JFK" {
+		t.Errorf("flight details = %+v", fd)
+	}
+
+	hotel := candidates[1].Input
+	if hotel.Category != domain.CategoryLodging || hotel.Latitude == nil || *hotel.Latitude != lat {
+		t.Errorf("hotel = %s at %v", hotel.Category, hotel.Latitude)
+	}
+	ld := hotel.LodgingDetails
+	if ld == nil || ld.CheckInTime == nil || ld.CheckOutTime == nil {
+		t.Fatalf("hotel lodging details = %+v", ld)
+	}
+	if got := ld.CheckInTime.In(rome).Format("Jan 2 15:04"); got != "May 1 15:00" {
+		t.Errorf("hotel check-in = %s", got)
+	}
+	if got := ld.CheckOutTime.In(rome).Format("Jan 2 15:04"); got != "May 3 11:00" {
+		t.Errorf("hotel check-out = %s", got)
+	}
+
+	dinner := candidates[2].Input
+	if dinner.Category != domain.CategoryFood || dinner.TimeZone != "Europe/Rome" {
+		t.Errorf("dinner = %s in %q, want food in the trip zone", dinner.Category, dinner.TimeZone)
+	}
+	if dinner.EndTime.Sub(dinner.StartTime) != 90*time.Minute {
+		t.Errorf("dinner duration = %v, want 1h30m", dinner.EndTime.Sub(dinner.StartTime))
+	}
+
+	museum := candidates[3]
+	if museum.Input.Category != domain.CategoryActivity || !museum.Existing {
+		t.Errorf("museum = %s, existing %v; want an activity exported from this trip", museum.Input.Category, museum.Existing)
+	}
+
+	train := candidates[4]
+	if train.Input.Category != domain.CategoryTransit || !train.OutOfRange || train.Selectable() {
+		t.Errorf("train = %s, out of range %v", train.Input.Category, train.OutOfRange)
+	}
+	for _, c := range candidates[:4] {
+		if c.OutOfRange {
+			t.Errorf("%q flagged out of range", c.Input.Title)
+		}
+	}
+
+	t.Run("category override", func(t *testing.T) {
+		overridden, err := svc.CalendarCandidates(context.Background(), trip, "", map[int]domain.EventCategory{2: domain.CategoryActivity})
+		if err != nil {
+			t.Fatalf("CalendarCandidates() error = %v", err)
+		}
+		if got := overridden[2].Input.Category; got != domain.CategoryActivity {
+			t.Errorf("category = %q, want override", got)
+		}
+	})
+
+	t.Run("unreadable calendar", func(t *testing.T) {
+		svc, trip, _ := newImportFixture(t, stubCalendar{err: errors.New("not an iCalendar file")}, stubReservations{})
+		_, err := svc.CalendarCandidates(context.Background(), trip, "hello", nil)
+		if !errors.Is(err, domain.ErrInvalidInput) || !strings.Contains(err.Error(), "not an iCalendar file") {
+			t.Errorf("error = %v, want ErrInvalidInput", err)
+		}
+	})
+}
+
+func TestImportService_Create(t *testing.T) {
+	rome, _ := time.LoadLocation("Europe/Rome")
+	entry := func(summary string, day int) domain.CalendarEntry {
+		start := time.Date(2026, 5, day, 18, 0, 0, 0, rome)
+		return domain.CalendarEntry{Summary: summary, Start: start, End: start.Add(time.Hour), HasEnd: true}
+	}
+	svc, trip, events := newImportFixture(t, stubCalendar{entries: []domain.CalendarEntry{
+		entry("Dinner", 1),
+		entry("Already imported", 2),
+		entry("Not selected", 3),
+		entry("After the trip", 9),
+	}}, stubReservations{})
+
+	candidates, err := svc.CalendarCandidates(context.Background(), trip, "", nil)
+	if err != nil {
+		t.Fatalf("CalendarCandidates() error = %v", err)
+	}
+	candidates[1].Imported = true
+	if !svc.Create(context.Background(), candidates, map[int]bool{0: true, 1: true, 3: true}) {
+		t.Errorf("Create() = false, want true")
+	}
+	var titles []string
+	for _, e := range events.events {
+		if e.ID != 42 {
+			titles = append(titles, e.Title)
+		}
+	}
+	if len(titles) != 1 || titles[0] != "Dinner" {
+		t.Errorf("created %v, want only the dinner", titles)
+	}
+	if !candidates[0].Imported || candidates[2].Imported || candidates[3].Imported {
+		t.Errorf("imported = %v %v %v %v", candidates[0].Imported, candidates[1].Imported, candidates[2].Imported, candidates[3].Imported)
+	}
+}
+
+func TestImportService_ReservationCandidates(t *testing.T) {
+	rome, _ := time.LoadLocation("Europe/Rome")
+	departure := time.Date(2026, 5, 3, 10, 15, 0, 0, rome)
+	flight := domain.Reservation{
+		Start:            departure,
+		End:              departure.Add(9 * time.Hour),
+		BookingReference: "QX7P2L",
+		Flight:           &domain.FlightDetails{FlightNumber: "AZ610", DepartureAirport: "FCO", ArrivalAirport: "JFK"},
+	}
+	reservations := []domain.Reservation{
+		flight,
+		// The same flight again, as when an email carries JSON-LD and microdata
+		{Start: departure, Flight: &domain.FlightDetails{FlightNumber: "AZ610"}},
+		{Start: departure.AddDate(0, 0, -1), Cancelled: true, Flight: &domain.FlightDetails{FlightNumber: "AZ608"}},
+		{
+			Name:             "Hotel Artemide",
+			Address:          "Via Nazionale 22, Roma",
+			BookingReference: "HTL-778",
+			Start:            time.Date(2026, 5, 1, 0, 0, 0, 0, rome),
+			StartDateOnly:    true,
+			End:              time.Date(2026, 5, 3, 0, 0, 0, 0, rome),
+			EndDateOnly:      true,
+		},
+	}
+	svc, trip, events := newImportFixture(t, stubCalendar{}, stubReservations{reservations: reservations})
+
+	candidates, err := svc.ReservationCandidates(context.Background(), trip, "")
+	if err != nil {
+		t.Fatalf("ReservationCandidates() error = %v", err)
+	}
+	if len(candidates) != 2 {
+		t.Fatalf("got %d candidates, want the flight and the hotel", len(candidates))
+	}
+
+	got := candidates[0].Input
+	if got.Category != domain.CategoryFlight || got.Title != "Flight AZ610 FCO → JFK" || got.FlightDetails.BookingReference != "QX7P2L" {
+		t.Errorf("flight = %q (%s), details %+v", got.Title, got.Category, got.FlightDetails)
+	}
+	if !got.EndTime.Equal(flight.End) || got.TimeZone != "Europe/Rome" {
+		t.Errorf("flight ends %v in %q", got.EndTime, got.TimeZone)
+	}
+
+	hotel := candidates[1]
+	ld := hotel.Input.LodgingDetails
+	if hotel.Index != 2 || hotel.Input.Title != "Hotel Artemide" || hotel.Input.Location != "Via Nazionale 22, Roma" {
+		t.Errorf("hotel = %d: %q at %q", hotel.Index, hotel.Input.Title, hotel.Input.Location)
+	}
+	if ld == nil || ld.BookingReference != "HTL-778" ||
+		!ld.CheckInTime.Equal(time.Date(2026, 5, 1, 15, 0, 0, 0, rome)) ||
+		!ld.CheckOutTime.Equal(time.Date(2026, 5, 3, 11, 0, 0, 0, rome)) {
+		t.Errorf("lodging details = %+v", ld)
+	}
+	if candidates[0].Existing || hotel.Existing || hotel.OutOfRange {
+		t.Errorf("flags = %+v, %+v", candidates[0], hotel)
+	}
+
+	t.Run("already imported", func(t *testing.T) {
+		events.events[43] = &domain.Event{ID: 43, TripID: trip.ID, Title: "AZ 610", StartTime: departure, Flight: &domain.FlightDetails{FlightNumber: "AZ610"}}
+		defer delete(events.events, 43)
+		c, err := svc.ReservationCandidates(context.Background(), trip, "")
+		if err != nil {
+			t.Fatalf("ReservationCandidates() error = %v", err)
+		}
+		if !c[0].Existing || c[1].Existing {
+			t.Errorf("existing = %v, %v; want only the flight", c[0].Existing, c[1].Existing)
+		}
+	})
+
+	t.Run("no reservations", func(t *testing.T) {
+		svc, trip, _ := newImportFixture(t, stubCalendar{}, stubReservations{reservations: reservations[2:3]})
+		if _, err := svc.ReservationCandidates(context.Background(), trip, ""); !errors.Is(err, domain.ErrInvalidInput) {
+			t.Errorf("error = %v, want ErrInvalidInput", err)
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+// ListStays returns the trip's lodging events, which can mark days other than
+// their own: check-in, every night in between, and check-out.
+func (s *EventService) ListStays(ctx context.Context, tripID int) ([]domain.Event, error) {
+	if _, err := authorizeTrip(ctx, s.trips, tripID, accessRead); err != nil {
+		return nil, err
+	}
+	lodgings, err := s.repo.ListLodgingByTrip(ctx, tripID)
+	if err != nil {
+		return nil, fmt.Errorf("listing lodging for trip %d: %w", tripID, err)
+	}
+	return lodgings, nil
+}
+
+// StaysOn returns the stay markers for date from the given lodging events, in
+// the order given. Lodging without a multi-day check-in/check-out window has
+// no markers; its event card is enough.
+func StaysOn(lodgings []domain.Event, date time.Time) []domain.DayStay {
+	day := dateOnly(date)
+	var stays []domain.DayStay
+	for i := range lodgings {
+		checkIn, checkOut, ok := lodgings[i].StayDates()
+		if !ok || day.Before(checkIn) || day.After(checkOut) {
+			continue
+		}
+		kind := domain.StayNight
+		switch {
+		case day.Equal(checkIn):
+			kind = domain.StayCheckIn
+		case day.Equal(checkOut):
+			kind = domain.StayCheckOut
+		}
+		stays = append(stays, domain.DayStay{Lodging: &lodgings[i], Kind: kind})
+	}
+	return stays
+}
+
+// StayDays returns every date from check-in to check-out of the given lodging
+// events, without duplicates. Changing one of them changes the markers on all
+// of these days.
+func StayDays(lodgings ...*domain.Event) []time.Time {
+	seen := make(map[time.Time]bool)
+	var days []time.Time
+	for _, event := range lodgings {
+		if event == nil {
+			continue
+		}
+		checkIn, checkOut, ok := event.StayDates()
+		if !ok {
+			continue
+		}
+		for d := checkIn; !d.After(checkOut); d = d.AddDate(0, 0, 1) {
+			if !seen[d] {
+				seen[d] = true
+				days = append(days, d)
+			}
+		}
+	}
+	return days
+}
+
+// NightsWithoutLodging reports the nights of a trip that neither lodging nor an
+// overnight journey covers.
+func (s *EventService) NightsWithoutLodging(ctx context.Context, tripID int) ([]time.Time, error) {
+	trip, err := authorizeTrip(ctx, s.trips, tripID, accessRead)
+	if err != nil {
+		return nil, fmt.Errorf("loading trip %d: %w", tripID, err)
+	}
+	events, err := s.repo.ListByTrip(ctx, tripID)
+	if err != nil {
+		return nil, fmt.Errorf("listing events for trip %d: %w", tripID, err)
+	}
+	return UncoveredNights(trip, events), nil
+}
+
+// UncoveredNights returns the nights of trip with nowhere to sleep, each as the
+// date the night starts on. The last day of a trip has no night: the traveller
+// goes home. A night is covered by a lodging stay from check-in until
+// check-out, or by a flight or transit that is still underway at local
+// midnight. Lodging without a check-out covers the night of its own day only.
+func UncoveredNights(trip *domain.Trip, events []domain.Event) []time.Time {
+	covered := make(map[time.Time]bool)
+	for i := range events {
+		event := &events[i]
+		switch event.Category {
+		case domain.CategoryLodging:
+			checkIn, checkOut, ok := event.StayDates()
+			if !ok {
+				covered[dateOnly(event.EventDate)] = true
+				continue
+			}
+			for d := checkIn; d.Before(checkOut); d = d.AddDate(0, 0, 1) {
+				covered[d] = true
+			}
+		case domain.CategoryFlight, domain.CategoryTransit:
+			departs, arrives := dateOnly(event.LocalStart()), dateOnly(event.LocalEnd())
+			for d := departs; d.Before(arrives); d = d.AddDate(0, 0, 1) {
+				covered[d] = true
+			}
+		}
+	}
+
+	var nights []time.Time
+	for d := dateOnly(trip.StartDate); d.Before(dateOnly(trip.EndDate)); d = d.AddDate(0, 0, 1) {
+		if !covered[d] {
+			nights = append(nights, d)
+		}
+	}
+	return nights
+}
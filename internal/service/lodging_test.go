@@ -0,0 +1,160 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+func day(d int) time.Time {
+	return time.Date(2026, 5, d, 0, 0, 0, 0, time.UTC)
+}
+
+func lodging(id int, zone string, checkIn, checkOut time.Time) domain.Event {
+	return domain.Event{
+		ID:        id,
+		Title:     "Hotel",
+		Category:  domain.CategoryLodging,
+		TimeZone:  zone,
+		StartTime: checkIn,
+		EndTime:   checkIn.Add(time.Hour),
+		Lodging:   &domain.LodgingDetails{CheckInTime: &checkIn, CheckOutTime: &checkOut},
+	}
+}
+
+func TestStaysOn(t *testing.T) {
+	threeNights := lodging(1, "UTC",
+		time.Date(2026, 5, 2, 15, 0, 0, 0, time.UTC),
+		time.Date(2026, 5, 5, 11, 0, 0, 0, time.UTC))
+	// 15:00 UTC on May 1 is midnight May 2 in Tokyo
+	tokyo := lodging(2, "Asia/Tokyo",
+		time.Date(2026, 5, 1, 15, 0, 0, 0, time.UTC),
+		time.Date(2026, 5, 3, 2, 0, 0, 0, time.UTC))
+	sameDay := lodging(3, "UTC",
+		time.Date(2026, 5, 2, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, 5, 2, 18, 0, 0, 0, time.UTC))
+	noCheckOut := lodging(4, "UTC", time.Date(2026, 5, 2, 15, 0, 0, 0, time.UTC), time.Time{})
+	noCheckOut.Lodging.CheckOutTime = nil
+
+	tests := []struct {
+		name     string
+		lodgings []domain.Event
+		date     time.Time
+		want     []domain.StayKind
+	}{
+		{name: "day before check-in", lodgings: []domain.Event{threeNights}, date: day(1), want: nil},
+		{name: "check-in day", lodgings: []domain.Event{threeNights}, date: day(2), want: []domain.StayKind{domain.StayCheckIn}},
+		{name: "intermediate night", lodgings: []domain.Event{threeNights}, date: day(3), want: []domain.StayKind{domain.StayNight}},
+		{name: "last night", lodgings: []domain.Event{threeNights}, date: day(4), want: []domain.StayKind{domain.StayNight}},
+		{name: "check-out day", lodgings: []domain.Event{threeNights}, date: day(5), want: []domain.StayKind{domain.StayCheckOut}},
+		{name: "day after check-out", lodgings: []domain.Event{threeNights}, date: day(6), want: nil},
+		{name: "dates follow the lodging zone", lodgings: []domain.Event{tokyo}, date: day(2), want: []domain.StayKind{domain.StayCheckIn}},
+		{name: "zone check-out day", lodgings: []domain.Event{tokyo}, date: day(3), want: []domain.StayKind{domain.StayCheckOut}},
+		{name: "same-day lodging has no markers", lodgings: []domain.Event{sameDay}, date: day(2), want: nil},
+		{name: "missing check-out has no markers", lodgings: []domain.Event{noCheckOut}, date: day(3), want: nil},
+		{
+			name:     "changeover day shows check-out then check-in",
+			lodgings: []domain.Event{threeNights, lodging(5, "UTC", time.Date(2026, 5, 5, 16, 0, 0, 0, time.UTC), time.Date(2026, 5, 7, 10, 0, 0, 0, time.UTC))},
+			date:     day(5),
+			want:     []domain.StayKind{domain.StayCheckOut, domain.StayCheckIn},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stays := service.StaysOn(tt.lodgings, tt.date)
+			if len(stays) != len(tt.want) {
+				t.Fatalf("got %d stays, want %d", len(stays), len(tt.want))
+			}
+			for i, stay := range stays {
+				if stay.Kind != tt.want[i] {
+					t.Errorf("stay %d: got %q, want %q", i, stay.Kind, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStayDays(t *testing.T) {
+	before := lodging(1, "UTC",
+		time.Date(2026, 5, 2, 15, 0, 0, 0, time.UTC),
+		time.Date(2026, 5, 4, 11, 0, 0, 0, time.UTC))
+	after := lodging(1, "UTC",
+		time.Date(2026, 5, 3, 15, 0, 0, 0, time.UTC),
+		time.Date(2026, 5, 5, 11, 0, 0, 0, time.UTC))
+
+	got := service.StayDays(&before, &after, nil)
+	want := []time.Time{day(2), day(3), day(4), day(5)}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("day %d: got %s, want %s", i, got[i].Format("2006-01-02"), want[i].Format("2006-01-02"))
+		}
+	}
+}
+
+func TestUncoveredNights(t *testing.T) {
+	// Nights of May 1 to May 5; May 6 is the day home
+	trip := &domain.Trip{ID: 1, StartDate: day(1), EndDate: day(6)}
+
+	hotel := lodging(1, "UTC",
+		time.Date(2026, 5, 1, 15, 0, 0, 0, time.UTC),
+		time.Date(2026, 5, 3, 11, 0, 0, 0, time.UTC))
+	secondHotel := lodging(2, "UTC",
+		time.Date(2026, 5, 4, 15, 0, 0, 0, time.UTC),
+		time.Date(2026, 5, 6, 11, 0, 0, 0, time.UTC))
+	oneNight := lodging(3, "UTC", time.Date(2026, 5, 3, 15, 0, 0, 0, time.UTC), time.Time{})
+	oneNight.Lodging.CheckOutTime = nil
+	oneNight.EventDate = day(3)
+	redEye := domain.Event{
+		ID:        4,
+		Category:  domain.CategoryFlight,
+		TimeZone:  "Europe/Rome",
+		StartTime: time.Date(2026, 5, 3, 20, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2026, 5, 4, 11, 0, 0, 0, time.UTC),
+		Flight:    &domain.FlightDetails{DepartureTimeZone: "Europe/Rome", ArrivalTimeZone: "America/New_York"},
+	}
+	// 22:00 to 23:30 in Tokyo is 13:00 to 14:30 UTC: not overnight
+	eveningTrain := domain.Event{
+		ID:        5,
+		Category:  domain.CategoryTransit,
+		TimeZone:  "Asia/Tokyo",
+		StartTime: time.Date(2026, 5, 3, 13, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2026, 5, 3, 14, 30, 0, 0, time.UTC),
+	}
+	dayTrip := domain.Event{
+		ID:        6,
+		Category:  domain.CategoryActivity,
+		StartTime: time.Date(2026, 5, 3, 20, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2026, 5, 4, 2, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name   string
+		events []domain.Event
+		want   []time.Time
+	}{
+		{name: "no lodging leaves every night uncovered", want: []time.Time{day(1), day(2), day(3), day(4), day(5)}},
+		{name: "gap between two stays", events: []domain.Event{hotel, secondHotel}, want: []time.Time{day(3)}},
+		{name: "lodging without check-out covers its own night", events: []domain.Event{hotel, oneNight, secondHotel}, want: nil},
+		{name: "overnight flight covers the night", events: []domain.Event{hotel, redEye, secondHotel}, want: nil},
+		{name: "transit ending before midnight does not", events: []domain.Event{hotel, eveningTrain, secondHotel}, want: []time.Time{day(3)}},
+		{name: "other overnight events do not", events: []domain.Event{hotel, dayTrip, secondHotel}, want: []time.Time{day(3)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := service.UncoveredNights(trip, tt.events)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("night %d: got %s, want %s", i, got[i].Format("2006-01-02"), tt.want[i].Format("2006-01-02"))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+// MemberService manages who plans a trip together with its owner. The owner
+// invites other users as editors or viewers, and an invitation only takes
+// effect once its invitee accepts it.
+type MemberService struct {
+	members domain.TripMemberRepository
+	trips   domain.TripRepository
+	users   domain.UserRepository
+}
+
+func NewMemberService(members domain.TripMemberRepository, trips domain.TripRepository, users domain.UserRepository) *MemberService {
+	return &MemberService{members: members, trips: trips, users: users}
+}
+
+// Invite asks invitee, a username or an email, to join the trip in role.
+// Usernames must belong to an account; emails need not, so people can be
+// invited before they sign up, but only reach users whose identity provider
+// has verified them.
+func (s *MemberService) Invite(ctx context.Context, tripID int, invitee string, role domain.TripRole) (*domain.TripInvitation, error) {
+	trip, err := authorizeTrip(ctx, s.trips, tripID, accessManage)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsValidInviteRole(role) {
+		return nil, fmt.Errorf("%w: role must be editor or viewer", domain.ErrInvalidInput)
+	}
+	invitee = normalizeUsername(invitee)
+	if invitee == "" {
+		return nil, fmt.Errorf("%w: username or email is required", domain.ErrInvalidInput)
+	}
+	if !strings.Contains(invitee, "@") {
+		user, userErr := s.users.GetByUsername(ctx, invitee)
+		if errors.Is(userErr, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%w: no user is called %s", domain.ErrInvalidInput, invitee)
+		}
+		if userErr != nil {
+			return nil, fmt.Errorf("looking up %s: %w", invitee, userErr)
+		}
+		if trip.RoleOf(user.ID) != "" {
+			return nil, fmt.Errorf("%w: %s is already on this trip", domain.ErrInvalidInput, invitee)
+		}
+	}
+
+	invitation := &domain.TripInvitation{TripID: tripID, Invitee: invitee, Role: role, TripName: trip.Name}
+	if p, ok := PrincipalFromContext(ctx); ok {
+		invitation.InvitedBy = p.Username
+	}
+	if err = s.members.CreateInvitation(ctx, invitation); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return nil, fmt.Errorf("%w: %s is already invited", domain.ErrInvalidInput, invitee)
+		}
+		return nil, fmt.Errorf("inviting %s to trip %d: %w", invitee, tripID, err)
+	}
+	return invitation, nil
+}
+
+// ListInvitations returns the invitations of the trip nobody accepted yet.
+func (s *MemberService) ListInvitations(ctx context.Context, tripID int) ([]domain.TripInvitation, error) {
+	if _, err := authorizeTrip(ctx, s.trips, tripID, accessManage); err != nil {
+		return nil, err
+	}
+	return s.members.ListInvitationsByTrip(ctx, tripID)
+}
+
+// CancelInvitation withdraws an invitation before it is accepted.
+func (s *MemberService) CancelInvitation(ctx context.Context, tripID, id int) error {
+	if _, err := authorizeTrip(ctx, s.trips, tripID, accessManage); err != nil {
+		return err
+	}
+	return s.members.DeleteInvitation(ctx, tripID, id)
+}
+
+// SetRole makes a member an editor or a viewer. The owner stays the owner.
+func (s *MemberService) SetRole(ctx context.Context, tripID int, userID string, role domain.TripRole) (*domain.TripMember, error) {
+	if _, err := authorizeTrip(ctx, s.trips, tripID, accessManage); err != nil {
+		return nil, err
+	}
+	if !domain.IsValidInviteRole(role) {
+		return nil, fmt.Errorf("%w: role must be editor or viewer", domain.ErrInvalidInput)
+	}
+	return s.members.SetRole(ctx, tripID, userID, role)
+}
+
+// Remove takes userID off the trip. The owner removes anyone but
+// themselves; other members may only remove themselves, to leave the trip.
+func (s *MemberService) Remove(ctx context.Context, tripID int, userID string) error {
+	need := accessManage
+	if p, ok := PrincipalFromContext(ctx); ok && p.UserID == userID {
+		need = accessRead
+	}
+	if _, err := authorizeTrip(ctx, s.trips, tripID, need); err != nil {
+		return err
+	}
+	return s.members.Remove(ctx, tripID, userID)
+}
+
+// PendingInvitations returns the invitations addressed to the signed-in
+// user, by username or by the email of their account.
+func (s *MemberService) PendingInvitations(ctx context.Context) ([]domain.TripInvitation, error) {
+	invitees, err := s.inviteesOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.members.ListInvitationsFor(ctx, invitees)
+}
+
+// Accept makes the signed-in user a member of the invitation's trip and
+// returns that membership. An invitation to a trip they are already on is
+// just discarded.
+func (s *MemberService) Accept(ctx context.Context, id int) (*domain.TripMember, error) {
+	invitation, err := s.addressedInvitation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	p, _ := PrincipalFromContext(ctx)
+	member := &domain.TripMember{TripID: invitation.TripID, UserID: p.UserID, Username: p.Username}
+	if err = s.members.AcceptInvitation(ctx, id, member); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			// Invited both by username and by email, say
+			if err = s.members.DeleteInvitation(ctx, invitation.TripID, id); err != nil {
+				return nil, err
+			}
+			return member, nil
+		}
+		return nil, fmt.Errorf("accepting invitation %d: %w", id, err)
+	}
+	return member, nil
+}
+
+// Decline discards an invitation addressed to the signed-in user.
+func (s *MemberService) Decline(ctx context.Context, id int) error {
+	invitation, err := s.addressedInvitation(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.members.DeleteInvitation(ctx, invitation.TripID, id)
+}
+
+// addressedInvitation loads invitation id if it was sent to the signed-in
+// user. Other users' invitations are ErrNotFound.
+func (s *MemberService) addressedInvitation(ctx context.Context, id int) (*domain.TripInvitation, error) {
+	invitees, err := s.inviteesOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	invitation, err := s.members.GetInvitation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, invitee := range invitees {
+		if invitation.Invitee == invitee {
+			return invitation, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+// inviteesOf returns the names invitations to the signed-in user are
+// addressed to: the username of their local account, and their email once an
+// identity provider has verified it. Local accounts' emails are never
+// verified, so anyone could sign up with someone else's.
+func (s *MemberService) inviteesOf(ctx context.Context) ([]string, error) {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	var invitees []string
+	user, err := s.users.GetByID(ctx, p.UserID)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+	case err != nil:
+		return nil, fmt.Errorf("loading user %s: %w", p.UserID, err)
+	default:
+		invitees = append(invitees, user.Username)
+	}
+	if p.Email != "" {
+		invitees = append(invitees, normalizeUsername(p.Email))
+	}
+	return invitees, nil
+}
@@ -0,0 +1,303 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+// mockMemberRepo keeps members on the trips of a mockTripRepo, as the real
+// store does in trip_members.
+type mockMemberRepo struct {
+	trips       *mockTripRepo
+	invitations map[int]*domain.TripInvitation
+	nextID      int
+}
+
+func newMockMemberRepo(trips *mockTripRepo) *mockMemberRepo {
+	return &mockMemberRepo{trips: trips, invitations: make(map[int]*domain.TripInvitation), nextID: 1}
+}
+
+func (m *mockMemberRepo) member(tripID int, userID string) (*domain.TripMember, error) {
+	trip, ok := m.trips.trips[tripID]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	for i := range trip.Members {
+		if trip.Members[i].UserID == userID && trip.Members[i].Role != domain.RoleOwner {
+			return &trip.Members[i], nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockMemberRepo) SetRole(_ context.Context, tripID int, userID string, role domain.TripRole) (*domain.TripMember, error) {
+	member, err := m.member(tripID, userID)
+	if err != nil {
+		return nil, err
+	}
+	member.Role = role
+	return member, nil
+}
+
+func (m *mockMemberRepo) Remove(_ context.Context, tripID int, userID string) error {
+	if _, err := m.member(tripID, userID); err != nil {
+		return err
+	}
+	trip := m.trips.trips[tripID]
+	var kept []domain.TripMember
+	for _, member := range trip.Members {
+		if member.UserID != userID {
+			kept = append(kept, member)
+		}
+	}
+	trip.Members = kept
+	return nil
+}
+
+func (m *mockMemberRepo) CreateInvitation(_ context.Context, invitation *domain.TripInvitation) error {
+	for _, existing := range m.invitations {
+		if existing.TripID == invitation.TripID && existing.Invitee == invitation.Invitee {
+			return domain.ErrConflict
+		}
+	}
+	invitation.ID = m.nextID
+	invitation.CreatedAt = time.Now()
+	m.invitations[invitation.ID] = invitation
+	m.nextID++
+	return nil
+}
+
+func (m *mockMemberRepo) GetInvitation(_ context.Context, id int) (*domain.TripInvitation, error) {
+	invitation, ok := m.invitations[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return invitation, nil
+}
+
+func (m *mockMemberRepo) ListInvitationsByTrip(_ context.Context, tripID int) ([]domain.TripInvitation, error) {
+	var result []domain.TripInvitation
+	for _, invitation := range m.invitations {
+		if invitation.TripID == tripID {
+			result = append(result, *invitation)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockMemberRepo) ListInvitationsFor(_ context.Context, invitees []string) ([]domain.TripInvitation, error) {
+	var result []domain.TripInvitation
+	for _, invitation := range m.invitations {
+		for _, invitee := range invitees {
+			if invitation.Invitee == invitee {
+				result = append(result, *invitation)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *mockMemberRepo) DeleteInvitation(_ context.Context, tripID, id int) error {
+	invitation, ok := m.invitations[id]
+	if !ok || invitation.TripID != tripID {
+		return domain.ErrNotFound
+	}
+	delete(m.invitations, id)
+	return nil
+}
+
+func (m *mockMemberRepo) AcceptInvitation(_ context.Context, id int, member *domain.TripMember) error {
+	invitation, ok := m.invitations[id]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	trip := m.trips.trips[invitation.TripID]
+	if trip.RoleOf(member.UserID) != "" {
+		return domain.ErrConflict
+	}
+	member.Role = invitation.Role
+	trip.Members = append(trip.Members, *member)
+	delete(m.invitations, id)
+	return nil
+}
+
+const userC = "00000000-0000-0000-0000-00000000000c"
+
+func TestMemberService(t *testing.T) {
+	f := newTwoUsers(t)
+	members := newMockMemberRepo(f.tripRepo)
+	users := &mockUserRepo{users: []*domain.User{
+		{ID: userA, Username: "ada", Email: "ada@example.com"},
+		{ID: userC, Username: "cy"},
+	}}
+	svc := service.NewMemberService(members, f.tripRepo, users)
+	owner := service.WithPrincipal(context.Background(), service.Principal{UserID: userB, Username: "bo"})
+	ada := service.WithPrincipal(context.Background(), service.Principal{UserID: userA, Username: "ada"})
+	cy := service.WithPrincipal(context.Background(), service.Principal{UserID: userC, Username: "cy"})
+	title := "Changed"
+	updateEvent := func(ctx context.Context) error {
+		_, err := f.events.Update(ctx, f.eventB, &service.UpdateEventInput{Title: &title})
+		return err
+	}
+
+	t.Run("invitations are checked", func(t *testing.T) {
+		tests := []struct {
+			ctx     context.Context
+			wantErr error
+			name    string
+			invitee string
+			role    domain.TripRole
+		}{
+			{name: "unknown username", ctx: owner, invitee: "nobody", role: domain.RoleViewer, wantErr: domain.ErrInvalidInput},
+			{name: "owner role", ctx: owner, invitee: "ada", role: domain.RoleOwner, wantErr: domain.ErrInvalidInput},
+			{name: "empty invitee", ctx: owner, invitee: " ", role: domain.RoleViewer, wantErr: domain.ErrInvalidInput},
+			{name: "not the owner", ctx: ada, invitee: "cy", role: domain.RoleViewer, wantErr: domain.ErrNotFound},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := svc.Invite(tt.ctx, f.tripB, tt.invitee, tt.role)
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Invite() error = %v, want %v", err, tt.wantErr)
+				}
+			})
+		}
+	})
+
+	invitation, err := svc.Invite(owner, f.tripB, "Ada", domain.RoleViewer)
+	if err != nil {
+		t.Fatalf("Invite() error = %v", err)
+	}
+	if invitation.Invitee != "ada" || invitation.InvitedBy != "bo" {
+		t.Errorf("Invite() = %+v, want invitee ada invited by bo", invitation)
+	}
+	if _, err = svc.Invite(owner, f.tripB, "ada", domain.RoleEditor); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("Invite() twice error = %v, want ErrInvalidInput", err)
+	}
+
+	t.Run("invitation does not grant access", func(t *testing.T) {
+		if _, err := f.trips.GetByID(ada, f.tripB); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("GetByID() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("only the invitee can accept", func(t *testing.T) {
+		if _, err := svc.Accept(cy, invitation.ID); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Accept() error = %v, want ErrNotFound", err)
+		}
+		pending, err := svc.PendingInvitations(ada)
+		if err != nil || len(pending) != 1 {
+			t.Fatalf("PendingInvitations() = %v, %v; want 1", pending, err)
+		}
+	})
+
+	member, err := svc.Accept(ada, invitation.ID)
+	if err != nil {
+		t.Fatalf("Accept() error = %v", err)
+	}
+	if member.Role != domain.RoleViewer || member.TripID != f.tripB {
+		t.Errorf("Accept() = %+v, want viewer of trip %d", member, f.tripB)
+	}
+
+	t.Run("viewers only read", func(t *testing.T) {
+		if _, err := f.trips.GetByID(ada, f.tripB); err != nil {
+			t.Errorf("GetByID() error = %v", err)
+		}
+		if events, err := f.events.ListByTrip(ada, f.tripB); err != nil || len(events) != 1 {
+			t.Errorf("ListByTrip() = %d events, %v; want 1", len(events), err)
+		}
+		if err := updateEvent(ada); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("EventService.Update() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	if _, err = svc.SetRole(ada, f.tripB, userA, domain.RoleEditor); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("SetRole() by a viewer error = %v, want ErrNotFound", err)
+	}
+	if _, err = svc.SetRole(owner, f.tripB, userA, domain.RoleEditor); err != nil {
+		t.Fatalf("SetRole() error = %v", err)
+	}
+
+	t.Run("editors change events but do not manage the trip", func(t *testing.T) {
+		if err := updateEvent(ada); err != nil {
+			t.Errorf("EventService.Update() error = %v", err)
+		}
+		if err := f.trips.Delete(ada, f.tripB); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("TripService.Delete() error = %v, want ErrNotFound", err)
+		}
+		if _, err := f.feeds.Create(ada, f.tripB); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("CalendarFeedService.Create() error = %v, want ErrNotFound", err)
+		}
+		if _, err := svc.Invite(ada, f.tripB, "cy", domain.RoleEditor); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Invite() error = %v, want ErrNotFound", err)
+		}
+		if err := svc.Remove(ada, f.tripB, userB); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Remove() of the owner error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("email invitations reach only verified emails", func(t *testing.T) {
+		byEmail, err := svc.Invite(owner, f.tripB, "ada@example.com", domain.RoleViewer)
+		if err != nil {
+			t.Fatalf("Invite() error = %v", err)
+		}
+		impostor := service.WithPrincipal(context.Background(), service.Principal{UserID: userC, Username: "ada@example.com"})
+		for name, ctx := range map[string]context.Context{"account email": ada, "unverified token email": impostor} {
+			if pending, _ := svc.PendingInvitations(ctx); len(pending) != 0 {
+				t.Errorf("%s: PendingInvitations() = %v, want none", name, pending)
+			}
+			if _, err = svc.Accept(ctx, byEmail.ID); !errors.Is(err, domain.ErrNotFound) {
+				t.Errorf("%s: Accept() error = %v, want ErrNotFound", name, err)
+			}
+		}
+		if err = svc.CancelInvitation(owner, f.tripB, byEmail.ID); err != nil {
+			t.Fatalf("CancelInvitation() error = %v", err)
+		}
+	})
+
+	t.Run("a second invitation to a member is discarded", func(t *testing.T) {
+		byEmail, err := svc.Invite(owner, f.tripB, "ada@example.com", domain.RoleViewer)
+		if err != nil {
+			t.Fatalf("Invite() error = %v", err)
+		}
+		verified := service.WithPrincipal(ada, service.Principal{UserID: userA, Username: "ada", Email: "Ada@example.com"})
+		if _, err = svc.Accept(verified, byEmail.ID); err != nil {
+			t.Fatalf("Accept() error = %v", err)
+		}
+		if pending, _ := svc.PendingInvitations(ada); len(pending) != 0 {
+			t.Errorf("PendingInvitations() = %v, want none", pending)
+		}
+		if trip, _ := f.trips.GetByID(ada, f.tripB); trip.RoleOf(userA) != domain.RoleEditor {
+			t.Errorf("role = %q, want editor kept", trip.RoleOf(userA))
+		}
+	})
+
+	t.Run("declined invitations are gone", func(t *testing.T) {
+		toCy, err := svc.Invite(owner, f.tripB, "cy", domain.RoleViewer)
+		if err != nil {
+			t.Fatalf("Invite() error = %v", err)
+		}
+		if err = svc.Decline(cy, toCy.ID); err != nil {
+			t.Fatalf("Decline() error = %v", err)
+		}
+		if _, err = svc.Accept(cy, toCy.ID); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Accept() after Decline() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("members can leave", func(t *testing.T) {
+		if err := svc.Remove(ada, f.tripB, userA); err != nil {
+			t.Fatalf("Remove() error = %v", err)
+		}
+		if _, err := f.trips.GetByID(ada, f.tripB); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("GetByID() after leaving error = %v, want ErrNotFound", err)
+		}
+		if err := svc.Remove(owner, f.tripB, userB); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Remove() of the owner error = %v, want ErrNotFound", err)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package service
+
+import "context"
+
+// Principal is the user a request acts for.
+type Principal struct {
+	UserID   string // UUID
+	Username string
+	Email    string // only set once an identity provider has verified it
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a context acting for p.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the user ctx acts for, if anyone is signed in.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok && p.UserID != ""
+}
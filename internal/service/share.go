@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+// shareTokenBytes is the entropy of a share token, which alone guards the
+// shared trip.
+const shareTokenBytes = 32
+
+// ShareService manages read-only links to trips for people without an account.
+type ShareService struct {
+	shares domain.TripShareRepository
+	trips  domain.TripRepository
+	now    func() time.Time
+}
+
+func NewShareService(shares domain.TripShareRepository, trips domain.TripRepository) *ShareService {
+	return &ShareService{shares: shares, trips: trips, now: time.Now}
+}
+
+// WithClock replaces the service's clock, for tests of link expiry.
+func (s *ShareService) WithClock(now func() time.Time) *ShareService {
+	s.now = now
+	return s
+}
+
+// Create issues a new share link for the trip, expiring at expiresAt unless
+// that is nil.
+func (s *ShareService) Create(ctx context.Context, tripID int, expiresAt *time.Time) (*domain.TripShare, error) {
+	if _, err := authorizeTrip(ctx, s.trips, tripID, accessManage); err != nil {
+		return nil, fmt.Errorf("loading trip %d: %w", tripID, err)
+	}
+	if err := s.checkExpiry(expiresAt); err != nil {
+		return nil, err
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return nil, err
+	}
+	share := &domain.TripShare{TripID: tripID, Token: token, ExpiresAt: expiresAt}
+	if err = s.shares.Create(ctx, share); err != nil {
+		return nil, fmt.Errorf("creating share for trip %d: %w", tripID, err)
+	}
+	return share, nil
+}
+
+func (s *ShareService) ListByTrip(ctx context.Context, tripID int) ([]domain.TripShare, error) {
+	if _, err := authorizeTrip(ctx, s.trips, tripID, accessManage); err != nil {
+		return nil, err
+	}
+	return s.shares.ListByTrip(ctx, tripID)
+}
+
+// Revoke deletes a share link; opening it gives 404 from then on.
+func (s *ShareService) Revoke(ctx context.Context, tripID, id int) error {
+	if _, err := authorizeTrip(ctx, s.trips, tripID, accessManage); err != nil {
+		return err
+	}
+	return s.shares.Delete(ctx, tripID, id)
+}
+
+// Rotate gives a share link a new token, for when the old one reached people
+// it should not have. The expiry is kept.
+func (s *ShareService) Rotate(ctx context.Context, tripID, id int) (*domain.TripShare, error) {
+	if _, err := authorizeTrip(ctx, s.trips, tripID, accessManage); err != nil {
+		return nil, err
+	}
+	token, err := newShareToken()
+	if err != nil {
+		return nil, err
+	}
+	return s.shares.Rotate(ctx, tripID, id, token)
+}
+
+// SetExpiry changes when a share link stops working; nil keeps it working
+// until it is revoked.
+func (s *ShareService) SetExpiry(ctx context.Context, tripID, id int, expiresAt *time.Time) (*domain.TripShare, error) {
+	if _, err := authorizeTrip(ctx, s.trips, tripID, accessManage); err != nil {
+		return nil, err
+	}
+	if err := s.checkExpiry(expiresAt); err != nil {
+		return nil, err
+	}
+	return s.shares.SetExpiry(ctx, tripID, id, expiresAt)
+}
+
+// Open resolves a share token to its trip and returns a context that may read
+// that trip, and only read it, through the other services. Unknown, revoked
+// and expired tokens return ErrNotFound.
+func (s *ShareService) Open(ctx context.Context, token string) (context.Context, *domain.Trip, error) {
+	if token == "" {
+		return nil, nil, domain.ErrNotFound
+	}
+	share, err := s.shares.GetByToken(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if share.Expired(s.now()) {
+		return nil, nil, domain.ErrNotFound
+	}
+	shared := withShareGrant(ctx, share.TripID)
+	trip, err := authorizeTrip(shared, s.trips, share.TripID, accessRead)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading trip %d: %w", share.TripID, err)
+	}
+	return shared, trip, nil
+}
+
+func (s *ShareService) checkExpiry(expiresAt *time.Time) error {
+	if expiresAt != nil && !expiresAt.After(s.now()) {
+		return fmt.Errorf("%w: expiry must be in the future", domain.ErrInvalidInput)
+	}
+	return nil
+}
+
+func newShareToken() (string, error) {
+	b := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating share token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,204 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+type mockShareRepo struct {
+	shares map[int]*domain.TripShare
+	nextID int
+}
+
+func newMockShareRepo() *mockShareRepo {
+	return &mockShareRepo{shares: make(map[int]*domain.TripShare), nextID: 1}
+}
+
+func (m *mockShareRepo) Create(_ context.Context, share *domain.TripShare) error {
+	share.ID = m.nextID
+	share.CreatedAt = time.Now()
+	m.shares[share.ID] = share
+	m.nextID++
+	return nil
+}
+
+func (m *mockShareRepo) GetByToken(_ context.Context, token string) (*domain.TripShare, error) {
+	for _, share := range m.shares {
+		if share.Token == token {
+			return share, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockShareRepo) ListByTrip(_ context.Context, tripID int) ([]domain.TripShare, error) {
+	var result []domain.TripShare
+	for _, share := range m.shares {
+		if share.TripID == tripID {
+			result = append(result, *share)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockShareRepo) get(tripID, id int) (*domain.TripShare, error) {
+	share, ok := m.shares[id]
+	if !ok || share.TripID != tripID {
+		return nil, domain.ErrNotFound
+	}
+	return share, nil
+}
+
+func (m *mockShareRepo) Delete(_ context.Context, tripID, id int) error {
+	if _, err := m.get(tripID, id); err != nil {
+		return err
+	}
+	delete(m.shares, id)
+	return nil
+}
+
+func (m *mockShareRepo) Rotate(_ context.Context, tripID, id int, token string) (*domain.TripShare, error) {
+	share, err := m.get(tripID, id)
+	if err != nil {
+		return nil, err
+	}
+	share.Token = token
+	return share, nil
+}
+
+func (m *mockShareRepo) SetExpiry(_ context.Context, tripID, id int, expiresAt *time.Time) (*domain.TripShare, error) {
+	share, err := m.get(tripID, id)
+	if err != nil {
+		return nil, err
+	}
+	share.ExpiresAt = expiresAt
+	return share, nil
+}
+
+func TestShareService(t *testing.T) {
+	f := newTwoUsers(t)
+	now := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+	shares := service.NewShareService(newMockShareRepo(), f.tripRepo).WithClock(func() time.Time { return now })
+	owner := actingAs(userB)
+
+	share, err := shares.Create(owner, f.tripB, nil)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if len(share.Token) < 40 {
+		t.Errorf("Create() token %q is too short to be unguessable", share.Token)
+	}
+
+	t.Run("opening grants read access to the trip", func(t *testing.T) {
+		ctx, trip, err := shares.Open(context.Background(), share.Token)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		if trip.ID != f.tripB {
+			t.Errorf("Open() trip = %d, want %d", trip.ID, f.tripB)
+		}
+		events, err := f.events.ListByTrip(ctx, f.tripB)
+		if err != nil || len(events) != 1 {
+			t.Errorf("ListByTrip() = %d events, %v; want 1", len(events), err)
+		}
+	})
+
+	t.Run("opening grants no changes", func(t *testing.T) {
+		ctx, _, err := shares.Open(context.Background(), share.Token)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		title := "Changed"
+		if _, err := f.events.Update(ctx, f.eventB, &service.UpdateEventInput{Title: &title}); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("EventService.Update() error = %v, want ErrNotFound", err)
+		}
+		if err := f.trips.Delete(ctx, f.tripB); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("TripService.Delete() error = %v, want ErrNotFound", err)
+		}
+		if _, err := shares.Create(ctx, f.tripB, nil); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("ShareService.Create() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("opening grants nothing on other trips", func(t *testing.T) {
+		ctx, _, err := shares.Open(context.Background(), share.Token)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		if _, err := f.trips.GetByID(ctx, f.tripA); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("GetByID() of another trip error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("unknown token is not found", func(t *testing.T) {
+		if _, _, err := shares.Open(context.Background(), "nope"); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Open() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("other users cannot manage shares", func(t *testing.T) {
+		ctx := actingAs(userA)
+		if _, err := shares.Create(ctx, f.tripB, nil); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Create() error = %v, want ErrNotFound", err)
+		}
+		if _, err := shares.ListByTrip(ctx, f.tripB); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("ListByTrip() error = %v, want ErrNotFound", err)
+		}
+		if err := shares.Revoke(ctx, f.tripB, share.ID); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Revoke() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("expiry must be in the future", func(t *testing.T) {
+		past := now.Add(-time.Hour)
+		if _, err := shares.Create(owner, f.tripB, &past); !errors.Is(err, domain.ErrInvalidInput) {
+			t.Errorf("Create() error = %v, want ErrInvalidInput", err)
+		}
+	})
+
+	t.Run("expired link is not found", func(t *testing.T) {
+		expiry := now.Add(time.Hour)
+		expiring, err := shares.Create(owner, f.tripB, &expiry)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if _, _, err = shares.Open(context.Background(), expiring.Token); err != nil {
+			t.Errorf("Open() before expiry error = %v", err)
+		}
+		now = now.Add(2 * time.Hour)
+		if _, _, err = shares.Open(context.Background(), expiring.Token); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Open() after expiry error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("rotating replaces the link", func(t *testing.T) {
+		oldToken := share.Token
+		rotated, err := shares.Rotate(owner, f.tripB, share.ID)
+		if err != nil {
+			t.Fatalf("Rotate() error = %v", err)
+		}
+		if rotated.Token == oldToken {
+			t.Fatal("Rotate() kept the token")
+		}
+		if _, _, err = shares.Open(context.Background(), oldToken); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Open() of old token error = %v, want ErrNotFound", err)
+		}
+		if _, _, err = shares.Open(context.Background(), rotated.Token); err != nil {
+			t.Errorf("Open() of new token error = %v", err)
+		}
+	})
+
+	t.Run("revoked link is not found", func(t *testing.T) {
+		if err := shares.Revoke(owner, f.tripB, share.ID); err != nil {
+			t.Fatalf("Revoke() error = %v", err)
+		}
+		if _, _, err := shares.Open(context.Background(), share.Token); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Open() error = %v, want ErrNotFound", err)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+)
+
+// TravelService analyses the connective tissue of a timeline: whether there is
+// enough time to get from one event to the next.
+type TravelService struct {
+	estimator         domain.TravelTimeEstimator
+	modes             []domain.TravelMode
+	minConnectionTime time.Duration
+}
+
+// DefaultMinConnectionTime is the shortest layover between two flights that is
+// not flagged, unless the service is given another.
+const DefaultMinConnectionTime = time.Hour
+
+// NewTravelService returns a service that considers walking and public transit,
+// using whichever of the two is faster for each connection.
+func NewTravelService(estimator domain.TravelTimeEstimator) *TravelService {
+	return &TravelService{
+		estimator:         estimator,
+		modes:             []domain.TravelMode{domain.TravelWalking, domain.TravelTransit},
+		minConnectionTime: DefaultMinConnectionTime,
+	}
+}
+
+// WithMinConnectionTime sets the shortest layover between two flights that is
+// not flagged as short.
+func (s *TravelService) WithMinConnectionTime(d time.Duration) *TravelService {
+	s.minConnectionTime = d
+	return s
+}
+
+// Layovers returns the layovers of each connecting flight among events, keyed
+// by event ID, flagging those shorter than the minimum connection time.
+// Changing airport on a layover is no shortcut: the same minimum applies.
+func (s *TravelService) Layovers(events []domain.Event) map[int][]domain.Layover {
+	layovers := make(map[int][]domain.Layover)
+	for i := range events {
+		if events[i].Flight == nil {
+			continue
+		}
+		found := events[i].Flight.Layovers()
+		if len(found) == 0 {
+			continue
+		}
+		for j := range found {
+			found[j].Short = found[j].Duration < s.minConnectionTime
+		}
+		layovers[events[i].ID] = found
+	}
+	return layovers
+}
+
+// TightConnections walks a day's events in timeline order and flags every pair of
+// consecutive events whose gap is shorter than the fastest travel estimate.
+// Events without coordinates cannot be measured, and flights and transit are
+// journeys themselves rather than places to get to, so neither is compared with
+// the events either side of it. Overlapping events are left to DetectConflicts.
+func (s *TravelService) TightConnections(ctx context.Context, events []domain.Event) ([]domain.TightConnection, error) {
+	connections := []domain.TightConnection{}
+	var prev *domain.Event
+	for i := range events {
+		e := &events[i]
+		if e.Latitude == nil || e.Longitude == nil || e.Category == domain.CategoryFlight || e.Category == domain.CategoryTransit {
+			prev = nil
+			continue
+		}
+		if prev != nil {
+			gap := e.StartTime.Sub(prev.EndTime)
+			if gap >= 0 {
+				conn, err := s.fastest(ctx, prev, e)
+				if err != nil {
+					return nil, err
+				}
+				if gap < conn.Estimate {
+					conn.Gap = gap
+					connections = append(connections, conn)
+				}
+			}
+		}
+		prev = e
+	}
+	return connections, nil
+}
+
+func (s *TravelService) fastest(ctx context.Context, from, to *domain.Event) (domain.TightConnection, error) {
+	origin := domain.Coordinates{Latitude: *from.Latitude, Longitude: *from.Longitude}
+	destination := domain.Coordinates{Latitude: *to.Latitude, Longitude: *to.Longitude}
+
+	best := domain.TightConnection{FromID: from.ID, ToID: to.ID}
+	for i, mode := range s.modes {
+		estimate, err := s.estimator.Estimate(ctx, origin, destination, mode)
+		if err != nil {
+			return best, fmt.Errorf("estimating %s time from event %d to %d: %w", mode, from.ID, to.ID, err)
+		}
+		if i == 0 || estimate < best.Estimate {
+			best.Mode = mode
+			best.Estimate = estimate
+		}
+	}
+	return best, nil
+}
@@ -0,0 +1,170 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/simopzz/traccia/internal/domain"
+	"github.com/simopzz/traccia/internal/service"
+)
+
+// fixedEstimator returns a fixed duration per mode regardless of distance.
+type fixedEstimator struct {
+	err       error
+	estimates map[domain.TravelMode]time.Duration
+}
+
+func (f *fixedEstimator) Estimate(_ context.Context, _, _ domain.Coordinates, mode domain.TravelMode) (time.Duration, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	return f.estimates[mode], nil
+}
+
+func ptr(f float64) *float64 { return &f }
+
+func TestTravelService_TightConnections(t *testing.T) {
+	estimator := &fixedEstimator{estimates: map[domain.TravelMode]time.Duration{
+		domain.TravelWalking: 40 * time.Minute,
+		domain.TravelTransit: 25 * time.Minute,
+	}}
+	located := func(id int, start, end time.Time) domain.Event {
+		return domain.Event{ID: id, StartTime: start, EndTime: end, Latitude: ptr(41.89), Longitude: ptr(12.49)}
+	}
+
+	tests := []struct {
+		name   string
+		events []domain.Event
+		want   []domain.TightConnection
+	}{
+		{
+			name: "gap shorter than fastest mode is flagged",
+			events: []domain.Event{
+				located(1, at(9, 0), at(10, 0)),
+				located(2, at(10, 15), at(11, 0)),
+			},
+			want: []domain.TightConnection{
+				{FromID: 1, ToID: 2, Mode: domain.TravelTransit, Gap: 15 * time.Minute, Estimate: 25 * time.Minute},
+			},
+		},
+		{
+			name: "enough time is not flagged",
+			events: []domain.Event{
+				located(1, at(9, 0), at(10, 0)),
+				located(2, at(10, 30), at(11, 0)),
+			},
+			want: []domain.TightConnection{},
+		},
+		{
+			name: "events without coordinates are skipped",
+			events: []domain.Event{
+				located(1, at(9, 0), at(10, 0)),
+				{ID: 2, StartTime: at(10, 5), EndTime: at(10, 10)},
+				located(3, at(10, 15), at(12, 0)),
+			},
+			want: []domain.TightConnection{},
+		},
+		{
+			name: "transit between places is skipped",
+			events: []domain.Event{
+				located(1, at(9, 0), at(10, 0)),
+				{ID: 2, Category: domain.CategoryTransit, StartTime: at(10, 0), EndTime: at(10, 20), Latitude: ptr(1), Longitude: ptr(1)},
+				located(3, at(10, 20), at(11, 0)),
+			},
+			want: []domain.TightConnection{},
+		},
+		{
+			name: "a flight separates the places either side of it",
+			events: []domain.Event{
+				located(1, at(9, 0), at(10, 0)),
+				{ID: 2, Category: domain.CategoryFlight, StartTime: at(10, 0), EndTime: at(10, 15), Latitude: ptr(41.8), Longitude: ptr(12.25)},
+				{ID: 3, StartTime: at(10, 20), EndTime: at(12, 0), Latitude: ptr(48.86), Longitude: ptr(2.35)},
+			},
+			want: []domain.TightConnection{},
+		},
+		{
+			name: "overlapping events are left to conflict detection",
+			events: []domain.Event{
+				located(1, at(9, 0), at(10, 30)),
+				located(2, at(10, 0), at(11, 0)),
+			},
+			want: []domain.TightConnection{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := service.NewTravelService(estimator)
+			got, err := svc.TightConnections(context.Background(), tt.events)
+			if err != nil {
+				t.Fatalf("TightConnections() unexpected error: %v", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("TightConnections() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTravelService_TightConnections_EstimatorError(t *testing.T) {
+	svc := service.NewTravelService(&fixedEstimator{err: errors.New("boom")})
+	events := []domain.Event{
+		{ID: 1, StartTime: at(9, 0), EndTime: at(10, 0), Latitude: ptr(1), Longitude: ptr(1)},
+		{ID: 2, StartTime: at(10, 0), EndTime: at(11, 0), Latitude: ptr(2), Longitude: ptr(2)},
+	}
+	if _, err := svc.TightConnections(context.Background(), events); err == nil {
+		t.Fatal("TightConnections() expected error from estimator")
+	}
+}
+
+func TestTravelService_Layovers(t *testing.T) {
+	leg := func(from, to string, dep, arr time.Time) domain.FlightSegment {
+		return domain.FlightSegment{DepartureAirport: from, ArrivalAirport: to, DepartureTime: dep, ArrivalTime: arr}
+	}
+	events := []domain.Event{
+		{ID: 1, Category: domain.CategoryFlight, Flight: &domain.FlightDetails{Segments: []domain.FlightSegment{
+			leg("LHR", "FRA", at(7, 0), at(8, 40)),
+			leg("FRA", "MUC", at(9, 15), at(10, 10)),
+			leg("MUC", "NRT", at(12, 0), at(23, 0)),
+		}}},
+		{ID: 2, Category: domain.CategoryFlight, Flight: &domain.FlightDetails{DepartureAirport: "NRT", ArrivalAirport: "ITM"}},
+		{ID: 3, Category: domain.CategoryActivity},
+	}
+
+	tests := []struct {
+		name      string
+		minimum   time.Duration
+		wantShort []bool
+	}{
+		{name: "default minimum", wantShort: []bool{true, false}},
+		{name: "configured minimum", minimum: 2 * time.Hour, wantShort: []bool{true, true}},
+		{name: "shorter minimum", minimum: 30 * time.Minute, wantShort: []bool{false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := service.NewTravelService(&fixedEstimator{})
+			if tt.minimum != 0 {
+				svc.WithMinConnectionTime(tt.minimum)
+			}
+			layovers := svc.Layovers(events)
+			if len(layovers) != 1 {
+				t.Fatalf("Layovers() = %v, want only event 1", layovers)
+			}
+			got := layovers[1]
+			if len(got) != 2 {
+				t.Fatalf("event 1 layovers = %v, want 2", got)
+			}
+			if got[0].ArrivalAirport != "FRA" || got[0].Duration != 35*time.Minute || got[1].Duration != 110*time.Minute {
+				t.Errorf("event 1 layovers = %+v", got)
+			}
+			for i := range got {
+				if got[i].Short != tt.wantShort[i] {
+					t.Errorf("layover %d Short = %v, want %v", i, got[i].Short, tt.wantShort[i])
+				}
+			}
+		})
+	}
+}
@@ -21,6 +21,8 @@ type CreateTripInput struct {
 	EndDate     time.Time
 	Name        string
 	Destination string
+	// TimeZone is the IANA zone the trip's events default to. Empty means UTC.
+	TimeZone string
 }
 
 func (s *TripService) Create(ctx context.Context, input *CreateTripInput) (*domain.Trip, error) {
@@ -37,11 +39,24 @@ func (s *TripService) Create(ctx context.Context, input *CreateTripInput) (*doma
 		return nil, fmt.Errorf("%w: end date must be on or after start date", domain.ErrInvalidInput)
 	}
 
+	timeZone := input.TimeZone
+	if timeZone == "" {
+		timeZone = domain.DefaultTimeZone
+	}
+	if _, err := domain.LoadTimeZone(timeZone); err != nil {
+		return nil, err
+	}
+
 	trip := &domain.Trip{
 		Name:        input.Name,
 		Destination: input.Destination,
 		StartDate:   input.StartDate,
 		EndDate:     input.EndDate,
+		TimeZone:    timeZone,
+	}
+	if p, ok := PrincipalFromContext(ctx); ok {
+		trip.UserID = p.UserID
+		trip.Members = []domain.TripMember{{UserID: p.UserID, Username: p.Username, Role: domain.RoleOwner}}
 	}
 
 	if err := s.repo.Create(ctx, trip); err != nil {
@@ -51,11 +66,18 @@ func (s *TripService) Create(ctx context.Context, input *CreateTripInput) (*doma
 	return trip, nil
 }
 
+// GetByID returns a trip the caller is on; other trips are ErrNotFound.
 func (s *TripService) GetByID(ctx context.Context, id int) (*domain.Trip, error) {
-	return s.repo.GetByID(ctx, id)
+	return authorizeTrip(ctx, s.repo, id, accessRead)
 }
 
-func (s *TripService) List(ctx context.Context, userID *string) ([]domain.Trip, error) {
+// List returns the trips the caller is on, in any role. Callers without a
+// principal list the trips that have no owner, as RoleOf lets them reach.
+func (s *TripService) List(ctx context.Context) ([]domain.Trip, error) {
+	var userID *string
+	if p, ok := PrincipalFromContext(ctx); ok {
+		userID = &p.UserID
+	}
 	return s.repo.List(ctx, userID)
 }
 
@@ -64,6 +86,9 @@ type UpdateTripInput struct {
 	Destination *string
 	StartDate   *time.Time
 	EndDate     *time.Time
+	// TimeZone changes the default zone of new events. Existing events keep
+	// the zone they were entered in.
+	TimeZone *string
 }
 
 func (s *TripService) Update(ctx context.Context, id int, input UpdateTripInput) (*domain.Trip, error) {
@@ -79,13 +104,22 @@ func (s *TripService) Update(ctx context.Context, id int, input UpdateTripInput)
 	if input.StartDate != nil && input.EndDate != nil && input.EndDate.Before(*input.StartDate) {
 		return nil, fmt.Errorf("%w: end date must be on or after start date", domain.ErrInvalidInput)
 	}
+	if input.TimeZone != nil {
+		if *input.TimeZone == "" {
+			return nil, fmt.Errorf("%w: time zone is required", domain.ErrInvalidInput)
+		}
+		if _, err := domain.LoadTimeZone(*input.TimeZone); err != nil {
+			return nil, err
+		}
+	}
+
+	current, err := authorizeTrip(ctx, s.repo, id, accessEdit)
+	if err != nil {
+		return nil, err
+	}
 
 	// Validate date range shrink if dates are changing
 	if input.StartDate != nil || input.EndDate != nil {
-		current, err := s.repo.GetByID(ctx, id)
-		if err != nil {
-			return nil, err
-		}
 		newStart := current.StartDate
 		if input.StartDate != nil {
 			newStart = *input.StartDate
@@ -112,12 +146,16 @@ func (s *TripService) Update(ctx context.Context, id int, input UpdateTripInput)
 		if input.EndDate != nil {
 			trip.EndDate = *input.EndDate
 		}
+		if input.TimeZone != nil {
+			trip.TimeZone = *input.TimeZone
+		}
 		return trip
 	})
 }
 
 // ValidateDateRangeShrink checks if shrinking a trip's date range would exclude days with events.
 // It only queries the database when the range actually shrinks (new start after old start or new end before old end).
+// The caller must already have authorized the trip.
 func (s *TripService) ValidateDateRangeShrink(ctx context.Context, tripID int, oldStart, oldEnd, newStart, newEnd time.Time) error {
 	// Only validate when range actually shrinks
 	if !newStart.After(oldStart) && !newEnd.Before(oldEnd) {
@@ -144,5 +182,8 @@ func (s *TripService) ValidateDateRangeShrink(ctx context.Context, tripID int, o
 }
 
 func (s *TripService) Delete(ctx context.Context, id int) error {
+	if _, err := authorizeTrip(ctx, s.repo, id, accessManage); err != nil {
+		return err
+	}
 	return s.repo.Delete(ctx, id)
 }
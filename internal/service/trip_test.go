@@ -45,10 +45,12 @@ func (m *mockTripRepo) GetByID(_ context.Context, id int) (*domain.Trip, error)
 	return &cp, nil
 }
 
-func (m *mockTripRepo) List(_ context.Context, _ *string) ([]domain.Trip, error) {
+func (m *mockTripRepo) List(_ context.Context, userID *string) ([]domain.Trip, error) {
 	result := make([]domain.Trip, 0, len(m.trips))
 	for _, t := range m.trips {
-		result = append(result, *t)
+		if userID == nil && t.UserID == "" || userID != nil && t.RoleOf(*userID) != "" {
+			result = append(result, *t)
+		}
 	}
 	return result, nil
 }
@@ -154,6 +156,26 @@ func TestTripService_Create(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "valid trip with time zone",
+			input: &service.CreateTripInput{
+				Name:      "Tokyo Trip",
+				TimeZone:  "Asia/Tokyo",
+				StartDate: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2026, 5, 5, 0, 0, 0, 0, time.UTC),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unknown time zone",
+			input: &service.CreateTripInput{
+				Name:      "Rome Trip",
+				TimeZone:  "Europe/Atlantis",
+				StartDate: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2026, 5, 5, 0, 0, 0, 0, time.UTC),
+			},
+			wantErr: domain.ErrInvalidInput,
+		},
 	}
 
 	for _, tt := range tests {
@@ -180,6 +202,13 @@ func TestTripService_Create(t *testing.T) {
 			if trip.Name != tt.input.Name {
 				t.Errorf("Create() Name = %q, want %q", trip.Name, tt.input.Name)
 			}
+			wantZone := tt.input.TimeZone
+			if wantZone == "" {
+				wantZone = domain.DefaultTimeZone
+			}
+			if trip.TimeZone != wantZone {
+				t.Errorf("Create() TimeZone = %q, want %q", trip.TimeZone, wantZone)
+			}
 		})
 	}
 }
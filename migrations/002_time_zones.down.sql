@@ -0,0 +1,4 @@
+ALTER TABLE flight_details DROP COLUMN IF EXISTS arrival_time_zone;
+ALTER TABLE flight_details DROP COLUMN IF EXISTS departure_time_zone;
+ALTER TABLE events DROP COLUMN IF EXISTS time_zone;
+ALTER TABLE trips DROP COLUMN IF EXISTS time_zone;
@@ -0,0 +1,9 @@
+ALTER TABLE trips ADD COLUMN time_zone TEXT NOT NULL DEFAULT 'UTC';
+
+-- Every event carries the zone its times were entered in; new events default
+-- to the trip's zone.
+ALTER TABLE events ADD COLUMN time_zone TEXT NOT NULL DEFAULT 'UTC';
+
+-- Empty means the flight departs or arrives in the event's own zone.
+ALTER TABLE flight_details ADD COLUMN departure_time_zone TEXT DEFAULT '';
+ALTER TABLE flight_details ADD COLUMN arrival_time_zone TEXT DEFAULT '';
@@ -0,0 +1,10 @@
+-- Secret subscription tokens for per-trip calendar feeds. Revoking a feed
+-- deletes its row.
+CREATE TABLE calendar_feeds (
+    id SERIAL PRIMARY KEY,
+    trip_id INTEGER NOT NULL REFERENCES trips(id) ON DELETE CASCADE,
+    token TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_calendar_feeds_trip_id ON calendar_feeds(trip_id);
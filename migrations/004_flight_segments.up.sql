@@ -0,0 +1,20 @@
+-- The legs of a connecting flight itinerary, in flying order. A direct
+-- flight has none; its flight_details row describes the whole flight.
+CREATE TABLE flight_segments (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES flight_details(event_id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    airline TEXT DEFAULT '',
+    flight_number TEXT DEFAULT '',
+    departure_airport TEXT DEFAULT '',
+    arrival_airport TEXT DEFAULT '',
+    departure_terminal TEXT DEFAULT '',
+    arrival_terminal TEXT DEFAULT '',
+    departure_gate TEXT DEFAULT '',
+    arrival_gate TEXT DEFAULT '',
+    departure_time TIMESTAMPTZ NOT NULL,
+    arrival_time TIMESTAMPTZ NOT NULL,
+    departure_time_zone TEXT DEFAULT '',
+    arrival_time_zone TEXT DEFAULT '',
+    UNIQUE (event_id, position)
+);
@@ -0,0 +1,11 @@
+-- Read-only share links. Like calendar feeds, the token is the only secret,
+-- so revoking a link deletes its row and rotating it replaces the token.
+CREATE TABLE trip_shares (
+    id SERIAL PRIMARY KEY,
+    trip_id INTEGER NOT NULL REFERENCES trips(id) ON DELETE CASCADE,
+    token TEXT NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_trip_shares_trip_id ON trip_shares(trip_id);
@@ -0,0 +1,34 @@
+-- Who is on a trip and in which role. user_id has no foreign key, like
+-- trips.user_id: users signed in through an identity provider have no row in
+-- users. username is kept so members can be listed without one.
+CREATE TABLE trip_members (
+    trip_id INTEGER NOT NULL REFERENCES trips(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    username TEXT NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (trip_id, user_id)
+);
+
+CREATE INDEX idx_trip_members_user_id ON trip_members(user_id);
+CREATE UNIQUE INDEX idx_trip_members_owner ON trip_members(trip_id) WHERE role = 'owner';
+
+INSERT INTO trip_members (trip_id, user_id, username, role)
+SELECT trips.id, trips.user_id, COALESCE(users.username, trips.user_id::text), 'owner'
+FROM trips
+LEFT JOIN users ON users.id = trips.user_id
+WHERE trips.user_id IS NOT NULL;
+
+-- Invitations are addressed to a username or an email, so people can be
+-- invited before they have an account.
+CREATE TABLE trip_invitations (
+    id SERIAL PRIMARY KEY,
+    trip_id INTEGER NOT NULL REFERENCES trips(id) ON DELETE CASCADE,
+    invitee TEXT NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('editor', 'viewer')),
+    invited_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (trip_id, invitee)
+);
+
+CREATE INDEX idx_trip_invitations_invitee ON trip_invitations(invitee);